@@ -0,0 +1,142 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+)
+
+// AnnualToQuarterly converts annual values (e.g. median household income) to a quarterly series.  Each
+// year's value is taken to be the value at Q2 and the quarters between are interpolated log-linearly.
+// Q1 of the first year and Q3 and Q4 of the last year take the value of that year.  years must be
+// consecutive and vals positive.
+func AnnualToQuarterly(geoName, geoCode string, years []int, vals []float64) (*HPIseries, error) {
+	if len(years) == 0 || len(years) != len(vals) {
+		return nil, fmt.Errorf("years and vals don't agree")
+	}
+
+	for j, v := range vals {
+		if v <= 0 {
+			return nil, fmt.Errorf("non-positive value in year %d", years[j])
+		}
+
+		if j > 0 && years[j] != years[j-1]+1 {
+			return nil, fmt.Errorf("years not consecutive at %d", years[j])
+		}
+	}
+
+	dts := []int{10*years[0] + 1}
+	indx := []float64{vals[0]}
+	for j, yr := range years {
+		dts = append(dts, 10*yr+2)
+		indx = append(indx, vals[j])
+
+		if j == len(years)-1 {
+			break
+		}
+
+		// log-linear steps to next year's Q2
+		step := math.Log(vals[j+1]/vals[j]) / 4
+		for q := 1; q <= 3; q++ {
+			dts = append(dts, AddQtrs(10*yr+2, q))
+			indx = append(indx, vals[j]*math.Exp(step*float64(q)))
+		}
+	}
+
+	last := years[len(years)-1]
+	dts = append(dts, 10*last+3, 10*last+4)
+	indx = append(indx, vals[len(vals)-1], vals[len(vals)-1])
+
+	return NewHPIseries(geoName, geoCode, dts, indx)
+}
+
+// PriceToIncome returns the ratio of house price to income for each quarter of h that is in income.  The
+// price level is set by price, the price (e.g. median home value) at priceDt (CCYYQ), and moves with h.
+// income must be quarterly (see AnnualToQuarterly) and in the same units as price.
+func (h *HPIseries) PriceToIncome(income *HPIseries, price float64, priceDt int) (*HPIseries, error) {
+	base, e := h.Index(priceDt)
+	if e != nil {
+		return nil, e
+	}
+
+	if price <= 0 {
+		return nil, fmt.Errorf("price must be positive")
+	}
+
+	var (
+		dts  []int
+		indx []float64
+	)
+
+	lastDt, lastIndx := 0, 0.0
+	for j, dt := range h.dates {
+		k := income.exact(dt)
+		if k < 0 || income.indx[k] <= 0 {
+			continue
+		}
+
+		v := price * h.indx[j] / base / income.indx[k]
+		if len(dts) > 0 && dt != NextQtr(dts[len(dts)-1]) {
+			return nil, fmt.Errorf("income is missing quarter %d", PrevQtr(dt))
+		}
+
+		dts = append(dts, dt)
+		indx = append(indx, v)
+
+		if dt <= h.lastDt {
+			lastDt, lastIndx = dt, v
+		}
+	}
+
+	if len(dts) == 0 {
+		return nil, fmt.Errorf("no overlap between series and income")
+	}
+
+	rs, e := NewHPIseries(h.geoName, h.geoCode, dts, indx)
+	if e != nil {
+		return nil, e
+	}
+
+	if lastDt > 0 {
+		rs.lastDt, rs.lastIndx = lastDt, lastIndx
+	}
+
+	rs.carryFlags(h)
+
+	return rs, nil
+}
+
+// PriceToIncome returns the price-to-income ratio history of each geo in hd that has both an income series in
+// income and a price in prices.  prices are the prices at priceDt (CCYYQ), keyed by geo; see
+// HPIseries.PriceToIncome.  Geos that can't be computed are reported in errs.
+func (hd *HPIdata) PriceToIncome(income *HPIdata, prices map[string]float64, priceDt int) (ratios *HPIdata, errs map[string]error) {
+	ratios = &HPIdata{
+		source:   hd.source,
+		geoLevel: hd.geoLevel,
+		series:   make(map[string]*HPIseries),
+	}
+
+	errs = make(map[string]error)
+	for geo, s := range hd.series {
+		inc, e := income.Geo(geo)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		price, ok := prices[geo]
+		if !ok {
+			errs[geo] = fmt.Errorf("no price for geo %s", geo)
+			continue
+		}
+
+		r, e := s.PriceToIncome(inc, price, priceDt)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		ratios.series[geo] = r
+	}
+
+	return ratios, errs
+}
@@ -0,0 +1,54 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnualToQuarterly(t *testing.T) {
+	s, e := AnnualToQuarterly("TX", "TX", []int{2000, 2001}, []float64{100, 121})
+	assert.Nil(t, e)
+	assert.Equal(t, []int{20001, 20002, 20003, 20004, 20011, 20012, 20013, 20014}, s.Dates())
+
+	v, e := s.Index(20004)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, 110.0, v, 1e-10)
+
+	v, e = s.Index(20014)
+	assert.Nil(t, e)
+	assert.Equal(t, 121.0, v)
+
+	_, e = AnnualToQuarterly("TX", "TX", []int{2000, 2002}, []float64{100, 121})
+	assert.NotNil(t, e)
+	_, e = AnnualToQuarterly("TX", "TX", []int{2000}, []float64{0})
+	assert.NotNil(t, e)
+}
+
+func TestHPIdata_PriceToIncome(t *testing.T) {
+	hd := testData()
+
+	years := []int{2000, 2001, 2002, 2003, 2004}
+	inc, e := AnnualToQuarterly("TX", "TX", years, []float64{50000, 50000, 50000, 50000, 50000})
+	assert.Nil(t, e)
+	income, e := NewHPIdata("state", map[string]*HPIseries{"TX": inc, "NY": inc})
+	assert.Nil(t, e)
+
+	ratios, errs := hd.PriceToIncome(income, map[string]float64{"TX": 200000, "CA": 300000}, 20001)
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs, "CA")
+	assert.Contains(t, errs, "NY")
+
+	r, e := ratios.Geo("TX")
+	assert.Nil(t, e)
+	assert.Equal(t, 20001, r.Dates()[0])
+	assert.Equal(t, 20044, r.Dates()[r.Len()-1])
+
+	v, e := r.Index(20001)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, 4.0, v, 1e-10)
+
+	v, e = r.Index(20011)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, 4*1.01*1.01*1.01*1.01, v, 1e-10)
+}
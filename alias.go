@@ -0,0 +1,65 @@
+package fhfa
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// Alias makes alias another key for the geo canonical, so lookups (Geo, Index, Change, etc.) by alias
+// return the series of canonical.  Use it for old CBSA codes, names ("Washington DC" for DC) or internal
+// region codes.  Aliases match regardless of case and surrounding spaces, even if hd is strict.  canonical
+// must be in hd and alias must not be.  Aliasing an alias again replaces it.
+func (hd *HPIdata) Alias(alias, canonical string) error {
+	s, ok := hd.lookup(canonical)
+	if !ok {
+		return &GeoError{Geo: canonical, Level: hd.geoLevel}
+	}
+
+	key := aliasKey(alias)
+	if key == "" {
+		return fmt.Errorf("empty alias")
+	}
+
+	if _, ok := hd.series[strings.TrimSpace(alias)]; ok {
+		return fmt.Errorf("alias %s is a geo in the data", alias)
+	}
+
+	// canonical may not be the key itself, e.g. a state name
+	for geo, v := range hd.series {
+		if v == s {
+			canonical = geo
+			break
+		}
+	}
+
+	if hd.aliases == nil {
+		hd.aliases = make(map[string]string)
+	}
+
+	hd.aliases[key] = canonical
+
+	return nil
+}
+
+// Aliases returns the aliases of hd, mapping each (upper-cased) alias to its geo.
+func (hd *HPIdata) Aliases() map[string]string {
+	return maps.Clone(hd.aliases)
+}
+
+// alias returns the series aliased by geo, if any.
+func (hd *HPIdata) alias(geo string) (*HPIseries, bool) {
+	canonical, ok := hd.aliases[aliasKey(geo)]
+	if !ok {
+		return nil, false
+	}
+
+	h, ok := hd.series[canonical]
+
+	return h, ok
+}
+
+// aliasKey is the form in which aliases are stored.
+func aliasKey(alias string) string {
+	return strings.ToUpper(strings.TrimSpace(alias))
+}
@@ -0,0 +1,35 @@
+package fhfa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_Alias(t *testing.T) {
+	hd := testData()
+	assert.Nil(t, hd.Alias("West Coast", "california"))
+	assert.Equal(t, map[string]string{"WEST COAST": "CA"}, hd.Aliases())
+
+	v, e := hd.Index(" west coast", 20001)
+	assert.Nil(t, e)
+	assert.Equal(t, 100.0, v)
+
+	hd.SetStrict(true)
+	_, e = hd.Geo("WEST COAST")
+	assert.Nil(t, e)
+	hd.SetStrict(false)
+
+	cp := hd.Copy()
+	_, e = cp.Geo("west coast")
+	assert.Nil(t, e)
+
+	assert.NotNil(t, hd.Alias("TX", "CA"))
+	assert.NotNil(t, hd.Alias("", "CA"))
+	assert.True(t, errors.Is(hd.Alias("Mountain", "WY"), ErrGeoNotFound))
+
+	assert.Nil(t, hd.RemoveSeries("CA"))
+	_, e = hd.Geo("west coast")
+	assert.True(t, errors.Is(e, ErrGeoNotFound))
+}
@@ -0,0 +1,123 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Annualization is the convention a growth rate is quoted in.  The zero value, QuarterlyRate, is a rate per quarter,
+// which is what the growth and forecast APIs have always taken.  Set the Annualization field of Extrapolation,
+// MeanReversionModel or Scenario to supply annual rates instead.
+type Annualization int
+
+const (
+	// QuarterlyRate rates are growth per quarter (the default).
+	QuarterlyRate Annualization = iota
+
+	// AnnualCompound rates compound the quarterly rate over four quarters: (1+q)^4 - 1.
+	AnnualCompound
+
+	// AnnualSimple rates are four times the quarterly rate.
+	AnnualSimple
+)
+
+// String returns the name of the convention.
+func (a Annualization) String() string {
+	switch a {
+	case QuarterlyRate:
+		return "quarterly"
+	case AnnualCompound:
+		return "compound"
+	case AnnualSimple:
+		return "simple"
+	default:
+		return fmt.Sprintf("Annualization(%d)", int(a))
+	}
+}
+
+// UnmarshalText parses the name of a convention, as returned by String.
+func (a *Annualization) UnmarshalText(text []byte) error {
+	for _, u := range []Annualization{QuarterlyRate, AnnualCompound, AnnualSimple} {
+		if strings.EqualFold(strings.TrimSpace(string(text)), u.String()) {
+			*a = u
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown annualization: %s", text)
+}
+
+// Annualize converts the quarterly growth rate q to the convention a.
+func (a Annualization) Annualize(q float64) float64 {
+	switch a {
+	case AnnualCompound:
+		return math.Pow(1+q, 4) - 1
+	case AnnualSimple:
+		return 4 * q
+	default:
+		return q
+	}
+}
+
+// Quarterly converts the rate r, quoted in the convention a, to a quarterly growth rate.
+func (a Annualization) Quarterly(r float64) float64 {
+	switch a {
+	case AnnualCompound:
+		return math.Pow(1+r, 0.25) - 1
+	case AnnualSimple:
+		return r / 4
+	default:
+		return r
+	}
+}
+
+// QuarterlyPath returns a copy of path with each rate converted from the convention a to quarterly.
+func (a Annualization) QuarterlyPath(path []float64) []float64 {
+	out := make([]float64, len(path))
+	for j, r := range path {
+		out[j] = a.Quarterly(r)
+	}
+
+	return out
+}
+
+// check validates the convention
+func (a Annualization) check() error {
+	if a < QuarterlyRate || a > AnnualSimple {
+		return fmt.Errorf("unknown annualization: %d", a)
+	}
+
+	return nil
+}
+
+// AnnualGrowth returns the growth of h from dtStart to dtEnd (CCYYQ) as an annual rate in the convention a.
+// The average quarterly growth over the period is annualized, so AnnualCompound gives the compound annual
+// growth rate.
+func (h *HPIseries) AnnualGrowth(dtStart, dtEnd int, a Annualization) (float64, error) {
+	if e := a.check(); e != nil {
+		return 0, e
+	}
+
+	n := QtrDiffSigned(dtStart, dtEnd)
+	if n <= 0 {
+		return 0, fmt.Errorf("dtEnd %d must follow dtStart %d", dtEnd, dtStart)
+	}
+
+	ratio, e := h.Change(dtStart, dtEnd)
+	if e != nil {
+		return 0, e
+	}
+
+	return a.Annualize(math.Pow(ratio, 1/float64(n)) - 1), nil
+}
+
+// Annualized returns the rates of a converted to the convention c.
+func (a *HPAseries) Annualized(c Annualization) []float64 {
+	out := make([]float64, len(a.hpa))
+	for j, q := range a.hpa {
+		out[j] = c.Annualize(q)
+	}
+
+	return out
+}
@@ -0,0 +1,61 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnualization(t *testing.T) {
+	q := 0.01
+	assert.Equal(t, q, QuarterlyRate.Annualize(q))
+	assert.InDelta(t, 0.04060401, AnnualCompound.Annualize(q), 1e-12)
+	assert.InDelta(t, 0.04, AnnualSimple.Annualize(q), 1e-12)
+
+	for _, a := range []Annualization{QuarterlyRate, AnnualCompound, AnnualSimple} {
+		assert.InDelta(t, q, a.Quarterly(a.Annualize(q)), 1e-12)
+
+		var u Annualization
+		assert.Nil(t, u.UnmarshalText([]byte(a.String())))
+		assert.Equal(t, a, u)
+	}
+
+	var u Annualization
+	assert.NotNil(t, u.UnmarshalText([]byte("monthly")))
+
+	hd := testData()
+	s, _ := hd.Geo("CA")
+	g, e := s.AnnualGrowth(20001, 20041, AnnualCompound)
+	assert.Nil(t, e)
+	assert.InDelta(t, math.Pow(1.02, 4)-1, g, 1e-12)
+	g, e = s.AnnualGrowth(20001, 20041, AnnualSimple)
+	assert.Nil(t, e)
+	assert.InDelta(t, 0.08, g, 1e-12)
+	_, e = s.AnnualGrowth(20041, 20001, AnnualSimple)
+	assert.NotNil(t, e)
+	_, e = s.AnnualGrowth(20001, 20041, Annualization(7))
+	assert.NotNil(t, e)
+
+	a, _ := s.HPA()
+	assert.InDelta(t, 0.08, a.Annualized(AnnualSimple)[0], 1e-12)
+
+	// annual rates are converted before they are used
+	x := Extrapolation{Method: ExtrapGrowth, Growth: 0.04, Annualization: AnnualSimple}
+	assert.Nil(t, s.SetExtrapolation(x))
+	v, e := s.Index(20102)
+	assert.Nil(t, e)
+	last, _ := s.Index(20094)
+	assert.InDelta(t, last*1.01*1.01, v, 1e-9)
+	assert.NotNil(t, s.SetExtrapolation(Extrapolation{Method: ExtrapGrowth, Growth: 0.04, Annualization: -1}))
+
+	m := MeanReversionModel{LongRun: math.Pow(1.01, 4) - 1, Speed: 1, Annualization: AnnualCompound}
+	growth, e := m.Growth(s, 2)
+	assert.Nil(t, e)
+	assert.InDelta(t, 0.01, growth[1], 1e-12)
+
+	sc := Scenario{Name: "down", Path: []float64{-0.08}, Annualization: AnnualSimple}
+	assert.Nil(t, hd.ApplyScenario(sc, 20094))
+	v, _ = s.Index(20101)
+	assert.InDelta(t, last*0.98, v, 1e-9)
+}
@@ -0,0 +1,227 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// AppendOptions determines how AppendWith treats geos that are in only one of the HPIdata.
+// The zero value requires every geo of the receiver to be in the data appended.
+type AppendOptions struct {
+	SkipMissing bool // leave geos missing from the appended data unchanged rather than failing
+	AddNew      bool // add geos that are only in the appended data
+
+	// Overlap lets AppendWith take data that overlaps the end of a series, such as a full new release.  Only
+	// the quarters after the end are appended; the overlapping quarters are checked against the existing
+	// values and those revised by more than MaxRevision (e.g. 0.01 is 1%) are listed in the report.
+	Overlap     bool
+	MaxRevision float64
+}
+
+// AppendReport lists what AppendWith did with each geo.
+type AppendReport struct {
+	Appended []string // geos whose series were extended
+	Skipped  []string // geos missing from the appended data and left unchanged
+	Added    []string // geos only in the appended data that were added
+	Ignored  []string // geos only in the appended data that were not added
+
+	Revisions []RevisedQuarter // overlapping quarters revised by more than MaxRevision, by geo and date
+}
+
+// AppendWith appends ta to hd, handling geos in only one of them according to opts.  The data is checked
+// before anything is changed, so hd is unchanged if an error is returned.
+func (hd *HPIdata) AppendWith(ta *HPIdata, opts AppendOptions) (*AppendReport, error) {
+	if !opts.Overlap {
+		return hd.combine(ta, opts, (*HPIseries).checkAppend, (*HPIseries).Append)
+	}
+
+	// the overlapping quarters keep their values, so the revisions can be found after appending
+	rpt, e := hd.combine(ta, opts, (*HPIseries).checkOverlap, (*HPIseries).appendOverlap)
+	if e != nil {
+		return nil, e
+	}
+
+	for _, geo := range rpt.Appended {
+		s := hd.series[geo]
+		for dt, v := range ta.series[geo].Observations() {
+			if j := s.exact(dt); j >= 0 && math.Abs(v/s.indx[j]-1) > opts.MaxRevision {
+				rpt.Revisions = append(rpt.Revisions, RevisedQuarter{Geo: geo, Dt: dt, Original: s.indx[j], Revised: v})
+			}
+		}
+	}
+
+	return rpt, nil
+}
+
+// checkOverlap checks that (dts, indx) can be appended to h when it may overlap the end of h.
+func (h *HPIseries) checkOverlap(dts []int, indx []float64) error {
+	if len(dts) == 0 || len(dts) != len(indx) {
+		return fmt.Errorf("dates and indx don't agree")
+	}
+
+	if !QtrsOK(dts) {
+		return ErrFrequencyMismatch
+	}
+
+	if end := h.dates[len(h.dates)-1]; dts[0] > NextQtr(end) {
+		return fmt.Errorf("new data starts at %d, leaving a gap after %d", dts[0], end)
+	}
+
+	return nil
+}
+
+// appendOverlap appends the quarters of (dts, indx) after the end of h.
+func (h *HPIseries) appendOverlap(dts []int, indx []float64) error {
+	end := h.dates[len(h.dates)-1]
+	for j, dt := range dts {
+		if dt > end {
+			return h.Append(dts[j:], indx[j:])
+		}
+	}
+
+	return nil
+}
+
+// Upsert updates hd with a new release, ta.  For each geo, values for quarters already in hd are replaced
+// and later quarters are appended (see HPIseries.Upsert).  Geos in only one of hd and ta are handled according
+// to opts.  The data is checked before anything is changed, so hd is unchanged if an error is returned.
+func (hd *HPIdata) Upsert(ta *HPIdata, opts AppendOptions) (*AppendReport, error) {
+	return hd.combine(ta, opts, (*HPIseries).checkUpsert, (*HPIseries).Upsert)
+}
+
+// Upsert merges (dts, indx) into h, replacing the values of quarters already in h and appending the rest.
+// The new data must be contiguous and start no later than the quarter after the end of h.  Unlike Append,
+// the data is treated as published: it is not flagged as projected and Last() moves to the end of the new
+// data if that is later.
+func (h *HPIseries) Upsert(dts []int, indx []float64) error {
+	if e := h.checkUpsert(dts, indx); e != nil {
+		return e
+	}
+
+	for j, dt := range dts {
+		if k := h.exact(dt); k >= 0 {
+			h.indx[k] = indx[j]
+			if h.prov != nil {
+				h.prov[k] = Published
+			}
+
+			continue
+		}
+
+		h.dates = append(h.dates, dt)
+		h.indx = append(h.indx, indx[j])
+	}
+
+	h.flag(len(h.dates), Published)
+
+	if n := len(dts) - 1; dts[n] >= h.lastDt {
+		h.lastDt, h.lastIndx = dts[n], indx[n]
+	}
+
+	return nil
+}
+
+// checkUpsert checks that (dts, indx) can be upserted into h.
+func (h *HPIseries) checkUpsert(dts []int, indx []float64) error {
+	if len(dts) == 0 || len(dts) != len(indx) {
+		return fmt.Errorf("dates and indx don't agree")
+	}
+
+	if !QtrsOK(dts) {
+		return ErrFrequencyMismatch
+	}
+
+	if end := h.dates[len(h.dates)-1]; dts[0] > NextQtr(end) {
+		return fmt.Errorf("new data starts at %d, leaving a gap after %d", dts[0], end)
+	}
+
+	if dts[0] < h.dates[0] {
+		return fmt.Errorf("new data starts at %d, before the series starts at %d", dts[0], h.dates[0])
+	}
+
+	end := h.dates[len(h.dates)-1]
+	for _, dt := range dts {
+		if dt <= end && h.exact(dt) < 0 {
+			return fmt.Errorf("series is missing quarter %d", dt)
+		}
+	}
+
+	return nil
+}
+
+// combine adds ta to hd. check validates the data for a geo and apply adds it.
+func (hd *HPIdata) combine(ta *HPIdata, opts AppendOptions,
+	check func(h *HPIseries, dts []int, indx []float64) error,
+	apply func(h *HPIseries, dts []int, indx []float64) error) (*AppendReport, error) {
+	if hd.geoLevel != ta.geoLevel {
+		return nil, fmt.Errorf("geoLevel not the same in append")
+	}
+
+	if e := hd.tags.Compatible(ta.tags); e != nil {
+		return nil, e
+	}
+
+	rpt := &AppendReport{}
+	for k, v := range hd.series {
+		va, ok := ta.series[k]
+		if !ok {
+			rpt.Skipped = append(rpt.Skipped, k)
+			continue
+		}
+
+		if e := check(v, va.dates, va.indx); e != nil {
+			return nil, fmt.Errorf("geo %s: %v", k, e)
+		}
+
+		rpt.Appended = append(rpt.Appended, k)
+	}
+
+	for k := range ta.series {
+		if _, ok := hd.series[k]; ok {
+			continue
+		}
+
+		if opts.AddNew {
+			rpt.Added = append(rpt.Added, k)
+		} else {
+			rpt.Ignored = append(rpt.Ignored, k)
+		}
+	}
+
+	rpt.sort()
+
+	if len(rpt.Skipped) > 0 && !opts.SkipMissing {
+		return nil, fmt.Errorf("cannot find geos in append data: %s", strings.Join(rpt.Skipped, ","))
+	}
+
+	// apply to copies so hd is unchanged if any geo fails
+	applied := make(map[string]*HPIseries, len(rpt.Appended))
+	for _, k := range rpt.Appended {
+		va, s := ta.series[k], hd.series[k].Copy()
+		if e := apply(s, va.dates, va.indx); e != nil {
+			return nil, fmt.Errorf("geo %s: %v", k, e)
+		}
+
+		applied[k] = s
+	}
+
+	// update in place so series already returned by Geo see the new data
+	for k, s := range applied {
+		*hd.series[k] = *s
+	}
+
+	for _, k := range rpt.Added {
+		hd.series[k] = ta.series[k].Copy()
+	}
+
+	return rpt, nil
+}
+
+// sort sorts the geo lists
+func (r *AppendReport) sort() {
+	for _, s := range [][]string{r.Appended, r.Skipped, r.Added, r.Ignored} {
+		sort.Strings(s)
+	}
+}
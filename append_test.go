@@ -0,0 +1,153 @@
+package fhfa
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRelease returns data for geos covering the 4 quarters after the end of testData.
+func testRelease(geos ...string) *HPIdata {
+	series := make(map[string]*HPIseries)
+	for _, geo := range geos {
+		s, _ := NewHPIseries(geo, geo, []int{20101, 20102, 20103, 20104}, []float64{200, 201, 202, 203})
+		series[geo] = s
+	}
+
+	hd, _ := NewHPIdata("state", series)
+
+	return hd
+}
+
+func TestHPIdata_AppendWith(t *testing.T) {
+	hd := testData()
+	assert.NotNil(t, hd.Append(testRelease("CA", "TX")))
+
+	// nothing changed
+	assert.Equal(t, 40, hd.series["CA"].Len())
+
+	rpt, e := hd.AppendWith(testRelease("CA", "TX", "WY"), AppendOptions{SkipMissing: true})
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"CA", "TX"}, rpt.Appended)
+	assert.Equal(t, []string{"NY"}, rpt.Skipped)
+	assert.Equal(t, []string{"WY"}, rpt.Ignored)
+	assert.Equal(t, 44, hd.series["CA"].Len())
+	assert.Equal(t, 40, hd.series["NY"].Len())
+
+	hd = testData()
+	rpt, e = hd.AppendWith(testRelease("CA", "TX", "NY", "WY"), AppendOptions{AddNew: true})
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"WY"}, rpt.Added)
+
+	v, e := hd.Index("WY", 20102)
+	assert.Nil(t, e)
+	assert.Equal(t, 201.0, v)
+
+	// bad dates
+	hd = testData()
+	bad := testRelease("CA", "TX", "NY")
+	bad.series["NY"].dates = []int{20111, 20112, 20113, 20114}
+	_, e = hd.AppendWith(bad, AppendOptions{})
+	assert.NotNil(t, e)
+	assert.Equal(t, 40, hd.series["CA"].Len())
+}
+
+func TestHPIdata_combineApplyError(t *testing.T) {
+	hd := testData()
+	ok := func(h *HPIseries, dts []int, indx []float64) error { return nil }
+
+	// apply fails for NY after check passed
+	apply := func(h *HPIseries, dts []int, indx []float64) error {
+		if h.geoCode == "NY" {
+			return fmt.Errorf("failed")
+		}
+
+		return h.Append(dts, indx)
+	}
+
+	_, e := hd.combine(testRelease("CA", "TX", "NY"), AppendOptions{}, ok, apply)
+	assert.ErrorContains(t, e, "geo NY")
+	for _, geo := range []string{"CA", "TX", "NY"} {
+		assert.Equal(t, 40, hd.series[geo].Len(), geo)
+	}
+}
+
+func TestHPIseries_Upsert(t *testing.T) {
+	s := testData().series["CA"]
+	ld, _ := s.Last()
+
+	// revise the last 2 quarters and add 2 more
+	dts := []int{20093, 20094, 20101, 20102}
+	indx := []float64{1, 2, 3, 4}
+	assert.Nil(t, s.Upsert(dts, indx))
+	assert.Equal(t, 42, s.Len())
+
+	for j, dt := range dts {
+		v, e := s.Index(dt)
+		assert.Nil(t, e)
+		assert.Equal(t, indx[j], v)
+	}
+
+	dt, v := s.Last()
+	assert.Equal(t, 20102, dt)
+	assert.Equal(t, 4.0, v)
+	assert.NotEqual(t, ld, dt)
+
+	assert.NotNil(t, s.Upsert([]int{20104}, []float64{1}))
+	assert.NotNil(t, s.Upsert([]int{19904, 20001}, []float64{1, 1}))
+	assert.NotNil(t, s.Upsert([]int{20001, 20003}, []float64{1, 1}))
+}
+
+func TestHPIdata_Upsert(t *testing.T) {
+	hd := testData()
+
+	rpt, e := hd.Upsert(testRelease("CA", "TX", "NY"), AppendOptions{})
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"CA", "NY", "TX"}, rpt.Appended)
+
+	// a second refresh overlapping the first
+	rpt, e = hd.Upsert(testRelease("CA", "TX", "NY"), AppendOptions{})
+	assert.Nil(t, e)
+	assert.Equal(t, 44, hd.series["TX"].Len())
+}
+
+func TestHPIdata_AppendWith_Overlap(t *testing.T) {
+	hd := testData()
+
+	// a full release: the last year again, revised, plus two new quarters
+	release := make(map[string]*HPIseries)
+	for geo, s := range hd.All() {
+		dts := append(append([]int{}, s.dates[36:]...), 20101, 20102)
+		indx := append(append([]float64{}, s.indx[36:]...), 300, 301)
+		if geo == "TX" {
+			indx[2] *= 1.02
+			indx[3] *= 1.001
+		}
+
+		release[geo], _ = NewHPIseries(geo, geo, dts, indx)
+	}
+
+	ta, _ := NewHPIdata("state", release)
+	assert.NotNil(t, hd.Append(ta))
+
+	tx, _ := hd.Index("TX", 20093)
+	rpt, e := hd.AppendWith(ta, AppendOptions{Overlap: true, MaxRevision: 0.01})
+	assert.Nil(t, e)
+	assert.Equal(t, []RevisedQuarter{{Geo: "TX", Dt: 20093, Original: tx, Revised: tx * 1.02}}, rpt.Revisions)
+
+	assert.Equal(t, 42, hd.series["TX"].Len())
+	v, _ := hd.Index("TX", 20093)
+	assert.Equal(t, tx, v)
+	v, _ = hd.Index("CA", 20102)
+	assert.Equal(t, 301.0, v)
+
+	// data starting right after the end is fine, a gap is still an error
+	_, e = testData().AppendWith(testRelease("CA", "TX", "NY"), AppendOptions{Overlap: true})
+	assert.Nil(t, e)
+
+	gap := testRelease("CA", "TX", "NY")
+	gap.series["NY"].dates = []int{20102, 20103, 20104, 20111}
+	_, e = testData().AppendWith(gap, AppendOptions{Overlap: true})
+	assert.NotNil(t, e)
+}
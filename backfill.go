@@ -0,0 +1,157 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+)
+
+// ParentFunc returns the geo of the parent geography of geo (e.g. the state of a zip3).
+type ParentFunc func(geo string) (string, error)
+
+// StateParent returns the ParentFunc mapping the geos of geoLevel to their state.  zip3 and metro data
+// are supported.  For metro data only the CBSAs in the CBSA table are mapped (see AddCBSA).
+func StateParent(geoLevel string) (ParentFunc, error) {
+	switch geoLevel {
+	case "zip3":
+		return Zip3State, nil
+	case "metro":
+		return CBSAState, nil
+	default:
+		return nil, fmt.Errorf("no state mapping for geo level %s", geoLevel)
+	}
+}
+
+// Backfill imputes the missing history of every series in hd from the growth of its parent series in
+// parents.  parentOf maps a geo in hd to a geo in parents; if nil, StateParent is used.  Series are
+// extended back to fromDt (CCYYQ) - or the start of the parent if fromDt is 0 - and interior gaps are filled.
+// It returns the number of quarters imputed in each series that changed and the errors for those that
+// could not be backfilled.
+func (hd *HPIdata) Backfill(parents *HPIdata, fromDt int, parentOf ParentFunc) (map[string]int, map[string]error) {
+	if parentOf == nil {
+		var e error
+		if parentOf, e = StateParent(hd.geoLevel); e != nil {
+			return nil, map[string]error{"": e}
+		}
+	}
+
+	imputed := make(map[string]int)
+	errs := make(map[string]error)
+	for geo, s := range hd.series {
+		pGeo, e := parentOf(geo)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		p, e := parents.Geo(pGeo)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		n, e := s.Backfill(p, fromDt)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		if n > 0 {
+			imputed[geo] = n
+		}
+	}
+
+	if len(errs) == 0 {
+		errs = nil
+	}
+
+	return imputed, errs
+}
+
+// Backfill imputes the missing history of h from the growth of parent, e.g. the state containing a zip3.
+// h is extended back to fromDt (CCYYQ) - or the start of parent if fromDt is 0 - by applying the growth of
+// parent to the first value of h.  Interior gaps follow the growth of parent, scaled to meet the values on
+// either side.  The imputed quarters have provenance Imputed.  It returns the number of quarters imputed;
+// h is unchanged if there is an error.
+func (h *HPIseries) Backfill(parent *HPIseries, fromDt int) (int, error) {
+	if fromDt == 0 {
+		fromDt = parent.dates[0]
+	}
+
+	// pv returns the parent value at dt, which must be there exactly
+	pv := func(dt int) (float64, error) {
+		j := parent.exact(dt)
+		if j < 0 {
+			return 0, fmt.Errorf("parent %s has no data at %d", parent.geoCode, dt)
+		}
+
+		return parent.indx[j], nil
+	}
+
+	var (
+		dts  []int
+		indx []float64
+		prov []Provenance
+	)
+
+	if n := QtrDiff(fromDt, h.dates[0]); fromDt < h.dates[0] {
+		base, e := pv(h.dates[0])
+		if e != nil {
+			return 0, e
+		}
+
+		for k := n; k > 0; k-- {
+			dt := AddQtrs(h.dates[0], -k)
+			p, e := pv(dt)
+			if e != nil {
+				return 0, e
+			}
+
+			dts = append(dts, dt)
+			indx = append(indx, h.indx[0]*p/base)
+			prov = append(prov, Imputed)
+		}
+	}
+
+	dts = append(dts, h.dates[0])
+	indx = append(indx, h.indx[0])
+	prov = append(prov, h.provenance(0))
+
+	for j := 1; j < len(h.dates); j++ {
+		if n := QtrDiff(h.dates[j-1], h.dates[j]); n > 1 {
+			pa, e := pv(h.dates[j-1])
+			if e != nil {
+				return 0, e
+			}
+
+			pb, e := pv(h.dates[j])
+			if e != nil {
+				return 0, e
+			}
+
+			// the part of the change across the gap not explained by the parent, spread evenly
+			adj := (h.indx[j] / h.indx[j-1]) / (pb / pa)
+			for k := 1; k < n; k++ {
+				dt := AddQtrs(h.dates[j-1], k)
+				p, e := pv(dt)
+				if e != nil {
+					return 0, e
+				}
+
+				dts = append(dts, dt)
+				indx = append(indx, h.indx[j-1]*p/pa*math.Pow(adj, float64(k)/float64(n)))
+				prov = append(prov, Imputed)
+			}
+		}
+
+		dts = append(dts, h.dates[j])
+		indx = append(indx, h.indx[j])
+		prov = append(prov, h.provenance(j))
+	}
+
+	n := len(dts) - len(h.dates)
+	if n > 0 {
+		h.dates, h.indx, h.prov = dts, indx, prov
+	}
+
+	return n, nil
+}
@@ -0,0 +1,64 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_Backfill(t *testing.T) {
+	hd := testData()
+	tx, _ := hd.Geo("TX")
+
+	// starts in 20051 with a gap from 20054 to 20063
+	h := &HPIseries{geoName: "750", geoCode: "750", dates: []int{20051, 20052, 20053, 20054, 20063, 20064},
+		indx: []float64{50, 51, 52, 53, 60, 61}, lastDt: 20064, lastIndx: 61}
+
+	n, e := h.Backfill(tx, 20041)
+	assert.Nil(t, e)
+	assert.Equal(t, 4+2, n)
+	assert.True(t, QtrsOK(h.dates))
+	assert.Equal(t, 20041, h.dates[0])
+
+	// backward extension follows TX growth of 1% per quarter
+	assert.InDelta(t, 50/1.01, h.indx[3], 1e-9)
+	assert.InDelta(t, 50/1.01/1.01/1.01/1.01, h.indx[0], 1e-9)
+
+	p, _ := h.Provenance(20041)
+	assert.Equal(t, Imputed, p)
+	p, _ = h.Provenance(20051)
+	assert.Equal(t, Published, p)
+	p, _ = h.Provenance(20061)
+	assert.Equal(t, Imputed, p)
+
+	// the gap meets the published values
+	v, _ := h.Index(20063)
+	assert.Equal(t, 60.0, v)
+	v0, _ := h.Index(20054)
+	v1, _ := h.Index(20061)
+	v2, _ := h.Index(20062)
+	assert.InDelta(t, v1/v0, v2/v1, 1e-9)
+	assert.InDelta(t, v2/v1, 60/v2, 1e-9)
+
+	// parent too short
+	h = &HPIseries{geoCode: "750", dates: []int{20051}, indx: []float64{50}, lastDt: 20051, lastIndx: 50}
+	_, e = h.Backfill(tx, 19991)
+	assert.NotNil(t, e)
+	assert.Equal(t, 1, h.Len())
+}
+
+func TestHPIdata_Backfill(t *testing.T) {
+	states := testData()
+	h := &HPIseries{geoName: "750", geoCode: "750", dates: []int{20051, 20052}, indx: []float64{50, 51},
+		lastDt: 20052, lastIndx: 51}
+
+	hd, e := NewHPIdata("zip3", map[string]*HPIseries{"750": h})
+	assert.Nil(t, e)
+
+	imputed, errs := hd.Backfill(states, 0, nil)
+	assert.Nil(t, errs)
+	assert.Equal(t, map[string]int{"750": 20}, imputed)
+
+	dt, _ := h.First()
+	assert.Equal(t, 20001, dt)
+}
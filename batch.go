@@ -0,0 +1,95 @@
+package fhfa
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// IndexBatch returns the house price index for each (geos[i], dts[i]) pair, dts in CCYYQ format.
+// errs is nil if every lookup succeeds, otherwise errs[i] is the error for the ith pair.
+// If geos and dts differ in length, the values are nil and errs has a single element.
+func (hd *HPIdata) IndexBatch(geos []string, dts []int) (hpi []float64, errs []error) {
+	return hd.IndexBatchParallel(geos, dts, 1)
+}
+
+// IndexBatchParallel is IndexBatch with the lookups split across workers goroutines.  If workers is not
+// positive, runtime.NumCPU() is used.
+func (hd *HPIdata) IndexBatchParallel(geos []string, dts []int, workers int) (hpi []float64, errs []error) {
+	if len(geos) != len(dts) {
+		return nil, []error{fmt.Errorf("geos and dts have different lengths: %d, %d", len(geos), len(dts))}
+	}
+
+	hpi = make([]float64, len(geos))
+	allErrs := make([]error, len(geos))
+
+	var (
+		mu    sync.Mutex
+		found bool
+	)
+
+	parallel(len(geos), workers, func(start, end int) {
+		if hd.indexRange(geos, dts, hpi, allErrs, start, end) {
+			mu.Lock()
+			found = true
+			mu.Unlock()
+		}
+	})
+
+	if found {
+		return hpi, allErrs
+	}
+
+	return hpi, nil
+}
+
+// indexRange fills hpi and errs for elements start through end-1, returning true if any lookup failed.
+// The series is only looked up when the geo changes, so sorting the input by geo speeds things up.
+func (hd *HPIdata) indexRange(geos []string, dts []int, hpi []float64, errs []error, start, end int) bool {
+	var (
+		s       *HPIseries
+		eGeo    error
+		lastGeo string
+		bad     bool
+	)
+
+	for j := start; j < end; j++ {
+		if j == start || geos[j] != lastGeo {
+			lastGeo = geos[j]
+			s, eGeo = hd.Geo(lastGeo)
+		}
+
+		if eGeo != nil {
+			errs[j], bad = eGeo, true
+			continue
+		}
+
+		var e error
+		if hpi[j], e = s.Index(dts[j]); e != nil {
+			errs[j], bad = e, true
+		}
+	}
+
+	return bad
+}
+
+// parallel splits [0,n) into contiguous blocks and calls fn(start, end) for each block concurrently
+// across workers goroutines, returning when all are done.  If workers is not positive, runtime.NumCPU() is used.
+func parallel(n, workers int, fn func(start, end int)) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	chunk := max((n+workers-1)/workers, 1)
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, min(start+chunk, n))
+	}
+
+	wg.Wait()
+}
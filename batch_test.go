@@ -0,0 +1,32 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_IndexBatch(t *testing.T) {
+	hd := testData()
+
+	geos := []string{"CA", "CA", "TX", "XX", "NY", "NY"}
+	dts := []int{20001, 20051, 20051, 20051, 20051, 19001}
+
+	for _, workers := range []int{1, 2, 0} {
+		hpi, errs := hd.IndexBatchParallel(geos, dts, workers)
+		assert.Equal(t, len(geos), len(hpi))
+		assert.Equal(t, len(geos), len(errs))
+
+		for j := range geos {
+			exp, e := hd.Index(geos[j], dts[j])
+			assert.Equal(t, e != nil, errs[j] != nil)
+			assert.Equal(t, exp, hpi[j])
+		}
+	}
+
+	_, errs := hd.IndexBatch(geos[:3], dts[:3])
+	assert.Nil(t, errs)
+
+	_, errs = hd.IndexBatch(geos, dts[:2])
+	assert.Equal(t, 1, len(errs))
+}
@@ -0,0 +1,318 @@
+package fhfa
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// The binary cache layout, all little-endian:
+//
+//	header     binHeader bytes: magic, geo level, number of geos
+//	directory  binEntry bytes per geo, in geo order
+//	names      the geo names, back to back
+//	values     float64 per quarter for each geo from its first to its last quarter, NaN if missing
+const (
+	binMagic  = "FHFAHPI1"
+	binHeader = 32
+	binEntry  = 40
+	binKey    = 16 // maximum length of a geo or geo level
+)
+
+// SaveBinary saves the data in a compact binary format that OpenBinary can query in place, without
+// loading it.  Quarters missing within a series are stored as NaN.  Geos and the geo level are limited to
+// 16 bytes.
+func (hd *HPIdata) SaveBinary(localFile string) error {
+	geos := hd.Geos()
+	sort.Strings(geos)
+
+	if len(hd.geoLevel) > binKey {
+		return fmt.Errorf("geo level too long for binary cache: %s", hd.geoLevel)
+	}
+
+	var (
+		dir, names bytes.Buffer
+		values     []float64
+	)
+
+	for _, geo := range geos {
+		s := hd.series[geo]
+		if len(geo) > binKey {
+			return fmt.Errorf("geo too long for binary cache: %s", geo)
+		}
+
+		first, last := s.dates[0], s.dates[len(s.dates)-1]
+		vals := make([]float64, QtrDiff(first, last)+1)
+		for j := range vals {
+			vals[j] = math.NaN()
+		}
+
+		for j, dt := range s.dates {
+			vals[QtrDiff(first, dt)] = s.indx[j]
+		}
+
+		var key [binKey]byte
+		copy(key[:], geo)
+		dir.Write(key[:])
+		_ = binary.Write(&dir, binary.LittleEndian, []uint32{uint32(first), uint32(len(vals))})
+		_ = binary.Write(&dir, binary.LittleEndian, uint64(len(values)))
+		_ = binary.Write(&dir, binary.LittleEndian, []uint32{uint32(names.Len()), uint32(len(s.geoName))})
+
+		names.WriteString(s.geoName)
+		values = append(values, vals...)
+	}
+
+	file, e := create(localFile)
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+
+	var hdr [binHeader]byte
+	copy(hdr[:], binMagic)
+	copy(hdr[8:8+binKey], hd.geoLevel)
+	binary.LittleEndian.PutUint32(hdr[24:], uint32(len(geos)))
+
+	for _, b := range [][]byte{hdr[:], dir.Bytes(), names.Bytes()} {
+		if _, e := w.Write(b); e != nil {
+			return e
+		}
+	}
+
+	if e := binary.Write(w, binary.LittleEndian, values); e != nil {
+		return e
+	}
+
+	if e := w.Flush(); e != nil {
+		return e
+	}
+
+	return file.Close()
+}
+
+// BinaryCache queries a file written by SaveBinary in place.  On Unix systems the file is memory-mapped, so
+// opening it costs next to nothing regardless of its size.  It is safe for concurrent use.
+type BinaryCache struct {
+	data     []byte
+	geoLevel string
+	nGeos    int
+	names    int // offset of the names
+	values   int // offset of the values
+	close    func() error
+}
+
+var _ IndexProvider = (*BinaryCache)(nil)
+
+// OpenBinary opens a binary cache written by SaveBinary.  Blob URLs (see RegisterBlobOpener) are read into
+// memory rather than mapped.  Close the cache when done with it.
+func OpenBinary(localFile string) (*BinaryCache, error) {
+	o, e := blobOpener(localFile)
+	if e != nil {
+		return nil, e
+	}
+
+	var (
+		data  []byte
+		close = func() error { return nil }
+	)
+
+	if o != nil {
+		r, e := o.Open(context.Background(), localFile)
+		if e != nil {
+			return nil, e
+		}
+		defer r.Close()
+
+		if data, e = io.ReadAll(r); e != nil {
+			return nil, e
+		}
+	} else if data, close, e = mapFile(localFile); e != nil {
+		return nil, e
+	}
+
+	bc, e := newBinaryCache(data)
+	if e != nil {
+		_ = close()
+		return nil, fmt.Errorf("%s: %w", localFile, e)
+	}
+
+	bc.close = close
+
+	return bc, nil
+}
+
+// newBinaryCache checks the layout of data.
+func newBinaryCache(data []byte) (*BinaryCache, error) {
+	if len(data) < binHeader || string(data[:8]) != binMagic {
+		return nil, fmt.Errorf("not an fhfa binary cache")
+	}
+
+	bc := &BinaryCache{
+		data:     data,
+		geoLevel: strings.TrimRight(string(data[8:8+binKey]), "\x00"),
+		nGeos:    int(binary.LittleEndian.Uint32(data[24:])),
+	}
+
+	bc.names = binHeader + bc.nGeos*binEntry
+	if len(data) < bc.names {
+		return nil, fmt.Errorf("binary cache is truncated")
+	}
+
+	bc.values = bc.names
+	nVals := 0
+	for j := range bc.nGeos {
+		_, _, n, _, nameLen := bc.entry(j)
+		bc.values += nameLen
+		nVals += n
+	}
+
+	if len(data) != bc.values+8*nVals {
+		return nil, fmt.Errorf("binary cache is truncated")
+	}
+
+	// check every entry lies within the file so a corrupt file can't cause a panic later
+	for j := range bc.nGeos {
+		_, first, n, off, nameLen := bc.entry(j)
+		nameOff := int(binary.LittleEndian.Uint32(data[binHeader+j*binEntry+32:]))
+		if !YrQtr(first).Valid() || n < 0 || off < 0 || off+n > nVals || nameOff+nameLen > bc.values-bc.names {
+			return nil, fmt.Errorf("binary cache entry %d is corrupt", j)
+		}
+	}
+
+	return bc, nil
+}
+
+// Close releases the cache.
+func (bc *BinaryCache) Close() error {
+	bc.data = nil
+
+	return bc.close()
+}
+
+// GeoLevel returns the geo level of the data.
+func (bc *BinaryCache) GeoLevel() string {
+	return bc.geoLevel
+}
+
+// Geos returns the geos, in order.
+func (bc *BinaryCache) Geos() []string {
+	geos := make([]string, bc.nGeos)
+	for j := range geos {
+		geos[j], _, _, _, _ = bc.entry(j)
+	}
+
+	return geos
+}
+
+// Index returns the index for geo at dt (CCYYQ).
+func (bc *BinaryCache) Index(geo string, dt int) (float64, error) {
+	j, e := bc.find(geo)
+	if e != nil {
+		return 0, e
+	}
+
+	_, first, n, off, _ := bc.entry(j)
+	last := AddQtrs(first, n-1)
+	if !YrQtr(dt).Valid() {
+		return 0, badDate(dt)
+	}
+
+	if dt < first || dt > last {
+		return 0, &DateRangeError{Dt: dt, First: first, Last: last}
+	}
+
+	pos := bc.values + 8*(off+QtrDiff(first, dt))
+	v := math.Float64frombits(binary.LittleEndian.Uint64(bc.data[pos:]))
+	if math.IsNaN(v) {
+		return 0, fmt.Errorf("%w: %d", ErrDateMissing, dt)
+	}
+
+	return v, nil
+}
+
+// Change returns the ratio of the index at dtEnd (CCYYQ) to dtStart (CCYYQ) for geo.
+func (bc *BinaryCache) Change(geo string, dtStart, dtEnd int) (float64, error) {
+	v0, e := bc.Index(geo, dtStart)
+	if e != nil {
+		return 0, e
+	}
+
+	v1, e := bc.Index(geo, dtEnd)
+	if e != nil {
+		return 0, e
+	}
+
+	return v1 / v0, nil
+}
+
+// Data loads the whole cache as HPIdata.
+func (bc *BinaryCache) Data() (*HPIdata, error) {
+	hd := &HPIdata{
+		source:   "binary cache",
+		geoLevel: bc.geoLevel,
+		series:   make(map[string]*HPIseries),
+	}
+
+	for j := range bc.nGeos {
+		geo, first, n, _, _ := bc.entry(j)
+		for k, dt := 0, first; k < n; k, dt = k+1, NextQtr(dt) {
+			if v, e := bc.Index(geo, dt); e == nil {
+				hd.add(geo, bc.name(j), dt, v)
+			}
+		}
+	}
+
+	if e := hd.finish(); e != nil {
+		return nil, e
+	}
+
+	return hd, nil
+}
+
+// find returns the directory position of geo.
+func (bc *BinaryCache) find(geo string) (int, error) {
+	geo = NormalizeGeo(bc.geoLevel, geo)
+	j := sort.Search(bc.nGeos, func(k int) bool {
+		g, _, _, _, _ := bc.entry(k)
+		return g >= geo
+	})
+
+	if j == bc.nGeos {
+		return 0, &GeoError{Geo: geo, Level: bc.geoLevel}
+	}
+
+	if g, _, _, _, _ := bc.entry(j); g != geo {
+		return 0, &GeoError{Geo: geo, Level: bc.geoLevel}
+	}
+
+	return j, nil
+}
+
+// entry decodes directory entry j.
+func (bc *BinaryCache) entry(j int) (geo string, first, n, off, nameLen int) {
+	b := bc.data[binHeader+j*binEntry:]
+	geo = strings.TrimRight(string(b[:binKey]), "\x00")
+	first = int(binary.LittleEndian.Uint32(b[16:]))
+	n = int(binary.LittleEndian.Uint32(b[20:]))
+	off = int(binary.LittleEndian.Uint64(b[24:]))
+	nameLen = int(binary.LittleEndian.Uint32(b[36:]))
+
+	return geo, first, n, off, nameLen
+}
+
+// name returns the geo name of directory entry j.
+func (bc *BinaryCache) name(j int) string {
+	b := bc.data[binHeader+j*binEntry:]
+	off := int(binary.LittleEndian.Uint32(b[32:]))
+	n := int(binary.LittleEndian.Uint32(b[36:]))
+
+	return string(bc.data[bc.names+off : bc.names+off+n])
+}
@@ -0,0 +1,99 @@
+package fhfa
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenBinary(t *testing.T) {
+	hd := testData()
+	file := filepath.Join(t.TempDir(), "hpi.bin")
+	assert.Nil(t, hd.SaveBinary(file))
+
+	bc, e := OpenBinary(file)
+	assert.Nil(t, e)
+	defer bc.Close()
+
+	assert.Equal(t, "state", bc.GeoLevel())
+	assert.Equal(t, []string{"CA", "NY", "TX"}, bc.Geos())
+
+	for geo, s := range hd.All() {
+		for dt, v := range s.Observations() {
+			v1, e := bc.Index(geo, dt)
+			assert.Nil(t, e)
+			assert.Equal(t, v, v1)
+		}
+	}
+
+	chg, e := bc.Change("ca", 20001, 20011)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.02*1.02*1.02*1.02, chg, 1e-9)
+
+	_, e = bc.Index("ZZ", 20001)
+	assert.True(t, errors.Is(e, ErrGeoNotFound))
+
+	var dre *DateRangeError
+	_, e = bc.Index("CA", 20101)
+	assert.True(t, errors.As(e, &dre))
+
+	hd1, e := bc.Data()
+	assert.Nil(t, e)
+	for geo, s := range hd.All() {
+		s1, e := hd1.Geo(geo)
+		assert.Nil(t, e)
+		assert.Equal(t, s.Dates(), s1.Dates())
+		assert.Equal(t, s.Values(), s1.Values())
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.bin")
+	assert.Nil(t, os.WriteFile(bad, []byte("not a cache"), 0o644))
+	_, e = OpenBinary(bad)
+	assert.NotNil(t, e)
+
+	// an entry pointing past the values
+	b, e := os.ReadFile(file)
+	assert.Nil(t, e)
+	b[binHeader+24] = 0xff
+	assert.Nil(t, os.WriteFile(bad, b, 0o644))
+	_, e = OpenBinary(bad)
+	assert.NotNil(t, e)
+}
+
+func TestOpenBinary_empty(t *testing.T) {
+	hd := testData()
+	for _, geo := range hd.Geos() {
+		assert.Nil(t, hd.RemoveSeries(geo))
+	}
+
+	file := filepath.Join(t.TempDir(), "hpi.bin")
+	assert.Nil(t, hd.SaveBinary(file))
+
+	bc, e := OpenBinary(file)
+	assert.Nil(t, e)
+	defer bc.Close()
+
+	assert.Empty(t, bc.Geos())
+	_, e = bc.Index("CA", 20001)
+	assert.ErrorIs(t, e, ErrGeoNotFound)
+}
+
+func BenchmarkBinaryCache_Index(b *testing.B) {
+	file := filepath.Join(b.TempDir(), "hpi.bin")
+	if e := testData().SaveBinary(file); e != nil {
+		b.Fatal(e)
+	}
+
+	bc, e := OpenBinary(file)
+	if e != nil {
+		b.Fatal(e)
+	}
+	defer bc.Close()
+
+	for b.Loop() {
+		_, _ = bc.Index("TX", 20052)
+	}
+}
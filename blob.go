@@ -0,0 +1,174 @@
+package fhfa
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// BlobOpener reads and writes objects in blob storage, e.g. S3 or GCS.  Register one for a URL scheme with
+// RegisterBlobOpener and the sources of Load and LoadParquet and the files of Save, SaveNDJSON, SaveParquet
+// and SaveDuckDB may be URLs of that scheme (e.g. s3://bucket/hpi/state.xlsx).  This package has no cloud
+// dependencies; an opener is a thin wrapper around the SDK of the storage provider.
+type BlobOpener interface {
+	// Open opens the object at url for reading.
+	Open(ctx context.Context, url string) (io.ReadCloser, error)
+
+	// Create creates (or replaces) the object at url.  The object is complete once the writer is closed
+	// without error.  Close may be called more than once; calls after the first should do nothing.
+	Create(ctx context.Context, url string) (io.WriteCloser, error)
+}
+
+var (
+	blobMu      sync.RWMutex
+	blobOpeners = make(map[string]BlobOpener)
+)
+
+// RegisterBlobOpener registers o for URLs with scheme (e.g. s3, gs).  A nil o removes the opener.
+func RegisterBlobOpener(scheme string, o BlobOpener) {
+	blobMu.Lock()
+	defer blobMu.Unlock()
+
+	if o == nil {
+		delete(blobOpeners, scheme)
+		return
+	}
+
+	blobOpeners[scheme] = o
+}
+
+// blobOpener returns the opener for name if it is a blob URL.  It is an error if name is an s3 or gs URL
+// and no opener is registered for it.
+func blobOpener(name string) (BlobOpener, error) {
+	u, e := url.Parse(name)
+	if e != nil || u.Scheme == "" {
+		return nil, nil
+	}
+
+	blobMu.RLock()
+	defer blobMu.RUnlock()
+
+	if o, ok := blobOpeners[u.Scheme]; ok {
+		return o, nil
+	}
+
+	if in(u.Scheme, []string{"s3", "gs"}) {
+		return nil, fmt.Errorf("no blob opener registered for %s", name)
+	}
+
+	return nil, nil
+}
+
+// create creates name, which is a local file or a blob URL, compressing it if the name has a compression
+// extension.
+func create(name string) (io.WriteCloser, error) {
+	o, e := blobOpener(name)
+	if e != nil {
+		return nil, e
+	}
+
+	var file io.WriteCloser
+	if o != nil {
+		file, e = o.Create(context.Background(), name)
+	} else {
+		file, e = os.Create(name)
+	}
+
+	if e != nil {
+		return nil, e
+	}
+
+	return compressWriter(name, file)
+}
+
+// localCopy returns a local file with the contents of source and a function that removes it.  Compressed
+// sources (see compressed) are decompressed and zip archives on the web are downloaded.  Other sources that
+// aren't blob URLs are returned as is.
+func localCopy(source string) (local string, cleanup func(), e error) {
+	o, e := blobOpener(source)
+	if e != nil {
+		return "", nil, e
+	}
+
+	ext := compressed(source)
+	if o == nil && ext == "" && !(isWeb(source) && isZip(source)) {
+		return source, func() {}, nil
+	}
+
+	dir, e := os.MkdirTemp("", "fhfa")
+	if e != nil {
+		return "", nil, e
+	}
+
+	cleanup = func() { _ = os.RemoveAll(dir) }
+
+	// keep the base name, without the compression extension, readers go by the extension
+	u, _ := url.Parse(source)
+	base := path.Base(u.Path)
+	local = dir + string(os.PathSeparator) + strings.TrimSuffix(base, path.Ext(base))
+	if ext == "" {
+		local = dir + string(os.PathSeparator) + base
+	}
+
+	if e := copySource(source, o, local); e != nil {
+		cleanup()
+		return "", nil, e
+	}
+
+	return local, cleanup, nil
+}
+
+// copySource writes the decompressed contents of source to the local file local.  o is the blob opener of
+// source, nil if it isn't a blob URL.
+func copySource(source string, o BlobOpener, local string) error {
+	var (
+		r io.ReadCloser
+		e error
+	)
+
+	switch {
+	case o != nil:
+		r, e = o.Open(context.Background(), source)
+	case isWeb(source):
+		if _, e = download(context.Background(), source, local+".download"); e == nil {
+			r, e = os.Open(local + ".download")
+		}
+	default:
+		r, e = os.Open(source)
+	}
+
+	if e != nil {
+		return e
+	}
+	defer r.Close()
+
+	dr, e := decompressReader(source, r)
+	if e != nil {
+		return e
+	}
+	defer dr.Close()
+
+	file, e := os.Create(local)
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	if _, e := io.Copy(file, dr); e != nil {
+		return e
+	}
+
+	return file.Close()
+}
+
+// isWeb returns true if source is an http or https URL.
+func isWeb(source string) bool {
+	u, e := url.Parse(source)
+
+	return e == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
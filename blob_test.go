@@ -0,0 +1,85 @@
+package fhfa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memBlobs is a BlobOpener that holds objects in memory.
+type memBlobs struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memBlobs) Open(_ context.Context, url string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.objects[url]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", url, os.ErrNotExist)
+	}
+
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m *memBlobs) Create(_ context.Context, url string) (io.WriteCloser, error) {
+	return &memWriter{m: m, url: url}, nil
+}
+
+// memWriter stores its contents in memBlobs when closed.
+type memWriter struct {
+	bytes.Buffer
+	m   *memBlobs
+	url string
+}
+
+func (w *memWriter) Close() error {
+	w.m.mu.Lock()
+	defer w.m.mu.Unlock()
+
+	w.m.objects[w.url] = w.Bytes()
+
+	return nil
+}
+
+func TestRegisterBlobOpener(t *testing.T) {
+	m := &memBlobs{objects: make(map[string][]byte)}
+	RegisterBlobOpener("mem", m)
+	defer RegisterBlobOpener("mem", nil)
+
+	b, e := os.ReadFile(testXLSX(t, testData()))
+	assert.Nil(t, e)
+	m.objects["mem://bucket/hpi/state.xlsx"] = b
+
+	hd, e := Load("mem://bucket/hpi/state.xlsx")
+	assert.Nil(t, e)
+	assert.ElementsMatch(t, []string{"CA", "NY", "TX"}, hd.Geos())
+
+	assert.Nil(t, hd.Save("mem://bucket/out/state.csv"))
+	assert.True(t, strings.HasPrefix(string(m.objects["mem://bucket/out/state.csv"]), "geo,code,date,index\n"))
+
+	assert.Nil(t, hd.SaveNDJSON("mem://bucket/out/state.json"))
+	assert.Contains(t, string(m.objects["mem://bucket/out/state.json"]), `"geo":"CA"`)
+
+	assert.Nil(t, hd.SaveDuckDB("mem://bucket/out/state.parquet", "hpi"))
+	assert.Contains(t, m.objects, "mem://bucket/out/state.sql")
+
+	hd1, e := LoadParquet("mem://bucket/out/state.parquet", "state")
+	assert.Nil(t, e)
+	assert.ElementsMatch(t, hd.Geos(), hd1.Geos())
+
+	_, e = Load("mem://bucket/none.xlsx")
+	assert.ErrorIs(t, e, os.ErrNotExist)
+
+	_, e = Load("s3://bucket/hpi/state.xlsx")
+	assert.ErrorContains(t, e, "no blob opener")
+}
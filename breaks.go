@@ -0,0 +1,96 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Breaks returns the dates (CCYYQ) at which the trend growth of h changes significantly, in date order.
+// The log index is fit with a piecewise-linear trend by binary segmentation of the quarterly log growth: the
+// split that most reduces the squared error around the segment means is kept while it passes a BIC test, up
+// to maxBreaks splits.  Each segment has at least minQtrs quarters (8 if 0).  A break date is the first
+// quarter of the new trend.
+func (h *HPIseries) Breaks(maxBreaks, minQtrs int) ([]int, error) {
+	if minQtrs == 0 {
+		minQtrs = 8
+	}
+
+	if minQtrs < 3 {
+		return nil, fmt.Errorf("minQtrs must be at least 3, got %d", minQtrs)
+	}
+
+	if maxBreaks < 1 {
+		return nil, fmt.Errorf("maxBreaks must be positive")
+	}
+
+	if len(h.indx) < 2 {
+		return nil, nil
+	}
+
+	// y[j] is the growth into h.dates[j+1]
+	y := make([]float64, len(h.indx)-1)
+	for j := range y {
+		y[j] = math.Log(h.indx[j+1] / h.indx[j])
+	}
+
+	// a split is worth 2 parameters: a mean and the break date
+	penalty := 2 * math.Log(float64(len(y)))
+
+	type segment struct{ start, end int }
+	segs := []segment{{0, len(y)}}
+
+	var breaks []int
+	for len(breaks) < maxBreaks {
+		bestSeg, bestAt, bestStat := -1, 0, penalty
+		for k, s := range segs {
+			at, stat := bestSplit(y[s.start:s.end], minQtrs)
+			if at > 0 && stat > bestStat {
+				bestSeg, bestAt, bestStat = k, s.start+at, stat
+			}
+		}
+
+		if bestSeg < 0 {
+			break
+		}
+
+		s := segs[bestSeg]
+		segs = append(segs[:bestSeg], append([]segment{{s.start, bestAt}, {bestAt, s.end}}, segs[bestSeg+1:]...)...)
+		breaks = append(breaks, h.dates[bestAt+1])
+	}
+
+	sort.Ints(breaks)
+
+	return breaks, nil
+}
+
+// bestSplit returns the position that best splits y into two segments with different means and the
+// likelihood-ratio statistic of the split.  at is 0 if y can't be split.
+func bestSplit(y []float64, minLen int) (at int, stat float64) {
+	sse := meanSSE(y)
+
+	// no variation beyond rounding error
+	if len(y) < 2*minLen || sse/float64(len(y)) < 1e-12 {
+		return 0, 0
+	}
+
+	best := math.Inf(1)
+	for j := minLen; j <= len(y)-minLen; j++ {
+		if s := meanSSE(y[:j]) + meanSSE(y[j:]); s < best {
+			at, best = j, s
+		}
+	}
+
+	if best <= 0 {
+		return at, math.Inf(1)
+	}
+
+	return at, float64(len(y)) * math.Log(sse/best)
+}
+
+// meanSSE returns the sum of squared deviations of y from its mean.
+func meanSSE(y []float64) float64 {
+	_, sd := meanSD(y)
+
+	return sd * sd * float64(len(y)-1)
+}
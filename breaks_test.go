@@ -0,0 +1,54 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_Breaks(t *testing.T) {
+	var (
+		dts  []int
+		indx []float64
+	)
+
+	// 20 quarters up 2%, 20 down 1%, 20 flat, with a bit of noise
+	dt, v := 20001, 100.0
+	for j := range 60 {
+		g := 0.02
+		switch {
+		case j >= 40:
+			g = 0
+		case j >= 20:
+			g = -0.01
+		}
+
+		v *= 1 + g
+		noise := 1 + 0.001*float64(j%3-1)
+		dts = append(dts, dt)
+		indx = append(indx, v*noise)
+		dt = NextQtr(dt)
+	}
+
+	h, e := NewHPIseries("X", "X", dts, indx)
+	assert.Nil(t, e)
+
+	br, e := h.Breaks(5, 0)
+	assert.Nil(t, e)
+	assert.Len(t, br, 2)
+	assert.InDelta(t, 20, QtrDiff(20001, br[0]), 1)
+	assert.InDelta(t, 40, QtrDiff(20001, br[1]), 1)
+
+	br, e = h.Breaks(1, 0)
+	assert.Nil(t, e)
+	assert.Len(t, br, 1)
+
+	// constant growth has no breaks
+	ca, _ := testData().Geo("CA")
+	br, e = ca.Breaks(5, 0)
+	assert.Nil(t, e)
+	assert.Len(t, br, 0)
+
+	_, e = ca.Breaks(0, 0)
+	assert.NotNil(t, e)
+}
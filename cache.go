@@ -0,0 +1,136 @@
+package fhfa
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// LookupCache is a concurrent-safe LRU cache of index lookups in front of a Manager, for services answering
+// many lookups of the same (level, geo, quarter).  Entries for a geo level are dropped when its data is
+// replaced (Swap, Update, Refresh).  Failed lookups aren't cached.
+type LookupCache struct {
+	m    *Manager
+	size int
+
+	mu    sync.Mutex
+	gen   uint64 // incremented when entries are dropped
+	lru   *list.List
+	items map[cacheKey]*list.Element
+}
+
+// cacheKey identifies a lookup.
+type cacheKey struct {
+	level, geo string
+	dt         int
+}
+
+// cacheEntry is an element of the LRU list.
+type cacheEntry struct {
+	key  cacheKey
+	indx float64
+}
+
+// NewLookupCache creates a cache of up to size lookups from m.
+func NewLookupCache(m *Manager, size int) (*LookupCache, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("cache size must be positive")
+	}
+
+	c := &LookupCache{
+		m:     m,
+		size:  size,
+		lru:   list.New(),
+		items: make(map[cacheKey]*list.Element),
+	}
+
+	m.onSwap(c.invalidate)
+
+	return c, nil
+}
+
+// Index returns the index for geo at geoLevel at dt (CCYYQ).  geo is normalized with NormalizeGeo, so "ca" and
+// "California" share the entry for CA.
+func (c *LookupCache) Index(geoLevel, geo string, dt int) (float64, error) {
+	key := cacheKey{geoLevel, NormalizeGeo(geoLevel, geo), dt}
+
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.lru.MoveToFront(el)
+		v := el.Value.(*cacheEntry).indx
+		c.mu.Unlock()
+		currentMetrics().Cache(true)
+
+		return v, nil
+	}
+	gen := c.gen
+	c.mu.Unlock()
+	currentMetrics().Cache(false)
+
+	v, e := c.m.Index(geoLevel, geo, dt)
+	if e != nil {
+		return 0, e
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// don't cache a value read from data that has since been replaced
+	if gen != c.gen {
+		return v, nil
+	}
+
+	if _, ok := c.items[key]; !ok {
+		c.items[key] = c.lru.PushFront(&cacheEntry{key: key, indx: v})
+		if c.lru.Len() > c.size {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.items, oldest.Value.(*cacheEntry).key)
+		}
+	}
+
+	return v, nil
+}
+
+// Best returns the index at dt (CCYYQ) from the first of levels with data for its key, and the level used.
+// keys[j] is the geo to look up at levels[j].  See the package function Best.
+func (c *LookupCache) Best(dt int, keys, levels []string) (hpi float64, geoLevel string, e error) {
+	if len(keys) != len(levels) || len(levels) == 0 {
+		return 0, "", fmt.Errorf("invalid series")
+	}
+
+	errs := make([]error, len(levels))
+	for j, level := range levels {
+		indx, e := c.Index(level, keys[j], dt)
+		if e == nil {
+			return indx, level, nil
+		}
+
+		errs[j] = e
+	}
+
+	return 0, "", fmt.Errorf("geo/dt not found in Best: %w", errors.Join(errs...))
+}
+
+// Len returns the number of cached lookups.
+func (c *LookupCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// invalidate drops the entries for geoLevel.
+func (c *LookupCache) invalidate(geoLevel string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	for key, el := range c.items {
+		if key.level == geoLevel {
+			c.lru.Remove(el)
+			delete(c.items, key)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package fhfa
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupCache(t *testing.T) {
+	m := NewManager(testData(), testUS())
+	c, e := NewLookupCache(m, 2)
+	assert.Nil(t, e)
+
+	exp, _ := testData().Index("TX", 20051)
+	for range 2 {
+		v, e := c.Index("state", "TX", 20051)
+		assert.Nil(t, e)
+		assert.Equal(t, exp, v)
+	}
+	assert.Equal(t, 1, c.Len())
+
+	// spellings of the same geo share an entry
+	for _, geo := range []string{"tx", "Texas", " TX"} {
+		v, e := c.Index("state", geo, 20051)
+		assert.Nil(t, e)
+		assert.Equal(t, exp, v)
+	}
+	assert.Equal(t, 1, c.Len())
+
+	_, e = c.Index("state", "XX", 20051)
+	assert.NotNil(t, e)
+	assert.Equal(t, 1, c.Len())
+
+	// least recently used is dropped
+	_, _ = c.Index("state", "CA", 20051)
+	_, _ = c.Index("state", "TX", 20051)
+	_, _ = c.Index("state", "NY", 20051)
+	assert.Equal(t, 2, c.Len())
+	_, ok := c.items[cacheKey{"state", "CA", 20051}]
+	assert.False(t, ok)
+
+	v, level, e := c.Best(20051, []string{"XX", "USA"}, []string{"state", "us"})
+	assert.Nil(t, e)
+	assert.Equal(t, "us", level)
+	exp, _ = testUS().Index("USA", 20051)
+	assert.Equal(t, exp, v)
+
+	// refresh drops the level's entries
+	hd := testData()
+	for _, s := range hd.series {
+		s.indx[20] *= 2
+	}
+	m.Swap(hd)
+	_, ok = c.items[cacheKey{"us", "USA", 20051}]
+	assert.True(t, ok)
+	assert.Equal(t, 1, c.Len())
+
+	v, e = c.Index("state", "TX", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, hd.series["TX"].indx[20], v)
+
+	_, e = NewLookupCache(m, 0)
+	assert.NotNil(t, e)
+}
+
+func TestLookupCache_Concurrent(t *testing.T) {
+	m := NewManager(testData())
+	c, e := NewLookupCache(m, 10)
+	assert.Nil(t, e)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 1000 {
+				_, e := c.Index("state", "TX", AddQtrs(20001, j%40))
+				assert.Nil(t, e)
+			}
+		}()
+	}
+
+	for range 10 {
+		m.Swap(testData())
+	}
+	wg.Wait()
+}
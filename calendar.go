@@ -0,0 +1,96 @@
+package fhfa
+
+import (
+	"sync"
+	"time"
+)
+
+// ReleaseDate is the date of an FHFA quarterly HPI release and the latest quarter it includes.
+type ReleaseDate struct {
+	Date time.Time // release date (UTC)
+	Qtr  int       // latest quarter in the release (CCYYQ)
+}
+
+var (
+	calendarMu sync.RWMutex
+	calendar   = make(map[int]time.Time) // release dates set by SetReleaseDates, keyed by quarter
+)
+
+// SetReleaseDates overrides the release dates of the quarters of dates, e.g. with the dates FHFA publishes.
+// A zero Date removes the override.
+func SetReleaseDates(dates ...ReleaseDate) {
+	calendarMu.Lock()
+	defer calendarMu.Unlock()
+
+	for _, rd := range dates {
+		if rd.Date.IsZero() {
+			delete(calendar, rd.Qtr)
+			continue
+		}
+
+		calendar[rd.Qtr] = rd.Date.UTC()
+	}
+}
+
+// ReleaseFor returns the release date of quarter qtr (CCYYQ).  Unless overridden by SetReleaseDates, this is
+// FHFA's usual schedule: the last Tuesday of the second month after the quarter ends (e.g. late February for
+// the fourth quarter).  FHFA occasionally departs from it, so treat it as an estimate.
+func ReleaseFor(qtr int) (time.Time, error) {
+	if !YrQtr(qtr).Valid() {
+		return time.Time{}, badDate(qtr)
+	}
+
+	calendarMu.RLock()
+	dt, ok := calendar[qtr]
+	calendarMu.RUnlock()
+
+	if ok {
+		return dt, nil
+	}
+
+	// last day of the second month after the quarter, then back to Tuesday
+	end, _ := QtrEndDate(qtr)
+	last := time.Date(end.Year(), end.Month()+3, 0, 0, 0, 0, 0, time.UTC)
+	back := (int(last.Weekday()) - int(time.Tuesday) + 7) % 7
+
+	return last.AddDate(0, 0, -back), nil
+}
+
+// NextReleaseAfter returns the first release after t.  It returns an error if t is beyond the dates handled
+// by YrQtr.
+func NextReleaseAfter(t time.Time) (ReleaseDate, error) {
+	// the release after t is for a quarter at most 2 quarters before the quarter of t
+	for qtr := AddQtrs(ToYrQtr(t), -2); ; qtr = NextQtr(qtr) {
+		dt, e := ReleaseFor(qtr)
+		if e != nil {
+			return ReleaseDate{}, e
+		}
+
+		if dt.After(t) {
+			return ReleaseDate{Date: dt, Qtr: qtr}, nil
+		}
+	}
+}
+
+// ReleaseSchedule returns the next 4 releases, in date order.  Near the end of the dates handled by YrQtr
+// fewer than 4 are returned, and none beyond it.
+func ReleaseSchedule() []ReleaseDate {
+	return releaseScheduleAfter(time.Now())
+}
+
+// releaseScheduleAfter returns the next 4 releases after t.
+func releaseScheduleAfter(t time.Time) []ReleaseDate {
+	var sched []ReleaseDate
+
+	for range 4 {
+		rd, e := NextReleaseAfter(t)
+		if e != nil {
+			break
+		}
+
+		sched = append(sched, rd)
+		t = rd.Date
+	}
+
+	return sched
+}
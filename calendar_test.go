@@ -0,0 +1,60 @@
+package fhfa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseFor(t *testing.T) {
+	dt, e := ReleaseFor(20244)
+	assert.Nil(t, e)
+	assert.Equal(t, time.Date(2025, 2, 25, 0, 0, 0, 0, time.UTC), dt)
+
+	dt, e = ReleaseFor(20251)
+	assert.Nil(t, e)
+	assert.Equal(t, time.Date(2025, 5, 27, 0, 0, 0, 0, time.UTC), dt)
+
+	_, e = ReleaseFor(20255)
+	assert.ErrorIs(t, e, ErrBadDate)
+}
+
+func TestNextReleaseAfter(t *testing.T) {
+	rd, e := NextReleaseAfter(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, e)
+	assert.Equal(t, 20251, rd.Qtr)
+
+	// on the release date itself, the next one is returned
+	rd, _ = NextReleaseAfter(time.Date(2025, 5, 27, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 20252, rd.Qtr)
+
+	override := time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC)
+	SetReleaseDates(ReleaseDate{Date: override, Qtr: 20251})
+	defer SetReleaseDates(ReleaseDate{Qtr: 20251})
+
+	rd, _ = NextReleaseAfter(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, override, rd.Date)
+
+	// beyond the dates YrQtr handles
+	_, e = NextReleaseAfter(time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, e, ErrBadDate)
+}
+
+func TestReleaseSchedule(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	sched := releaseScheduleAfter(now)
+	assert.Len(t, sched, 4)
+	assert.True(t, sched[0].Date.After(now))
+	assert.Equal(t, 20251, sched[0].Qtr)
+
+	for j := 1; j < len(sched); j++ {
+		assert.Equal(t, NextQtr(sched[j-1].Qtr), sched[j].Qtr)
+	}
+
+	// truncated at the end of the dates YrQtr handles
+	sched = releaseScheduleAfter(time.Date(2060, 12, 1, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, sched, 1)
+	assert.Equal(t, 20604, sched[0].Qtr)
+	assert.Empty(t, releaseScheduleAfter(time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
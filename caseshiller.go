@@ -0,0 +1,144 @@
+package fhfa
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/invertedv/dass"
+)
+
+// caseShiller maps the geo keys of the S&P CoreLogic Case-Shiller indices to their FRED series IDs
+// (not seasonally adjusted) and names.
+var caseShiller = map[string]struct{ id, name string }{
+	"US": {"CSUSHPINSA", "U.S. National"},
+	"10": {"SPCS10RNSA", "10-City Composite"},
+	"20": {"SPCS20RNSA", "20-City Composite"},
+	"AT": {"ATXRNSA", "Atlanta"},
+	"BO": {"BOXRNSA", "Boston"},
+	"CH": {"CHXRNSA", "Chicago"},
+	"CR": {"CRXRNSA", "Charlotte"},
+	"CE": {"CEXRNSA", "Cleveland"},
+	"DA": {"DAXRNSA", "Dallas"},
+	"DN": {"DNXRNSA", "Denver"},
+	"DE": {"DEXRNSA", "Detroit"},
+	"LV": {"LVXRNSA", "Las Vegas"},
+	"LX": {"LXXRNSA", "Los Angeles"},
+	"MI": {"MIXRNSA", "Miami"},
+	"MN": {"MNXRNSA", "Minneapolis"},
+	"NY": {"NYXRNSA", "New York"},
+	"PH": {"PHXRNSA", "Phoenix"},
+	"PO": {"POXRNSA", "Portland"},
+	"SD": {"SDXRNSA", "San Diego"},
+	"SF": {"SFXRNSA", "San Francisco"},
+	"SE": {"SEXRNSA", "Seattle"},
+	"TP": {"TPXRNSA", "Tampa"},
+	"WD": {"WDXRNSA", "Washington"},
+}
+
+// CaseShillerGeos returns the geo keys of the Case-Shiller indices, in order: US (national), 10 and 20
+// (composites) and a 2-letter code for each city (e.g. LX for Los Angeles).
+func CaseShillerGeos() []string {
+	var geos []string
+	for k := range caseShiller {
+		geos = append(geos, k)
+	}
+	sort.Strings(geos)
+
+	return geos
+}
+
+// CaseShillerURL returns the address of a FRED CSV download of the Case-Shiller indices of geos (all of them
+// if geos is empty), suitable for LoadCaseShiller.
+func CaseShillerURL(geos ...string) (string, error) {
+	if len(geos) == 0 {
+		geos = CaseShillerGeos()
+	}
+
+	var ids []string
+	for _, geo := range geos {
+		cs, ok := caseShiller[strings.ToUpper(geo)]
+		if !ok {
+			return "", &GeoError{Geo: geo, Level: "cs"}
+		}
+
+		ids = append(ids, cs.id)
+	}
+
+	return FREDURL(strings.Join(ids, ",")), nil
+}
+
+// LoadCaseShiller loads S&P CoreLogic Case-Shiller indices from source - either a local file or a web address
+// (see CaseShillerURL) - in the FRED CSV layout: a header row of the date and the FRED series IDs, followed by a
+// row per month.  The monthly values are averaged to quarters, keeping only quarters with all 3 months present.
+// The geo level of the result is "cs" and the geos are those of CaseShillerGeos.  Columns that aren't
+// Case-Shiller series are ignored.
+func LoadCaseShiller(source string) (*HPIdata, error) {
+	r, e := dass.FetchCSV(source)
+	if e != nil {
+		return nil, e
+	}
+
+	if len(r) < 2 {
+		return nil, fmt.Errorf("no data in %s", source)
+	}
+
+	geos := make(map[string]string)
+	for k, cs := range caseShiller {
+		geos[cs.id] = k
+	}
+
+	hd := &HPIdata{
+		source:   source,
+		geoLevel: "cs",
+		series:   make(map[string]*HPIseries),
+		tags:     IndexTags{Adjustment: NSA, Frequency: Monthly},
+	}
+
+	for col, id := range r[0] {
+		geo, ok := geos[strings.TrimSpace(id)]
+		if col == 0 || !ok {
+			continue
+		}
+
+		var (
+			ts   []time.Time
+			vals []float64
+		)
+		for _, row := range r[1:] {
+			// FRED marks missing values with "." or leaves them blank
+			v, e := strconv.ParseFloat(strings.TrimSpace(row[col]), 64)
+			if e != nil {
+				continue
+			}
+
+			t, e := time.Parse(time.DateOnly, strings.TrimSpace(row[0]))
+			if e != nil {
+				return nil, fmt.Errorf("bad date in %s: %s", source, row[0])
+			}
+
+			ts = append(ts, t)
+			vals = append(vals, v)
+		}
+
+		dts, indx := toQuarterly(ts, vals)
+		if len(dts) == 0 {
+			continue
+		}
+
+		s, e := NewHPIseries(caseShiller[geo].name, geo, dts, indx)
+		if e != nil {
+			return nil, fmt.Errorf("geo %s: %w", geo, e)
+		}
+
+		hd.series[geo] = s
+	}
+
+	if len(hd.series) == 0 {
+		return nil, fmt.Errorf("no Case-Shiller series in %s", source)
+	}
+
+	return hd, nil
+}
@@ -0,0 +1,74 @@
+package fhfa
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseShillerURL(t *testing.T) {
+	u, e := CaseShillerURL("us", "LX")
+	assert.Nil(t, e)
+	assert.True(t, strings.HasSuffix(u, "?id=CSUSHPINSA,LXXRNSA"))
+
+	_, e = CaseShillerURL("XX")
+	assert.ErrorIs(t, e, ErrGeoNotFound)
+
+	assert.Len(t, CaseShillerGeos(), 23)
+}
+
+func TestLoadCaseShiller(t *testing.T) {
+	csv := `observation_date,CSUSHPINSA,LXXRNSA,OTHER
+2020-01-01,200,300,1
+2020-02-01,201,.,1
+2020-03-01,202,303,1
+2020-04-01,204,306,1
+2020-05-01,206,309,1
+2020-06-01,208,312,1
+2020-07-01,210,315,1
+`
+	file := filepath.Join(t.TempDir(), "cs.csv")
+	assert.Nil(t, os.WriteFile(file, []byte(csv), 0o644))
+
+	hd, e := LoadCaseShiller(file)
+	assert.Nil(t, e)
+	assert.Equal(t, "cs", hd.GeoLevel())
+	assert.ElementsMatch(t, []string{"US", "LX"}, hd.Geos())
+
+	v, e := hd.Index("us", 20202)
+	assert.Nil(t, e)
+	assert.Equal(t, 206.0, v)
+
+	// LX is missing a month of 20201 and US of 20203
+	s, e := hd.Geo("LX")
+	assert.Nil(t, e)
+	assert.Equal(t, []int{20202}, s.Dates())
+	assert.Equal(t, "Los Angeles", s.Name())
+}
+
+func TestProviders(t *testing.T) {
+	hd := testData()
+	cs := testData()
+	delete(cs.series, "TX")
+	cs.series["LX"] = cs.series["CA"]
+
+	p := Providers{hd, cs}
+	assert.Equal(t, []string{"CA", "LX", "NY", "TX"}, p.Geos())
+
+	v, e := p.Index("LX", 20001)
+	assert.Nil(t, e)
+	assert.Equal(t, 100.0, v)
+
+	r, e := p.Change("TX", 20001, 20011)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.01*1.01*1.01*1.01, r, 1e-10)
+
+	_, e = p.Index("XX", 20001)
+	assert.ErrorIs(t, e, ErrGeoNotFound)
+
+	_, e = Providers{}.Index("TX", 20001)
+	assert.NotNil(t, e)
+}
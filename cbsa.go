@@ -0,0 +1,173 @@
+package fhfa
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed data/cbsa.csv
+var cbsaCSV string
+
+// CBSA describes a core-based statistical area (metro area or metropolitan division).
+type CBSA struct {
+	Code   string   // CBSA code, e.g. 14460
+	Name   string   // name, e.g. "Boston-Cambridge-Newton, MA-NH"
+	States []string // postal codes of the states of the CBSA, principal state first
+}
+
+var (
+	cbsaOnce sync.Once
+	cbsaMu   sync.RWMutex
+	cbsas    map[string]*CBSA
+)
+
+// AddCBSA adds (or replaces) a CBSA in the reference table.  The states are taken from the name, which
+// must end with the postal codes of the states, e.g. "Memphis, TN-MS-AR".
+func AddCBSA(code, name string) error {
+	cbsaOnce.Do(loadCBSAs)
+
+	c, e := newCBSA(code, name)
+	if e != nil {
+		return e
+	}
+
+	cbsaMu.Lock()
+	defer cbsaMu.Unlock()
+
+	cbsas[c.Code] = c
+
+	return nil
+}
+
+// AddCBSAs adds the CBSAs of metro-level data to the reference table.  The embedded table covers the larger
+// metros, so this is the way to get full coverage from the metro file.
+func AddCBSAs(hd *HPIdata) error {
+	if hd.geoLevel != "metro" {
+		return fmt.Errorf("need metro data, got %s", hd.geoLevel)
+	}
+
+	for code, s := range hd.series {
+		if e := AddCBSA(code, s.geoName); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// CBSAInfo returns the reference data for the CBSA code.
+func CBSAInfo(code string) (CBSA, error) {
+	cbsaOnce.Do(loadCBSAs)
+
+	cbsaMu.RLock()
+	defer cbsaMu.RUnlock()
+
+	c, ok := cbsas[strings.TrimSpace(code)]
+	if !ok {
+		return CBSA{}, fmt.Errorf("CBSA %s not found", code)
+	}
+
+	return *c, nil
+}
+
+// CBSAName returns the name of the CBSA code.
+func CBSAName(code string) (string, error) {
+	c, e := CBSAInfo(code)
+
+	return c.Name, e
+}
+
+// CBSAState returns the principal state of the CBSA code.
+func CBSAState(code string) (string, error) {
+	c, e := CBSAInfo(code)
+	if e != nil {
+		return "", e
+	}
+
+	return c.States[0], nil
+}
+
+// CBSAsInState returns the codes, in order, of the CBSAs that include any part of the state st (postal code).
+func CBSAsInState(st string) []string {
+	cbsaOnce.Do(loadCBSAs)
+
+	st = strings.ToUpper(strings.TrimSpace(st))
+
+	cbsaMu.RLock()
+	defer cbsaMu.RUnlock()
+
+	var codes []string
+	for code, c := range cbsas {
+		if in(st, c.States) {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+// FindCBSA returns the codes, in order, of the CBSAs whose names contain substr, ignoring case.
+func FindCBSA(substr string) []string {
+	cbsaOnce.Do(loadCBSAs)
+
+	substr = strings.ToLower(substr)
+
+	cbsaMu.RLock()
+	defer cbsaMu.RUnlock()
+
+	var codes []string
+	for code, c := range cbsas {
+		if strings.Contains(strings.ToLower(c.Name), substr) {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+// loadCBSAs loads the embedded CBSA table
+func loadCBSAs() {
+	cbsas = make(map[string]*CBSA)
+
+	recs, e := csv.NewReader(strings.NewReader(cbsaCSV)).ReadAll()
+	if e != nil {
+		panic(e)
+	}
+
+	for _, rec := range recs[1:] {
+		c, e := newCBSA(rec[0], rec[1])
+		if e != nil {
+			panic(e)
+		}
+
+		cbsas[c.Code] = c
+	}
+}
+
+// newCBSA creates a CBSA, parsing the states from the end of the name, which may be followed by (MSAD)
+func newCBSA(code, name string) (*CBSA, error) {
+	code, name = strings.TrimSpace(code), strings.TrimSpace(name)
+
+	// FHFA suffixes metropolitan division names with (MSAD)
+	stName := strings.TrimSpace(strings.TrimSuffix(name, "(MSAD)"))
+
+	indx := strings.LastIndex(stName, ", ")
+	if code == "" || indx < 0 {
+		return nil, fmt.Errorf("cannot parse CBSA %s: %s", code, name)
+	}
+
+	states := strings.Split(stName[indx+2:], "-")
+	for _, st := range states {
+		if len(st) != 2 {
+			return nil, fmt.Errorf("cannot parse states of CBSA %s: %s", code, name)
+		}
+	}
+
+	return &CBSA{Code: code, Name: name, States: states}, nil
+}
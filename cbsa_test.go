@@ -0,0 +1,42 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCBSA(t *testing.T) {
+	name, e := CBSAName("10180")
+	assert.Nil(t, e)
+	assert.Equal(t, "Abilene, TX", name)
+
+	st, e := CBSAState("32820")
+	assert.Nil(t, e)
+	assert.Equal(t, "TN", st)
+
+	assert.Contains(t, CBSAsInState("ar"), "32820")
+	assert.Equal(t, []string{"44140", "44180"}, FindCBSA("springfield"))
+
+	_, e = CBSAName("99999")
+	assert.NotNil(t, e)
+
+	metro := testLevel(testData(), "metro")
+	metro.series = map[string]*HPIseries{"99999": {geoName: "Nowhere-Someplace, WY-MT"}}
+	assert.Nil(t, AddCBSAs(metro))
+
+	c, e := CBSAInfo("99999")
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"WY", "MT"}, c.States)
+
+	// division names end in (MSAD)
+	metro.series = map[string]*HPIseries{"99998": {geoName: "Anaheim-Santa Ana-Irvine, CA (MSAD)"}}
+	assert.Nil(t, AddCBSAs(metro))
+	c, e = CBSAInfo("99998")
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"CA"}, c.States)
+	assert.Equal(t, "Anaheim-Santa Ana-Irvine, CA (MSAD)", c.Name)
+
+	assert.NotNil(t, AddCBSA("1", "no states"))
+	assert.NotNil(t, AddCBSAs(testData()))
+}
@@ -0,0 +1,29 @@
+package fhfa
+
+// ChangeAll returns the ratio of the index at dtEnd (CCYYQ) to dtStart (CCYYQ) for every geo in hd, e.g. the
+// appreciation of every metro since an origination cohort.  The ratios are computed concurrently.  Geos
+// without the data are reported in errs.
+func (hd *HPIdata) ChangeAll(dtStart, dtEnd int) (ratios map[string]float64, errs map[string]error) {
+	geos := hd.Geos()
+	r := make([]float64, len(geos))
+	es := make([]error, len(geos))
+
+	parallel(len(geos), 0, func(start, end int) {
+		for j := start; j < end; j++ {
+			r[j], es[j] = hd.series[geos[j]].Change(dtStart, dtEnd)
+		}
+	})
+
+	ratios = make(map[string]float64)
+	errs = make(map[string]error)
+	for j, geo := range geos {
+		if es[j] != nil {
+			errs[geo] = es[j]
+			continue
+		}
+
+		ratios[geo] = r[j]
+	}
+
+	return ratios, errs
+}
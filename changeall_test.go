@@ -0,0 +1,24 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_ChangeAll(t *testing.T) {
+	hd := testData()
+	s, _ := hd.Geo("NY")
+	s.dates, s.indx = s.dates[4:], s.indx[4:]
+
+	ratios, errs := hd.ChangeAll(20001, 20011)
+	assert.Len(t, ratios, 2)
+	assert.InDelta(t, 1.01*1.01*1.01*1.01, ratios["TX"], 1e-9)
+	assert.Contains(t, errs, "NY")
+
+	for geo, r := range ratios {
+		c, e := hd.Change(geo, 20001, 20011)
+		assert.Nil(t, e)
+		assert.Equal(t, c, r)
+	}
+}
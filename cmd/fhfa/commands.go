@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/invertedv/fhfa"
+)
+
+// levels are the geo levels FHFA publishes
+var levels = []string{"zip3", "metro", "nonmetro", "state", "us", "pr", "mh"}
+
+// fetch downloads the FHFA files for the levels in args.
+func fetch(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "directory to save the files in")
+	if e := fs.Parse(args); e != nil {
+		return e
+	}
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("fetch: need at least one level")
+	}
+
+	for _, level := range fs.Args() {
+		if !slices.Contains(levels, level) {
+			return fmt.Errorf("fetch: unknown level %s", level)
+		}
+
+		url := fhfa.URLs(level)
+		file := filepath.Join(*dir, filepath.Base(url))
+		if e := fhfa.Download(context.Background(), url, file); e != nil {
+			return fmt.Errorf("fetch %s: %w", level, e)
+		}
+
+		fmt.Fprintln(out, file)
+	}
+
+	return nil
+}
+
+// export writes the data in long format.
+func export(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	src := fs.String("src", "", "data source")
+	format := fs.String("format", "csv", "output format: csv, json (newline-delimited), parquet or binary")
+	outFile := fs.String("o", "", "output file")
+	if e := fs.Parse(args); e != nil {
+		return e
+	}
+
+	if *outFile == "" {
+		return fmt.Errorf("export: -o is required")
+	}
+
+	hd, e := load(*src)
+	if e != nil {
+		return e
+	}
+
+	switch *format {
+	case "csv":
+		e = hd.Save(*outFile)
+	case "json":
+		e = hd.SaveNDJSON(*outFile)
+	case "parquet":
+		e = hd.SaveParquet(*outFile)
+	case "binary":
+		e = hd.SaveBinary(*outFile)
+	default:
+		return fmt.Errorf("export: unknown format %s", *format)
+	}
+
+	if e != nil {
+		return e
+	}
+
+	fmt.Fprintln(out, *outFile)
+
+	return nil
+}
+
+// index prints the index for a geo and quarter.
+func index(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("index", flag.ContinueOnError)
+	src := fs.String("src", "", "data source")
+	if e := fs.Parse(args); e != nil {
+		return e
+	}
+
+	if fs.NArg() != 2 {
+		return fmt.Errorf("index: need geo and quarter")
+	}
+
+	dt, e := fhfa.ParseYrQtr(fs.Arg(1))
+	if e != nil {
+		return e
+	}
+
+	p, closer, e := provider(*src)
+	if e != nil {
+		return e
+	}
+	defer closer()
+
+	v, e := p.Index(fs.Arg(0), int(dt))
+	if e != nil {
+		return e
+	}
+
+	fmt.Fprintf(out, "%0.2f\n", v)
+
+	return nil
+}
+
+// change prints the ratio of the index at the end quarter to the start quarter.
+func change(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("change", flag.ContinueOnError)
+	src := fs.String("src", "", "data source")
+	if e := fs.Parse(args); e != nil {
+		return e
+	}
+
+	if fs.NArg() != 3 {
+		return fmt.Errorf("change: need geo, start and end quarters")
+	}
+
+	start, e := fhfa.ParseYrQtr(fs.Arg(1))
+	if e != nil {
+		return e
+	}
+
+	end, e := fhfa.ParseYrQtr(fs.Arg(2))
+	if e != nil {
+		return e
+	}
+
+	p, closer, e := provider(*src)
+	if e != nil {
+		return e
+	}
+	defer closer()
+
+	chg, e := p.Change(fs.Arg(0), int(start), int(end))
+	if e != nil {
+		return e
+	}
+
+	fmt.Fprintf(out, "%0.4f\n", chg)
+
+	return nil
+}
+
+// geos lists the geos in the data.
+func geos(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("geos", flag.ContinueOnError)
+	src := fs.String("src", "", "data source")
+	if e := fs.Parse(args); e != nil {
+		return e
+	}
+
+	hd, e := load(*src)
+	if e != nil {
+		return e
+	}
+
+	for geo, s := range hd.All() {
+		if s.Name() != geo {
+			fmt.Fprintf(out, "%s\t%s\n", geo, s.Name())
+			continue
+		}
+
+		fmt.Fprintln(out, geo)
+	}
+
+	return nil
+}
+
+// diff prints the quarters whose values differ between two releases.
+func diff(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	oldSrc := fs.String("old", "", "original release")
+	newSrc := fs.String("new", "", "revised release")
+	geo := fs.String("geo", "", "limit the comparison to this geo")
+	if e := fs.Parse(args); e != nil {
+		return e
+	}
+
+	orig, e := load(*oldSrc)
+	if e != nil {
+		return e
+	}
+
+	rev, e := load(*newSrc)
+	if e != nil {
+		return e
+	}
+
+	r, e := fhfa.MergeReleases(orig, rev)
+	if e != nil {
+		return e
+	}
+
+	geos := r.Geos()
+	if *geo != "" {
+		geos = []string{*geo}
+	}
+
+	fmt.Fprintln(out, "geo,date,original,revised,revision")
+	for _, g := range geos {
+		revs, e := r.Revisions(g)
+		if e != nil {
+			return e
+		}
+
+		for _, rv := range revs {
+			switch {
+			case !rv.InOriginal:
+				fmt.Fprintf(out, "%s,%d,,%0.2f,added\n", g, rv.Dt, rv.Revised)
+			case !rv.InRevised:
+				fmt.Fprintf(out, "%s,%d,%0.2f,,dropped\n", g, rv.Dt, rv.Original)
+			case rv.Original != rv.Revised:
+				fmt.Fprintf(out, "%s,%d,%0.2f,%0.2f,%0.4f\n", g, rv.Dt, rv.Original, rv.Revised, rv.Revised/rv.Original-1)
+			}
+		}
+	}
+
+	return nil
+}
+
+// load loads src, which may be a geo level, a URL or a local file.
+func load(src string) (*fhfa.HPIdata, error) {
+	if src == "" {
+		return nil, fmt.Errorf("-src is required")
+	}
+
+	if slices.Contains(levels, strings.ToLower(src)) {
+		src = fhfa.URLs(src)
+	}
+
+	return fhfa.Load(src)
+}
+
+// provider opens src for lookups.  A binary cache (.bin) is queried in place rather than loaded.
+func provider(src string) (p fhfa.IndexProvider, closer func() error, e error) {
+	if strings.HasSuffix(src, ".bin") {
+		bc, e := fhfa.OpenBinary(src)
+		if e != nil {
+			return nil, nil, e
+		}
+
+		return bc, bc.Close, nil
+	}
+
+	hd, e := load(src)
+	if e != nil {
+		return nil, nil, e
+	}
+
+	return hd, func() error { return nil }, nil
+}
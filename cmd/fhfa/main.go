@@ -0,0 +1,54 @@
+// Command fhfa fetches, queries and exports the FHFA house price indices.
+//
+// Usage:
+//
+//	fhfa fetch  [-dir dir] level...                   download the xlsx files for the levels
+//	fhfa export -src src [-format fmt] -o file       write the data (csv, json, parquet or binary)
+//	fhfa index  -src src geo yrqtr                   look up the index
+//	fhfa change -src src geo start end               appreciation between two quarters
+//	fhfa geos   -src src                             list the geos
+//	fhfa diff   -old src -new src [-geo geo]         compare two releases
+//	fhfa serve  [-addr :8080] [-every 2184h] src...  serve the REST API, refreshing on a schedule
+//
+// A src is a local xlsx file, a URL or a geo level (zip3, metro, nonmetro, state, us, pr, mh), which
+// downloads the current FHFA file.  index and change query a binary cache (a .bin file written by
+// export -format binary) in place, without loading it.  Quarters may be written as 2023Q1 or 20231.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if e := run(os.Args[1:], os.Stdout); e != nil {
+		fmt.Fprintln(os.Stderr, "fhfa:", e)
+		os.Exit(1)
+	}
+}
+
+// commands maps each subcommand to its implementation.
+var commands = map[string]func(args []string, out io.Writer) error{
+	"fetch":  fetch,
+	"export": export,
+	"index":  index,
+	"change": change,
+	"geos":   geos,
+	"diff":   diff,
+	"serve":  serve,
+}
+
+// run executes the subcommand in args, writing results to out.
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: fhfa fetch|export|index|change|geos|diff|serve [flags] [args]")
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %s", args[0])
+	}
+
+	return cmd(args[1:], out)
+}
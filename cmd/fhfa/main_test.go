@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+// testFile writes a state-level file in the FHFA layout with the given TX values starting in 2023Q1.
+func testFile(t *testing.T, name string, tx ...float64) string {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+
+	rows := [][]any{
+		{"House Price Index for the 50 States and the District of Columbia"},
+		{"State", "Year", "Quarter", "Index (NSA)"},
+	}
+
+	// rows are grouped by geo, as in the FHFA files
+	for _, geo := range []string{"CA", "TX"} {
+		dt := 20231
+		for _, v := range tx {
+			if geo == "CA" {
+				v *= 2
+			}
+
+			rows = append(rows, []any{geo, dt / 10, dt % 10, v})
+			dt++
+		}
+	}
+
+	for j, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, j+1)
+		assert.Nil(t, f.SetSheetRow(sheet, cell, &row))
+	}
+
+	file := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, f.SaveAs(file))
+
+	return file
+}
+
+func TestRun(t *testing.T) {
+	src := testFile(t, "old.xlsx", 100, 101, 102)
+
+	var out bytes.Buffer
+	assert.Nil(t, run([]string{"index", "-src", src, "TX", "2023Q2"}, &out))
+	assert.Equal(t, "101.00\n", out.String())
+
+	out.Reset()
+	assert.Nil(t, run([]string{"change", "-src", src, "CA", "20231", "2023Q3"}, &out))
+	assert.Equal(t, "1.0200\n", out.String())
+
+	out.Reset()
+	assert.Nil(t, run([]string{"geos", "-src", src}, &out))
+	assert.Equal(t, "CA\nTX\n", out.String())
+
+	dir := t.TempDir()
+	for _, format := range []string{"csv", "json", "parquet"} {
+		file := filepath.Join(dir, "out."+format)
+		assert.Nil(t, run([]string{"export", "-src", src, "-format", format, "-o", file}, &out))
+
+		b, e := os.ReadFile(file)
+		assert.Nil(t, e)
+		assert.True(t, strings.Contains(string(b), "TX"))
+	}
+
+	bin := filepath.Join(dir, "out.bin")
+	assert.Nil(t, run([]string{"export", "-src", src, "-format", "binary", "-o", bin}, &out))
+	out.Reset()
+	assert.Nil(t, run([]string{"index", "-src", bin, "TX", "2023Q2"}, &out))
+	assert.Equal(t, "101.00\n", out.String())
+
+	revised := testFile(t, "new.xlsx", 100, 101.5, 102, 103)
+	out.Reset()
+	assert.Nil(t, run([]string{"diff", "-old", src, "-new", revised, "-geo", "TX"}, &out))
+	assert.Equal(t, "geo,date,original,revised,revision\nTX,20232,101.00,101.50,0.0050\nTX,20234,,103.00,added\n", out.String())
+
+	assert.NotNil(t, run([]string{"index", "-src", src, "TX", "2023Q5"}, &out))
+	assert.NotNil(t, run([]string{"bogus"}, &out))
+	assert.NotNil(t, run(nil, &out))
+}
+
+func TestNewServer(t *testing.T) {
+	src := testFile(t, "state.xlsx", 100, 101, 102)
+
+	srv, m, sources, e := newServer([]string{src})
+	assert.Nil(t, e)
+	assert.Equal(t, []string{src}, sources)
+	assert.Equal(t, []string{"state"}, m.GeoLevels())
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/index/state/TX/2023Q3", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"index":102`)
+
+	_, _, _, e = newServer(nil)
+	assert.NotNil(t, e)
+}
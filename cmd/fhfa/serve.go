@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/invertedv/fhfa"
+	"github.com/invertedv/fhfa/hpiserver"
+)
+
+// quarter is the default refresh period; FHFA publishes quarterly
+const quarter = 91 * 24 * time.Hour
+
+// serve loads the sources in args and serves the REST API (see package hpiserver), refreshing the data
+// on a schedule, until interrupted.
+func serve(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	every := fs.Duration("every", quarter, "refresh period")
+	if e := fs.Parse(args); e != nil {
+		return e
+	}
+
+	srv, m, sources, e := newServer(fs.Args())
+	if e != nil {
+		return e
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := log.New(out, "fhfa serve: ", log.LstdFlags)
+	go m.Run(ctx, sources, *every, func(e error) { logger.Println(e) })
+
+	hs := &http.Server{Addr: *addr, Handler: srv, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+
+		shutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = hs.Shutdown(shutCtx)
+	}()
+
+	logger.Printf("serving %v on %s", m.GeoLevels(), *addr)
+	if e := hs.ListenAndServe(); !errors.Is(e, http.ErrServerClosed) {
+		return e
+	}
+
+	return nil
+}
+
+// newServer loads each of srcs and returns the server, the manager holding the data and the sources
+// to refresh from.
+func newServer(srcs []string) (*hpiserver.Server, *fhfa.Manager, []string, error) {
+	if len(srcs) == 0 {
+		return nil, nil, nil, fmt.Errorf("serve: need at least one source")
+	}
+
+	var (
+		hds     []*fhfa.HPIdata
+		sources []string
+	)
+
+	for _, src := range srcs {
+		hd, e := load(src)
+		if e != nil {
+			return nil, nil, nil, fmt.Errorf("serve: %s: %w", src, e)
+		}
+
+		hds = append(hds, hd)
+		sources = append(sources, hd.Source())
+	}
+
+	m := fhfa.NewManager(hds...)
+
+	return hpiserver.New(m), m, sources, nil
+}
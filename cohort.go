@@ -0,0 +1,83 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+)
+
+// CohortCurves holds cumulative appreciation by origination cohort: Curves[i][a] is the ratio of the index
+// a quarters after cohort Cohorts[i] to the index at the cohort, so every curve starts at 1.  Ages past the
+// end of the data are NaN.  These are the usual input to prepayment and default seasoning analysis.
+type CohortCurves struct {
+	Geo     string      // geo of the series
+	Cohorts []int       // cohort quarters (CCYYQ), in order
+	Curves  [][]float64 // cohort x age
+}
+
+// CohortCurves returns the appreciation curves of h for the cohorts firstCohort through lastCohort (CCYYQ)
+// and ages 0 through maxAge quarters.  If maxAge is 0, the curves run to the end of h.  firstCohort may not be
+// after the end of h.
+func (h *HPIseries) CohortCurves(firstCohort, lastCohort, maxAge int) (*CohortCurves, error) {
+	if !YrQtr(firstCohort).Valid() {
+		return nil, badDate(firstCohort)
+	}
+
+	if !YrQtr(lastCohort).Valid() {
+		return nil, badDate(lastCohort)
+	}
+
+	if lastCohort < firstCohort || maxAge < 0 {
+		return nil, fmt.Errorf("no cohorts between %d and %d", firstCohort, lastCohort)
+	}
+
+	end, _ := h.End()
+	if firstCohort > end {
+		return nil, &DateRangeError{Dt: firstCohort, First: h.dates[0], Last: end}
+	}
+
+	if maxAge == 0 {
+		maxAge = QtrDiffSigned(firstCohort, end)
+	}
+
+	cc := &CohortCurves{Geo: h.geoCode}
+	for c := firstCohort; c <= lastCohort; c = NextQtr(c) {
+		base, e := h.Index(c)
+		if e != nil {
+			return nil, fmt.Errorf("cohort %d: %w", c, e)
+		}
+
+		curve := make([]float64, maxAge+1)
+		for a, dt := 0, c; a <= maxAge; a, dt = a+1, NextQtr(dt) {
+			curve[a] = math.NaN()
+			if dt > end {
+				continue
+			}
+
+			if v, e := h.Index(dt); e == nil {
+				curve[a] = v / base
+			}
+		}
+
+		cc.Cohorts = append(cc.Cohorts, c)
+		cc.Curves = append(cc.Curves, curve)
+	}
+
+	return cc, nil
+}
+
+// CohortCurves returns the appreciation curves of geo.  See HPIseries.CohortCurves.
+func (hd *HPIdata) CohortCurves(geo string, firstCohort, lastCohort, maxAge int) (*CohortCurves, error) {
+	s, e := hd.Geo(geo)
+	if e != nil {
+		return nil, e
+	}
+
+	cc, e := s.CohortCurves(firstCohort, lastCohort, maxAge)
+	if e != nil {
+		return nil, e
+	}
+
+	cc.Geo = NormalizeGeo(hd.geoLevel, geo)
+
+	return cc, nil
+}
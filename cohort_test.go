@@ -0,0 +1,40 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_CohortCurves(t *testing.T) {
+	hd := testData()
+
+	cc, e := hd.CohortCurves("ca", 20091, 20094, 2)
+	assert.Nil(t, e)
+	assert.Equal(t, "CA", cc.Geo)
+	assert.Equal(t, []int{20091, 20092, 20093, 20094}, cc.Cohorts)
+	assert.Len(t, cc.Curves, 4)
+
+	assert.Equal(t, 1.0, cc.Curves[0][0])
+	assert.InDelta(t, 1.02*1.02, cc.Curves[0][2], 1e-9)
+	assert.InDelta(t, 1.02, cc.Curves[2][1], 1e-9)
+	assert.True(t, math.IsNaN(cc.Curves[2][2]))
+	assert.True(t, math.IsNaN(cc.Curves[3][1]))
+
+	// to the end of the data
+	cc, e = hd.CohortCurves("CA", 20001, 20001, 0)
+	assert.Nil(t, e)
+	assert.Len(t, cc.Curves[0], 40)
+
+	_, e = hd.CohortCurves("CA", 19991, 20001, 4)
+	assert.NotNil(t, e)
+
+	_, e = hd.CohortCurves("CA", 20012, 20011, 4)
+	assert.NotNil(t, e)
+
+	// cohorts after the end of the data, even when they can be extrapolated
+	assert.Nil(t, hd.SetExtrapolation(Extrapolation{Method: ExtrapFlat}))
+	_, e = hd.CohortCurves("CA", 20121, 20121, 0)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,84 @@
+package fhfa
+
+import (
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Files written by Save, SaveNDJSON, SaveParquet and ReleaseTable.Save are compressed if their names end in
+// one of these extensions, e.g. state.csv.gz, and the loaders decompress sources with them.
+const (
+	extGzip = ".gz"
+	extZstd = ".zst"
+)
+
+// compressed returns the compression extension of name, blank if it isn't compressed.
+func compressed(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == extGzip || ext == extZstd {
+		return ext
+	}
+
+	return ""
+}
+
+// compressor compresses to a file and closes both when it is closed.
+type compressor struct {
+	io.WriteCloser
+	file   io.WriteCloser
+	closed bool
+}
+
+// compressWriter wraps file to compress according to the extension of name.
+func compressWriter(name string, file io.WriteCloser) (io.WriteCloser, error) {
+	switch compressed(name) {
+	case extGzip:
+		return &compressor{WriteCloser: gzip.NewWriter(file), file: file}, nil
+	case extZstd:
+		enc, e := zstd.NewWriter(file)
+		if e != nil {
+			return nil, e
+		}
+
+		return &compressor{WriteCloser: enc, file: file}, nil
+	default:
+		return file, nil
+	}
+}
+
+// Close flushes the compressed data and closes the file.  Calls after the first do nothing.
+func (c *compressor) Close() error {
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+	if e := c.WriteCloser.Close(); e != nil {
+		_ = c.file.Close()
+		return e
+	}
+
+	return c.file.Close()
+}
+
+// decompressReader wraps r to decompress according to the extension of name.  The caller must close the
+// result, which releases the decompressor but not r.
+func decompressReader(name string, r io.Reader) (io.ReadCloser, error) {
+	switch compressed(name) {
+	case extGzip:
+		return gzip.NewReader(r)
+	case extZstd:
+		dec, e := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if e != nil {
+			return nil, e
+		}
+
+		return dec.IOReadCloser(), nil
+	default:
+		return io.NopCloser(r), nil
+	}
+}
@@ -0,0 +1,62 @@
+package fhfa
+
+import (
+	"bufio"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompression(t *testing.T) {
+	hd := testData()
+	dir := t.TempDir()
+
+	// the CSV is gzipped
+	file := filepath.Join(dir, "hpi.csv.gz")
+	assert.Nil(t, hd.Save(file))
+	f, e := os.Open(file)
+	assert.Nil(t, e)
+	gz, e := gzip.NewReader(f)
+	assert.Nil(t, e)
+	line, e := bufio.NewReader(gz).ReadString('\n')
+	assert.Nil(t, e)
+	assert.Equal(t, "geo,code,date,index\n", line)
+	_ = f.Close()
+
+	// NDJSON with zstd
+	file = filepath.Join(dir, "hpi.ndjson.zst")
+	assert.Nil(t, hd.SaveNDJSON(file))
+	f, e = os.Open(file)
+	assert.Nil(t, e)
+	zr, e := zstd.NewReader(f)
+	assert.Nil(t, e)
+	line, e = bufio.NewReader(zr).ReadString('\n')
+	assert.Nil(t, e)
+	assert.Contains(t, line, `"geo":"CA"`)
+	zr.Close()
+	_ = f.Close()
+
+	// parquet round trips through both
+	for _, name := range []string{"hpi.parquet", "hpi.parquet.gz", "hpi.parquet.zst"} {
+		file = filepath.Join(dir, name)
+		assert.Nil(t, hd.SaveParquet(file))
+
+		hd1, e := LoadParquet(file, "state")
+		assert.Nil(t, e, name)
+		ok, diff := hd1.Equal(hd, 0)
+		assert.True(t, ok, diff)
+	}
+
+	// not really gzipped
+	file = filepath.Join(dir, "bad.parquet.gz")
+	assert.Nil(t, os.WriteFile(file, []byte("not gzip"), 0o644))
+	_, e = LoadParquet(file, "state")
+	assert.NotNil(t, e)
+
+	_, e = Load(filepath.Join(dir, "none.xlsx.gz"))
+	assert.NotNil(t, e)
+}
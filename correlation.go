@@ -0,0 +1,82 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+)
+
+// Correlation returns the correlation of the quarterly log returns of a and b over the dates they have
+// in common between dtStart and dtEnd (CCYYQ).
+func Correlation(a, b *HPIseries, dtStart, dtEnd int) (float64, error) {
+	ra, rb, e := alignedReturns(a, b, dtStart, dtEnd)
+	if e != nil {
+		return 0, e
+	}
+
+	_, sa := meanSD(ra)
+	_, sb := meanSD(rb)
+	if sa == 0 || sb == 0 {
+		return 0, fmt.Errorf("returns have no variation")
+	}
+
+	return covariance(ra, rb) / (sa * sb), nil
+}
+
+// Beta returns the slope of the regression of the quarterly log returns of series on those of benchmark
+// over the dates they have in common between dtStart and dtEnd (CCYYQ).
+func Beta(series, benchmark *HPIseries, dtStart, dtEnd int) (float64, error) {
+	rs, rb, e := alignedReturns(series, benchmark, dtStart, dtEnd)
+	if e != nil {
+		return 0, e
+	}
+
+	_, sb := meanSD(rb)
+	if sb == 0 {
+		return 0, fmt.Errorf("benchmark returns have no variation")
+	}
+
+	return covariance(rs, rb) / (sb * sb), nil
+}
+
+// alignedReturns returns the quarterly log returns of a and b for the quarters between dtStart and dtEnd
+// (CCYYQ) at which both series have the quarter and the one before it.
+func alignedReturns(a, b *HPIseries, dtStart, dtEnd int) (ra, rb []float64, e error) {
+	var first, last int
+	if first, last, e = a.span(dtStart, dtEnd); e != nil {
+		return nil, nil, e
+	}
+
+	for j := first + 1; j <= last; j++ {
+		dt, prior := a.dates[j], a.dates[j-1]
+		if QtrDiff(prior, dt) != 1 {
+			continue
+		}
+
+		k := b.exact(dt)
+		if k < 1 || b.dates[k-1] != prior {
+			continue
+		}
+
+		ra = append(ra, math.Log(a.indx[j]/a.indx[j-1]))
+		rb = append(rb, math.Log(b.indx[k]/b.indx[k-1]))
+	}
+
+	if len(ra) < 3 {
+		return nil, nil, fmt.Errorf("need at least 3 common quarterly returns, have %d", len(ra))
+	}
+
+	return ra, rb, nil
+}
+
+// covariance returns the sample covariance of x and y, which must be the same length.
+func covariance(x, y []float64) float64 {
+	mx, _ := meanSD(x)
+	my, _ := meanSD(y)
+
+	var cov float64
+	for j := range x {
+		cov += (x[j] - mx) * (y[j] - my)
+	}
+
+	return cov / float64(len(x)-1)
+}
@@ -0,0 +1,48 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelation(t *testing.T) {
+	ret := []float64{0.01, -0.02, 0.03, 0.015, -0.005, 0.02}
+
+	var (
+		dts        []int
+		va, vb, vc []float64
+	)
+	dt, a, b, c := 20101, 100.0, 100.0, 100.0
+	for j := range len(ret) + 1 {
+		dts = append(dts, dt)
+		va, vb, vc = append(va, a), append(vb, b), append(vc, c)
+
+		if j < len(ret) {
+			a *= math.Exp(ret[j])
+			b *= math.Exp(2*ret[j] + 0.01)
+			c *= math.Exp(-ret[j])
+		}
+		dt = NextQtr(dt)
+	}
+
+	sa, _ := NewHPIseries("A", "A", dts, va)
+	sb, _ := NewHPIseries("B", "B", dts[1:], vb[1:])
+	sc, _ := NewHPIseries("C", "C", dts, vc)
+
+	r, e := Correlation(sa, sb, 20101, 20124)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, 1.0, r, 1e-9)
+
+	r, e = Correlation(sa, sc, 20101, 20124)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, -1.0, r, 1e-9)
+
+	beta, e := Beta(sb, sa, 20101, 20124)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, 2.0, beta, 1e-9)
+
+	_, e = Beta(sb, sa, 20101, 20103)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,81 @@
+package fhfa
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Coverage describes the dates of one series.
+type Coverage struct {
+	Geo     string // geo of the series
+	Name    string // name of the geo
+	FirstDt int    // first quarter (CCYYQ)
+	LastDt  int    // last quarter (CCYYQ), including appended data
+	N       int    // number of observations
+	Missing int    // number of quarters missing between FirstDt and LastDt
+}
+
+// CoverageTable holds the coverage of each series.  It sorts by geo; use SortBy for other orders.
+type CoverageTable []Coverage
+
+func (ct CoverageTable) Len() int           { return len(ct) }
+func (ct CoverageTable) Less(i, j int) bool { return ct[i].Geo < ct[j].Geo }
+func (ct CoverageTable) Swap(i, j int)      { ct[i], ct[j] = ct[j], ct[i] }
+
+// SortBy sorts ct by less, keeping geo order among ties.
+func (ct CoverageTable) SortBy(less func(a, b *Coverage) bool) {
+	sort.SliceStable(ct, func(i, j int) bool { return less(&ct[i], &ct[j]) })
+}
+
+// Coverage returns the coverage of h.
+func (h *HPIseries) Coverage() Coverage {
+	first, _ := h.First()
+	last, _ := h.End()
+
+	return Coverage{
+		Geo:     h.geoCode,
+		Name:    h.geoName,
+		FirstDt: first,
+		LastDt:  last,
+		N:       h.Len(),
+		Missing: QtrDiff(first, last) + 1 - h.Len(),
+	}
+}
+
+// Len returns the number of series in hd.
+func (hd *HPIdata) Len() int {
+	return len(hd.series)
+}
+
+// DateRange returns the earliest and latest quarters (CCYYQ) across the series of hd, including appended
+// data.  It returns an error if hd has no series.
+func (hd *HPIdata) DateRange() (first, last int, e error) {
+	if len(hd.series) == 0 {
+		return 0, 0, fmt.Errorf("no series")
+	}
+
+	for _, s := range hd.series {
+		cv := s.Coverage()
+		if first == 0 || cv.FirstDt < first {
+			first = cv.FirstDt
+		}
+
+		last = max(last, cv.LastDt)
+	}
+
+	return first, last, nil
+}
+
+// Coverage returns the coverage of each series of hd, sorted by geo.
+func (hd *HPIdata) Coverage() CoverageTable {
+	ct := make(CoverageTable, 0, len(hd.series))
+	for geo, s := range hd.series {
+		cv := s.Coverage()
+		cv.Geo = geo
+		ct = append(ct, cv)
+	}
+
+	sort.Sort(ct)
+
+	return ct
+}
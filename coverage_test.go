@@ -0,0 +1,30 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_Coverage(t *testing.T) {
+	hd := testData()
+	assert.Equal(t, 3, hd.Len())
+
+	s, _ := hd.Geo("TX")
+	assert.Nil(t, s.Append([]int{20101, 20102}, []float64{200, 201}))
+
+	first, last, e := hd.DateRange()
+	assert.Nil(t, e)
+	assert.Equal(t, 20001, first)
+	assert.Equal(t, 20102, last)
+
+	ct := hd.Coverage()
+	assert.Equal(t, []string{"CA", "NY", "TX"}, []string{ct[0].Geo, ct[1].Geo, ct[2].Geo})
+	assert.Equal(t, Coverage{Geo: "TX", Name: "TX", FirstDt: 20001, LastDt: 20102, N: 42}, ct[2])
+
+	ct.SortBy(func(a, b *Coverage) bool { return a.N > b.N })
+	assert.Equal(t, "TX", ct[0].Geo)
+
+	_, _, e = (&HPIdata{}).DateRange()
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,101 @@
+package fhfa
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed data/zip3_state.csv
+var zip3StateCSV string
+
+var (
+	zip3StateOnce sync.Once
+	zip3States    map[string]string
+)
+
+// Zip3State returns the postal abbreviation of the state (or territory) of a 3-digit ZIP.  Military
+// (APO/FPO) prefixes have no state.
+func Zip3State(zip3 string) (string, error) {
+	zip3StateOnce.Do(loadZip3States)
+
+	z, e := padCode(zip3, 3)
+	if e != nil {
+		return "", e
+	}
+
+	st, ok := zip3States[z]
+	if !ok {
+		return "", fmt.Errorf("no state for zip3 %s", zip3)
+	}
+
+	return st, nil
+}
+
+// Zip5ToZip3 returns the 3-digit ZIP of a 5-digit ZIP.  ZIP+4 codes (12345-6789) are accepted, as are ZIPs
+// that have lost their leading zeros (e.g. 2134 for 02134).
+func Zip5ToZip3(zip5 string) (string, error) {
+	z, _, _ := strings.Cut(strings.TrimSpace(zip5), "-")
+
+	z, e := padCode(z, 5)
+	if e != nil {
+		return "", e
+	}
+
+	return z[:3], nil
+}
+
+// ZipKeys returns the zip3 and state of a 5-digit ZIP, the keys needed for a zip3 -> state -> us fallback.
+func ZipKeys(zip5 string) (zip3, state string, e error) {
+	if zip3, e = Zip5ToZip3(zip5); e != nil {
+		return "", "", e
+	}
+
+	if state, e = Zip3State(zip3); e != nil {
+		return "", "", e
+	}
+
+	return zip3, state, nil
+}
+
+// LookupZip is Lookup with the zip3 and state derived from the 5-digit ZIP zip5.
+func (fc *FallbackChain) LookupZip(zip5, cbsa string, dt int) (hpi float64, geoLevel string, e error) {
+	zip3, state, e := ZipKeys(zip5)
+	if e != nil {
+		return 0, "", e
+	}
+
+	return fc.Lookup(zip3, cbsa, state, dt)
+}
+
+// loadZip3States expands the embedded ranges of zip3s into zip3States
+func loadZip3States() {
+	zip3States = make(map[string]string)
+
+	for _, line := range strings.Split(strings.TrimSpace(zip3StateCSV), "\n")[1:] {
+		flds := strings.Split(line, ",")
+		first, _ := strconv.Atoi(flds[0])
+		last, _ := strconv.Atoi(flds[1])
+
+		for z := first; z <= last; z++ {
+			zip3States[fmt.Sprintf("%03d", z)] = flds[2]
+		}
+	}
+}
+
+// padCode checks code (e.g. a zip or FIPS code) is numeric with at most n digits and left-pads it with
+// zeros to n digits.
+func padCode(code string, n int) (string, error) {
+	code = strings.TrimSpace(code)
+	if code == "" || len(code) > n {
+		return "", fmt.Errorf("invalid code: %q", code)
+	}
+
+	if _, e := strconv.Atoi(code); e != nil || strings.HasPrefix(code, "-") || strings.HasPrefix(code, "+") {
+		return "", fmt.Errorf("invalid code: %q", code)
+	}
+
+	return strings.Repeat("0", n-len(code)) + code, nil
+}
@@ -0,0 +1,45 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip3State(t *testing.T) {
+	zips := []string{"837", "100", "021", "21", "055", "733", "969", "995"}
+	exp := []string{"ID", "NY", "MA", "MA", "MA", "TX", "GU", "AK"}
+
+	for j, z := range zips {
+		st, e := Zip3State(z)
+		assert.Nil(t, e)
+		assert.Equal(t, exp[j], st)
+	}
+
+	for _, z := range []string{"090", "1000", "abc", ""} {
+		_, e := Zip3State(z)
+		assert.NotNil(t, e)
+	}
+}
+
+func TestZipKeys(t *testing.T) {
+	zip3, st, e := ZipKeys("2134")
+	assert.Nil(t, e)
+	assert.Equal(t, "021", zip3)
+	assert.Equal(t, "MA", st)
+
+	zip3, st, e = ZipKeys("90210-1234")
+	assert.Nil(t, e)
+	assert.Equal(t, "902", zip3)
+	assert.Equal(t, "CA", st)
+
+	_, _, e = ZipKeys("123456")
+	assert.NotNil(t, e)
+
+	fc, e := NewFallbackChain(testData(), testUS())
+	assert.Nil(t, e)
+
+	_, level, e := fc.LookupZip("77002", "", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, "state", level)
+}
@@ -0,0 +1,61 @@
+package fhfa
+
+import "fmt"
+
+// DataFrame holds the data in long format as columns, one element per geo and quarter.  It is a minimal
+// column-oriented table that maps directly to the series of dataframe packages such as gota, e.g.
+//
+//	df := hd.ToDataFrame()
+//	gdf := dataframe.New(
+//	    series.New(df.Code, series.String, "geo"),
+//	    series.New(df.YrQtr, series.Int, "yrqtr"),
+//	    series.New(df.Index, series.Float, "index"))
+type DataFrame struct {
+	GeoLevel string    // geo level, e.g. state
+	Code     []string  // geo key (e.g. TX, 10180)
+	Name     []string  // geo name
+	YrQtr    []int     // date (CCYYQ)
+	Index    []float64 // index value
+}
+
+// ToDataFrame returns the data as a DataFrame in geo and date order.
+func (hd *HPIdata) ToDataFrame() *DataFrame {
+	recs := hd.Records()
+	df := &DataFrame{
+		GeoLevel: hd.geoLevel,
+		Code:     make([]string, len(recs)),
+		Name:     make([]string, len(recs)),
+		YrQtr:    make([]int, len(recs)),
+		Index:    make([]float64, len(recs)),
+	}
+
+	for j, r := range recs {
+		df.Code[j], df.Name[j], df.YrQtr[j], df.Index[j] = r.Code, r.Geo, r.YrQtr, r.Index
+	}
+
+	return df
+}
+
+// FromDataFrame builds HPIdata from df, whose rows may be in any order.  Name may be nil, in which case the
+// geo key is used as the name.
+func FromDataFrame(df *DataFrame) (*HPIdata, error) {
+	n := df.Len()
+	if len(df.YrQtr) != n || len(df.Index) != n || (df.Name != nil && len(df.Name) != n) {
+		return nil, fmt.Errorf("dataframe columns have different lengths")
+	}
+
+	recs := make([]Record, n)
+	for j := range n {
+		recs[j] = Record{GeoLevel: df.GeoLevel, Code: df.Code[j], YrQtr: df.YrQtr[j], Index: df.Index[j]}
+		if df.Name != nil {
+			recs[j].Geo = df.Name[j]
+		}
+	}
+
+	return FromRecords(recs)
+}
+
+// Len returns the number of rows in df.
+func (df *DataFrame) Len() int {
+	return len(df.Code)
+}
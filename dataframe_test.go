@@ -0,0 +1,30 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_ToDataFrame(t *testing.T) {
+	hd := testData()
+	df := hd.ToDataFrame()
+	assert.Equal(t, 120, df.Len())
+	assert.Equal(t, "state", df.GeoLevel)
+	assert.Equal(t, []string{"CA", "CA"}, df.Code[:2])
+	assert.Equal(t, []int{20001, 20002}, df.YrQtr[:2])
+
+	hd1, e := FromDataFrame(df)
+	assert.Nil(t, e)
+	ok, diff := hd1.Equal(hd, 0)
+	assert.True(t, ok, diff)
+
+	df.Name = nil
+	hd1, e = FromDataFrame(df)
+	assert.Nil(t, e)
+	assert.Equal(t, 3, hd1.Len())
+
+	df.Index = df.Index[1:]
+	_, e = FromDataFrame(df)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,158 @@
+package fhfa
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/invertedv/dass"
+)
+
+// County is the CBSA assignment of a county under an OMB delineation.
+type County struct {
+	FIPS     string // 5-digit state + county FIPS code
+	Name     string // county name
+	State    string // state name
+	CBSA     string // CBSA code, empty if the county is not in a CBSA
+	Division string // metropolitan division code, empty if none
+	Metro    bool   // true if the CBSA is a metropolitan (rather than micropolitan) area
+}
+
+// Delineation maps counties to CBSAs for an OMB delineation vintage.
+type Delineation struct {
+	vintage  int
+	counties map[string]*County
+}
+
+// DelineationURL returns the address of the Census Bureau delineation file (list 1) for vintage (e.g. 2023).
+// Vintages before 2023 are published as xls, which must be converted to xlsx or csv before loading.
+func DelineationURL(vintage int) string {
+	return fmt.Sprintf("https://www2.census.gov/programs-surveys/metro-micro/geographies/reference-files/%d/delineation-files/list1_%d.xlsx",
+		vintage, vintage)
+}
+
+// LoadDelineation loads the Census Bureau delineation file (list 1) from source - a local file or web address,
+// in xlsx or csv format.  vintage is the year of the delineation.
+func LoadDelineation(source string, vintage int) (*Delineation, error) {
+	need := []string{"CBSA Code", "Metropolitan Division Code", "Metropolitan/Micropolitan Statistical Area",
+		"County/County Equivalent", "State Name", "FIPS State Code", "FIPS County Code"}
+
+	r, cols, e := readDelineation(source, need)
+	if e != nil {
+		return nil, e
+	}
+
+	d := &Delineation{vintage: vintage, counties: make(map[string]*County)}
+	for _, row := range r {
+		if len(row) <= cols["FIPS County Code"] {
+			continue
+		}
+
+		fld := func(name string) string { return unquote(row[cols[name]]) }
+
+		st, e1 := padCode(fld("FIPS State Code"), 2)
+		cnty, e2 := padCode(fld("FIPS County Code"), 3)
+		if e1 != nil || e2 != nil {
+			continue
+		}
+
+		c := &County{
+			FIPS:     st + cnty,
+			Name:     fld("County/County Equivalent"),
+			State:    fld("State Name"),
+			CBSA:     fld("CBSA Code"),
+			Division: fld("Metropolitan Division Code"),
+			Metro:    strings.HasPrefix(fld("Metropolitan/Micropolitan Statistical Area"), "Metropolitan"),
+		}
+
+		d.counties[c.FIPS] = c
+	}
+
+	if len(d.counties) == 0 {
+		return nil, fmt.Errorf("no counties in delineation file %s", source)
+	}
+
+	return d, nil
+}
+
+// County returns the delineation data for the county with 5-digit FIPS code fips.
+func (d *Delineation) County(fips string) (County, error) {
+	f, e := padCode(fips, 5)
+	if e != nil {
+		return County{}, e
+	}
+
+	c, ok := d.counties[f]
+	if !ok {
+		return County{}, fmt.Errorf("county %s not in %d delineation", fips, d.vintage)
+	}
+
+	return *c, nil
+}
+
+// Route returns the CBSA code of the metro series for a county (5-digit FIPS), or "nonmetro" if the county
+// is not in a metropolitan area (counties outside CBSAs and in micropolitan areas both use the nonmetro series).
+// Counties absent from the delineation file are outside any CBSA, so they also route to nonmetro.
+func (d *Delineation) Route(fips string) (string, error) {
+	f, e := padCode(fips, 5)
+	if e != nil {
+		return "", e
+	}
+
+	c, ok := d.counties[f]
+	if !ok || !c.Metro {
+		return "nonmetro", nil
+	}
+
+	return c.CBSA, nil
+}
+
+// Vintage returns the year of the delineation.
+func (d *Delineation) Vintage() int {
+	return d.vintage
+}
+
+// readDelineation reads a Census Bureau delineation file from source, in xlsx or csv format, returning the
+// data rows and the positions of the columns.  The first column of need starts the header row.
+func readDelineation(source string, need []string) (rows [][]string, cols map[string]int, e error) {
+	var r [][]string
+	if strings.HasSuffix(strings.ToLower(source), ".csv") {
+		r, e = dass.FetchCSV(source)
+	} else {
+		r, e = dass.FetchXLSX(source)
+	}
+
+	if e != nil {
+		return nil, nil, e
+	}
+
+	// the header row is preceded by title rows and the data is followed by footnotes
+	hdr := -1
+	for j, row := range r {
+		if len(row) > 0 && unquote(row[0]) == need[0] {
+			hdr = j
+			break
+		}
+	}
+
+	if hdr < 0 {
+		return nil, nil, fmt.Errorf("no header row in delineation file %s", source)
+	}
+
+	cols = make(map[string]int)
+	for j, name := range r[hdr] {
+		cols[unquote(name)] = j
+	}
+
+	for _, n := range need {
+		if _, ok := cols[n]; !ok {
+			return nil, nil, fmt.Errorf("column %s missing from delineation file %s", n, source)
+		}
+	}
+
+	return r[hdr+1:], cols, nil
+}
+
+// unquote trims spaces and surrounding double quotes
+func unquote(s string) string {
+	return strings.Trim(strings.TrimSpace(s), `"`)
+}
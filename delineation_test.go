@@ -0,0 +1,54 @@
+package fhfa
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDelineation writes a small delineation file in the Census list 1 layout and returns its path.
+func testDelineation(t *testing.T) string {
+	contents := `List 1. CORE BASED STATISTICAL AREAS (CBSAs) AND METROPOLITAN DIVISIONS
+CBSA Code,Metropolitan Division Code,CSA Code,CBSA Title,Metropolitan/Micropolitan Statistical Area,Metropolitan Division Title,CSA Title,County/County Equivalent,State Name,FIPS State Code,FIPS County Code,Central/Outlying County
+10180,,101,"Abilene, TX",Metropolitan Statistical Area,,,Callahan County,Texas,48,059,Outlying
+10180,,101,"Abilene, TX",Metropolitan Statistical Area,,,Taylor County,Texas,48,441,Central
+35620,35614,408,"New York-Newark-Jersey City, NY-NJ",Metropolitan Statistical Area,"New York-Jersey City-White Plains, NY-NJ","New York-Newark, NY-NJ-CT-PA",Kings County,New York,36,047,Central
+35620,35154,408,"New York-Newark-Jersey City, NY-NJ",Metropolitan Statistical Area,"New Brunswick-Lakewood, NJ","New York-Newark, NY-NJ-CT-PA",Middlesex County,New Jersey,34,023,Central
+10100,,,"Aberdeen, SD",Micropolitan Statistical Area,,,Brown County,South Dakota,46,013,Central
+14460,14454,148,"Boston-Cambridge-Newton, MA-NH",Metropolitan Statistical Area,"Boston, MA","Boston-Worcester-Providence, MA-RI-NH",Norfolk County,Massachusetts,25,021,Central
+Note: footnote
+`
+	file := fmt.Sprintf("%s/list1.csv", t.TempDir())
+	assert.Nil(t, os.WriteFile(file, []byte(contents), 0o644))
+
+	return file
+}
+
+func TestLoadDelineation(t *testing.T) {
+	d, e := LoadDelineation(testDelineation(t), 2023)
+	assert.Nil(t, e)
+	assert.Equal(t, 2023, d.Vintage())
+
+	c, e := d.County("36047")
+	assert.Nil(t, e)
+	assert.Equal(t, "35620", c.CBSA)
+	assert.Equal(t, "35614", c.Division)
+	assert.Equal(t, "Kings County", c.Name)
+	assert.True(t, c.Metro)
+
+	fips := []string{"48441", "46013", "01001", "25021"}
+	exp := []string{"10180", "nonmetro", "nonmetro", "14460"}
+	for j, f := range fips {
+		cbsa, e := d.Route(f)
+		assert.Nil(t, e)
+		assert.Equal(t, exp[j], cbsa)
+	}
+
+	_, e = d.Route("x")
+	assert.NotNil(t, e)
+
+	_, e = d.County("01001")
+	assert.NotNil(t, e)
+}
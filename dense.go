@@ -0,0 +1,87 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// DenseValues selects what HPIdata.Dense puts in the matrix.
+type DenseValues int
+
+const (
+	// DenseLevels are the index values.
+	DenseLevels DenseValues = iota
+
+	// DenseLogReturns are the quarterly log returns, log(index[t]/index[t-1]).
+	DenseLogReturns
+)
+
+// Dense returns the data for geos between dtStart and dtEnd (CCYYQ) as a matrix with a row per quarter and
+// a column per geo, ready for PCA or factor analysis with gonum.  The dates of the rows and the geos of the
+// columns are also returned.  If geos is nil, every geo is used, in order.
+//
+// For DenseLevels the rows run from dtStart to dtEnd.  For DenseLogReturns the row for a quarter holds the
+// return into it, so the rows run from the quarter after dtStart to dtEnd.  Every geo must have every quarter
+// from dtStart to dtEnd.
+func (hd *HPIdata) Dense(dtStart, dtEnd int, geos []string, values DenseValues) (m *mat.Dense, dts []int, cols []string, e error) {
+	for _, dt := range []int{dtStart, dtEnd} {
+		if !YrQtr(dt).Valid() {
+			return nil, nil, nil, badDate(dt)
+		}
+	}
+
+	if values != DenseLevels && values != DenseLogReturns {
+		return nil, nil, nil, fmt.Errorf("unknown dense values: %d", values)
+	}
+
+	nQtrs := QtrDiffSigned(dtStart, dtEnd) + 1
+	if nQtrs < 1 || (values == DenseLogReturns && nQtrs < 2) {
+		return nil, nil, nil, fmt.Errorf("no quarters between %d and %d", dtStart, dtEnd)
+	}
+
+	if geos == nil {
+		geos = hd.Geos()
+		sort.Strings(geos)
+	}
+
+	if len(geos) == 0 {
+		return nil, nil, nil, fmt.Errorf("no geos")
+	}
+
+	levels := mat.NewDense(nQtrs, len(geos), nil)
+	for c, geo := range geos {
+		s, e := hd.Geo(geo)
+		if e != nil {
+			return nil, nil, nil, e
+		}
+
+		for r, dt := 0, dtStart; r < nQtrs; r, dt = r+1, NextQtr(dt) {
+			j := s.exact(dt)
+			if j < 0 {
+				return nil, nil, nil, fmt.Errorf("geo %s: %w: %d", geo, ErrDateMissing, dt)
+			}
+
+			levels.Set(r, c, s.indx[j])
+		}
+
+		cols = append(cols, s.geoCode)
+	}
+
+	for r, dt := 0, dtStart; r < nQtrs; r, dt = r+1, NextQtr(dt) {
+		dts = append(dts, dt)
+	}
+
+	if values == DenseLevels {
+		return levels, dts, cols, nil
+	}
+
+	m = mat.NewDense(nQtrs-1, len(geos), nil)
+	m.Apply(func(r, c int, _ float64) float64 {
+		return math.Log(levels.At(r+1, c) / levels.At(r, c))
+	}, m)
+
+	return m, dts[1:], cols, nil
+}
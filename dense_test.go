@@ -0,0 +1,42 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_Dense(t *testing.T) {
+	hd := testData()
+
+	m, dts, cols, e := hd.Dense(20001, 20014, nil, DenseLevels)
+	assert.Nil(t, e)
+	r, c := m.Dims()
+	assert.Equal(t, []int{8, 3}, []int{r, c})
+	assert.Equal(t, []string{"CA", "NY", "TX"}, cols)
+	assert.Equal(t, 20001, dts[0])
+	assert.Equal(t, 20014, dts[7])
+	assert.InDelta(t, 102, m.At(1, 0), 1e-9)
+
+	m, dts, cols, e = hd.Dense(20001, 20014, []string{"TX", "CA"}, DenseLogReturns)
+	assert.Nil(t, e)
+	r, c = m.Dims()
+	assert.Equal(t, []int{7, 2}, []int{r, c})
+	assert.Equal(t, []string{"TX", "CA"}, cols)
+	assert.Equal(t, 20002, dts[0])
+	assert.InDelta(t, math.Log(1.01), m.At(3, 0), 1e-12)
+	assert.InDelta(t, math.Log(1.02), m.At(6, 1), 1e-12)
+
+	_, _, _, e = hd.Dense(20001, 20101, nil, DenseLevels)
+	assert.ErrorIs(t, e, ErrDateMissing)
+
+	_, _, _, e = hd.Dense(20001, 20001, nil, DenseLogReturns)
+	assert.NotNil(t, e)
+
+	_, _, _, e = hd.Dense(20001, 20014, []string{"XX"}, DenseLevels)
+	assert.NotNil(t, e)
+
+	_, _, _, e = hd.Dense(20001, 20005, nil, DenseLevels)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,142 @@
+package fhfa
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DiscontinuedSeries describes a series FHFA has stopped publishing.
+type DiscontinuedSeries struct {
+	Geo     string // geo of the series
+	Name    string // name of the geo
+	LastDt  int    // last quarter (CCYYQ) of the series
+	Dropped bool   // true if the geo is missing from the current release, false if its series stops short
+}
+
+// DiscontinuedReport lists the discontinued series of a release.
+type DiscontinuedReport struct {
+	GeoLevel string               // geo level of the release
+	Latest   int                  // latest quarter (CCYYQ) of the release
+	Series   []DiscontinuedSeries // discontinued series, sorted by geo
+}
+
+// FindDiscontinued reports the series of cur that FHFA has stopped publishing: geos in prev, an earlier release,
+// that are missing from cur and series of cur that end before its latest quarter.  prev may be nil.
+func FindDiscontinued(prev, cur *HPIdata) (*DiscontinuedReport, error) {
+	if prev != nil && prev.geoLevel != cur.geoLevel {
+		return nil, fmt.Errorf("releases have different geo levels: %s, %s", prev.geoLevel, cur.geoLevel)
+	}
+
+	rpt := &DiscontinuedReport{GeoLevel: cur.geoLevel, Latest: cur.latest()}
+	for geo, s := range cur.series {
+		if s.lastDt < rpt.Latest {
+			rpt.Series = append(rpt.Series, DiscontinuedSeries{Geo: geo, Name: s.geoName, LastDt: s.lastDt})
+		}
+	}
+
+	if prev != nil {
+		for geo, s := range prev.series {
+			if _, ok := cur.series[geo]; !ok {
+				rpt.Series = append(rpt.Series, DiscontinuedSeries{Geo: geo, Name: s.geoName, LastDt: s.lastDt, Dropped: true})
+			}
+		}
+	}
+
+	sort.Slice(rpt.Series, func(i, j int) bool { return rpt.Series[i].Geo < rpt.Series[j].Geo })
+
+	return rpt, nil
+}
+
+// DiscontinuedPolicy determines how a FallbackChain treats dates after the end of a series that stops before
+// the latest quarter of its data.  Geos dropped from the data altogether always fall through to the next level.
+type DiscontinuedPolicy int
+
+const (
+	// DiscontinuedAsIs treats the series like any other: its extrapolation policy applies.
+	DiscontinuedAsIs DiscontinuedPolicy = iota
+
+	// DiscontinuedFreeze uses the last value of the series.
+	DiscontinuedFreeze
+
+	// DiscontinuedReroute uses the next level of the chain, e.g. the state of a zip3.
+	DiscontinuedReroute
+)
+
+// SetDiscontinuedPolicy sets how the chain treats discontinued series.  The default is DiscontinuedAsIs.
+func (fc *FallbackChain) SetDiscontinuedPolicy(p DiscontinuedPolicy) error {
+	if p < DiscontinuedAsIs || p > DiscontinuedReroute {
+		return fmt.Errorf("invalid discontinued policy: %d", p)
+	}
+
+	fc.discontinued = p
+
+	return nil
+}
+
+// best is Best, applying the chain's discontinued policy.
+func (fc *FallbackChain) best(dt int, keys []string, hpis []*HPIdata) (hpi float64, geoLevel string, e error) {
+	if fc.discontinued == DiscontinuedAsIs {
+		return Best(dt, keys, hpis)
+	}
+
+	errs := make([]error, len(hpis))
+	for j, hd := range hpis {
+		var d int
+		if d, errs[j] = fc.date(hd, keys[j], dt); errs[j] != nil {
+			continue
+		}
+
+		if hpi, errs[j] = hd.Index(keys[j], d); errs[j] == nil {
+			return hpi, hd.geoLevel, nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("geo/dt not found in Best: %w", errors.Join(errs...))
+}
+
+// bestChange is BestChange, applying the chain's discontinued policy.
+func (fc *FallbackChain) bestChange(dtStart, dtEnd int, keys []string, hpis []*HPIdata) (ratio float64, geoLevel string, e error) {
+	if fc.discontinued == DiscontinuedAsIs {
+		return BestChange(dtStart, dtEnd, keys, hpis)
+	}
+
+	errs := make([]error, len(hpis))
+	for j, hd := range hpis {
+		var d0, d1 int
+		if d0, errs[j] = fc.date(hd, keys[j], dtStart); errs[j] != nil {
+			continue
+		}
+
+		if d1, errs[j] = fc.date(hd, keys[j], dtEnd); errs[j] != nil {
+			continue
+		}
+
+		if ratio, errs[j] = hd.Change(keys[j], d0, d1); errs[j] == nil {
+			return ratio, hd.geoLevel, nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("geo/dt not found in BestChange: %w", errors.Join(errs...))
+}
+
+// date returns the date to look up in hd for geo at dt under the discontinued policy.
+func (fc *FallbackChain) date(hd *HPIdata, geo string, dt int) (int, error) {
+	s, e := hd.Geo(geo)
+	if e != nil {
+		return 0, e
+	}
+
+	end, _ := s.End()
+	if dt <= end || end >= hd.latest() {
+		return dt, nil
+	}
+
+	if fc.discontinued == DiscontinuedFreeze {
+		return end, nil
+	}
+
+	first, _ := s.First()
+
+	return 0, fmt.Errorf("geo %s discontinued: %w", geo, &DateRangeError{Dt: dt, First: first, Last: end})
+}
@@ -0,0 +1,74 @@
+package fhfa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testStale returns state data in which TX stops at 20084 and NY is dropped.
+func testStale() (prev, cur *HPIdata) {
+	prev = testData()
+	cur = testData()
+	delete(cur.series, "NY")
+
+	tx := cur.series["TX"]
+	tx.dates, tx.indx = tx.dates[:36], tx.indx[:36]
+	tx.lastDt, tx.lastIndx = tx.dates[35], tx.indx[35]
+
+	return prev, cur
+}
+
+func TestFindDiscontinued(t *testing.T) {
+	prev, cur := testStale()
+
+	rpt, e := FindDiscontinued(prev, cur)
+	assert.Nil(t, e)
+	assert.Equal(t, 20094, rpt.Latest)
+	assert.Equal(t, []DiscontinuedSeries{
+		{Geo: "NY", Name: "NY", LastDt: 20094, Dropped: true},
+		{Geo: "TX", Name: "TX", LastDt: 20084},
+	}, rpt.Series)
+
+	rpt, e = FindDiscontinued(nil, cur)
+	assert.Nil(t, e)
+	assert.Len(t, rpt.Series, 1)
+
+	_, e = FindDiscontinued(testUS(), cur)
+	assert.NotNil(t, e)
+}
+
+func TestFallbackChain_SetDiscontinuedPolicy(t *testing.T) {
+	_, cur := testStale()
+	fc, e := NewFallbackChain(cur, testUS())
+	assert.Nil(t, e)
+
+	// the default leaves it to the extrapolation policy, which is none
+	_, level, e := fc.Lookup("", "", "TX", 20091)
+	assert.Nil(t, e)
+	assert.Equal(t, "us", level)
+
+	assert.Nil(t, fc.SetDiscontinuedPolicy(DiscontinuedFreeze))
+	v, level, e := fc.Lookup("", "", "TX", 20091)
+	assert.Nil(t, e)
+	assert.Equal(t, "state", level)
+	last, _ := cur.Index("TX", 20084)
+	assert.Equal(t, last, v)
+
+	r, level, e := fc.Change("", "", "TX", 20083, 20094)
+	assert.Nil(t, e)
+	assert.Equal(t, "state", level)
+	assert.InDelta(t, 1.01, r, 1e-9)
+
+	assert.Nil(t, cur.SetExtrapolation(Extrapolation{Method: ExtrapGrowth, Growth: 0.01}))
+	assert.Nil(t, fc.SetDiscontinuedPolicy(DiscontinuedReroute))
+	_, level, e = fc.Lookup("", "", "TX", 20091)
+	assert.Nil(t, e)
+	assert.Equal(t, "us", level)
+
+	_, _, e = (&FallbackChain{hpis: []*HPIdata{cur}, discontinued: DiscontinuedReroute}).Lookup("", "", "TX", 20091)
+	assert.True(t, errors.Is(e, ErrDateOutOfRange))
+
+	assert.NotNil(t, fc.SetDiscontinuedPolicy(DiscontinuedPolicy(7)))
+}
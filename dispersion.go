@@ -0,0 +1,89 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Dispersion holds cross-sectional statistics of trailing 4-quarter growth across geos at a date.  Growth
+// is the ratio minus 1, so 0.05 is 5% appreciation over the year.
+type Dispersion struct {
+	Dt     int     // date (CCYYQ)
+	N      int     // number of geos with data
+	Mean   float64 // mean growth
+	SD     float64 // standard deviation of growth
+	IQR    float64 // interquartile range of growth
+	Min    float64 // minimum growth
+	MinGeo string  // geo with the minimum growth
+	Max    float64 // maximum growth
+	MaxGeo string  // geo with the maximum growth
+}
+
+// Dispersion returns the cross-sectional dispersion of trailing 4-quarter growth across the geos in hd for
+// each quarter from dtStart to dtEnd (CCYYQ).  Quarters with fewer than 2 geos with data are omitted.
+func (hd *HPIdata) Dispersion(dtStart, dtEnd int) ([]Dispersion, error) {
+	if dtEnd < dtStart {
+		return nil, fmt.Errorf("dtEnd %d precedes dtStart %d", dtEnd, dtStart)
+	}
+
+	if qtr := dtStart % 10; qtr < 1 || qtr > 4 {
+		return nil, badDate(dtStart)
+	}
+
+	geos := hd.Geos()
+	sort.Strings(geos)
+
+	var out []Dispersion
+	for dt := dtStart; dt <= dtEnd; dt = NextQtr(dt) {
+		d := Dispersion{Dt: dt, Min: math.Inf(1), Max: math.Inf(-1)}
+
+		var growth []float64
+		for _, geo := range geos {
+			s := hd.series[geo]
+			j, k := s.exact(AddQtrs(dt, -4)), s.exact(dt)
+			if j < 0 || k < 0 {
+				continue
+			}
+
+			g := s.indx[k]/s.indx[j] - 1
+			growth = append(growth, g)
+
+			if g < d.Min {
+				d.Min, d.MinGeo = g, geo
+			}
+
+			if g > d.Max {
+				d.Max, d.MaxGeo = g, geo
+			}
+		}
+
+		if len(growth) < 2 {
+			continue
+		}
+
+		d.N = len(growth)
+		d.Mean, d.SD = meanSD(growth)
+		sort.Float64s(growth)
+		d.IQR = quantile(growth, 0.75) - quantile(growth, 0.25)
+
+		out = append(out, d)
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no quarters between %d and %d with data", dtStart, dtEnd)
+	}
+
+	return out, nil
+}
+
+// quantile returns the q quantile of the sorted values x, interpolating linearly.
+func quantile(x []float64, q float64) float64 {
+	pos := q * float64(len(x)-1)
+	lo := int(pos)
+	if lo == len(x)-1 {
+		return x[lo]
+	}
+
+	return x[lo] + (pos-float64(lo))*(x[lo+1]-x[lo])
+}
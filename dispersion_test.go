@@ -0,0 +1,32 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_Dispersion(t *testing.T) {
+	hd := testData()
+	d, e := hd.Dispersion(20001, 20094)
+	assert.Nil(t, e)
+
+	// the first 4 quarters have no trailing year
+	assert.Len(t, d, 36)
+	assert.Equal(t, 20011, d[0].Dt)
+	assert.Equal(t, 3, d[0].N)
+
+	ca := math.Pow(1.02, 4) - 1
+	tx := math.Pow(1.01, 4) - 1
+	ny := math.Pow(0.995, 4) - 1
+	assert.InDelta(t, ca, d[0].Max, 1e-9)
+	assert.Equal(t, "CA", d[0].MaxGeo)
+	assert.InDelta(t, ny, d[0].Min, 1e-9)
+	assert.Equal(t, "NY", d[0].MinGeo)
+	assert.InDelta(t, (ca+tx+ny)/3, d[0].Mean, 1e-9)
+	assert.InDelta(t, (ca-tx)/2+(tx-ny)/2, d[0].IQR, 1e-9)
+
+	_, e = hd.Dispersion(20001, 20004)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,156 @@
+package fhfa
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DivisionPolicy determines whether metropolitan division or MSA series are preferred for metro lookups.
+// FHFA publishes the largest MSAs as their metropolitan divisions, so an MSA-level series may not exist.
+type DivisionPolicy int
+
+const (
+	// PreferDivision uses the division series, falling back to the MSA series.
+	PreferDivision DivisionPolicy = iota
+
+	// PreferMSA uses the MSA series, falling back to the division series.
+	PreferMSA
+)
+
+// Divisions returns the metropolitan division codes of the MSA cbsa, in order.  The result is empty if the MSA
+// is not divided.
+func (d *Delineation) Divisions(cbsa string) []string {
+	var divs []string
+	for _, c := range d.counties {
+		if c.CBSA == cbsa && c.Division != "" && !in(c.Division, divs) {
+			divs = append(divs, c.Division)
+		}
+	}
+	sort.Strings(divs)
+
+	return divs
+}
+
+// IsDivision returns true if code is a metropolitan division code.
+func (d *Delineation) IsDivision(code string) bool {
+	_, e := d.Parent(code)
+
+	return e == nil
+}
+
+// Parent returns the MSA code of the metropolitan division division.
+func (d *Delineation) Parent(division string) (string, error) {
+	for _, c := range d.counties {
+		if c.Division == division {
+			return c.CBSA, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s is not a metropolitan division in %d delineation", division, d.vintage)
+}
+
+// MetroKeys returns the keys of the metro series for a county (5-digit FIPS) in the order given by policy.
+// The result is empty for counties outside metropolitan areas.
+func (d *Delineation) MetroKeys(fips string, policy DivisionPolicy) ([]string, error) {
+	cbsa, e := d.Route(fips)
+	if e != nil {
+		return nil, e
+	}
+
+	if cbsa == "nonmetro" {
+		return nil, nil
+	}
+
+	c, _ := d.County(fips)
+	if c.Division == "" {
+		return []string{cbsa}, nil
+	}
+
+	if policy == PreferMSA {
+		return []string{cbsa, c.Division}, nil
+	}
+
+	return []string{c.Division, cbsa}, nil
+}
+
+// MetroSeries returns the metro series for a county (5-digit FIPS) from the metro data hd, trying the
+// division and MSA series in the order given by policy.  The key of the series found is also returned.
+func (d *Delineation) MetroSeries(hd *HPIdata, fips string, policy DivisionPolicy) (*HPIseries, string, error) {
+	if hd.geoLevel != "metro" {
+		return nil, "", fmt.Errorf("need metro data, got %s", hd.geoLevel)
+	}
+
+	keys, e := d.MetroKeys(fips, policy)
+	if e != nil {
+		return nil, "", e
+	}
+
+	for _, k := range keys {
+		if s, e := hd.Geo(k); e == nil {
+			return s, k, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("no metro series for county %s", fips)
+}
+
+// SetDelineation sets the delineation and division policy used by LookupCounty.
+func (fc *FallbackChain) SetDelineation(d *Delineation, policy DivisionPolicy) {
+	fc.delin, fc.policy = d, policy
+}
+
+// LookupCounty returns the house price index at dt (CCYYQ) for a property in the county with 5-digit FIPS
+// code fips, and the geo level of the series used.  The metro keys (division and MSA) and the state are
+// derived from the county using the chain's delineation (see SetDelineation).
+func (fc *FallbackChain) LookupCounty(fips, zip3 string, dt int) (hpi float64, geoLevel string, e error) {
+	if fc.delin == nil {
+		return 0, "", fmt.Errorf("no delineation set for fallback chain")
+	}
+
+	var (
+		metro []string
+		f     string
+		st    string
+	)
+
+	if metro, e = fc.delin.MetroKeys(fips, fc.policy); e != nil {
+		return 0, "", e
+	}
+
+	f, _ = padCode(fips, 5)
+	if st, e = NormalizeState(f[:2]); e != nil {
+		return 0, "", e
+	}
+
+	if e := fc.checkTerritory(st); e != nil {
+		return 0, "", e
+	}
+
+	// expand the metro level into one entry per metro key
+	var (
+		keys []string
+		hpis []*HPIdata
+	)
+	cbsa := ""
+	if len(metro) > 0 {
+		cbsa = metro[0]
+	}
+
+	for j, k := range fc.Keys(zip3, cbsa, st) {
+		hd := fc.hpis[j]
+		if hd.geoLevel != "metro" {
+			keys, hpis = append(keys, k), append(hpis, hd)
+			continue
+		}
+
+		for _, m := range metro {
+			keys, hpis = append(keys, m), append(hpis, hd)
+		}
+	}
+
+	if len(hpis) == 0 {
+		return 0, "", fmt.Errorf("geo/dt not found in LookupCounty")
+	}
+
+	return fc.best(dt, keys, hpis)
+}
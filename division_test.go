@@ -0,0 +1,57 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelineation_MetroKeys(t *testing.T) {
+	d, e := LoadDelineation(testDelineation(t), 2023)
+	assert.Nil(t, e)
+
+	assert.Equal(t, []string{"35154", "35614"}, d.Divisions("35620"))
+	assert.Empty(t, d.Divisions("10180"))
+
+	parent, e := d.Parent("35614")
+	assert.Nil(t, e)
+	assert.Equal(t, "35620", parent)
+	assert.False(t, d.IsDivision("35620"))
+
+	keys, e := d.MetroKeys("36047", PreferDivision)
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"35614", "35620"}, keys)
+
+	keys, e = d.MetroKeys("36047", PreferMSA)
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"35620", "35614"}, keys)
+
+	keys, e = d.MetroKeys("46013", PreferMSA)
+	assert.Nil(t, e)
+	assert.Empty(t, keys)
+
+	// FHFA publishes the division, not the MSA
+	metro := testLevel(testData(), "metro")
+	metro.series = map[string]*HPIseries{"35614": metro.series["NY"], "10180": metro.series["TX"]}
+
+	_, key, e := d.MetroSeries(metro, "36047", PreferMSA)
+	assert.Nil(t, e)
+	assert.Equal(t, "35614", key)
+
+	_, _, e = d.MetroSeries(metro, "34023", PreferDivision)
+	assert.NotNil(t, e)
+
+	fc, e := NewFallbackChain(metro, testData(), testUS())
+	assert.Nil(t, e)
+
+	_, _, e = fc.LookupCounty("36047", "112", 20051)
+	assert.NotNil(t, e)
+
+	fc.SetDelineation(d, PreferDivision)
+	levels := map[string]string{"36047": "metro", "48059": "metro", "46013": "us", "6001": "state"}
+	for fips, exp := range levels {
+		_, level, e := fc.LookupCounty(fips, "", 20051)
+		assert.Nil(t, e)
+		assert.Equal(t, exp, level)
+	}
+}
@@ -0,0 +1,58 @@
+package fhfa
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Download saves the contents of url (e.g. from URLs) to localFile.  The result is reported to the Metrics
+// set by SetMetrics.
+func Download(ctx context.Context, url, localFile string) error {
+	start := time.Now()
+	n, e := download(ctx, url, localFile)
+	currentMetrics().Downloaded(url, n, time.Since(start), e)
+
+	return e
+}
+
+// download does the work of Download, returning the number of bytes saved.
+func download(ctx context.Context, url, localFile string) (int64, error) {
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if e != nil {
+		return 0, e
+	}
+
+	resp, e := http.DefaultClient.Do(req)
+	if e != nil {
+		return 0, e
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	// download to a temporary file so a failure doesn't leave a partial localFile or replace a good one
+	file, e := os.CreateTemp(filepath.Dir(localFile), filepath.Base(localFile)+".*.tmp")
+	if e != nil {
+		return 0, e
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	n, e := io.Copy(file, resp.Body)
+	if e != nil {
+		return n, e
+	}
+
+	if e := file.Close(); e != nil {
+		return n, e
+	}
+
+	return n, os.Rename(file.Name(), localFile)
+}
@@ -0,0 +1,40 @@
+package fhfa
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/short" {
+			// promise more than is sent, so the body ends early
+			w.Header().Set("Content-Length", "100")
+		}
+
+		_, _ = w.Write([]byte("new data"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "hpi.xlsx")
+	assert.Nil(t, os.WriteFile(file, []byte("good"), 0o644))
+
+	assert.NotNil(t, Download(context.Background(), srv.URL+"/short", file))
+	b, _ := os.ReadFile(file)
+	assert.Equal(t, "good", string(b))
+
+	assert.Nil(t, Download(context.Background(), srv.URL+"/full", file))
+	b, _ = os.ReadFile(file)
+	assert.Equal(t, "new data", string(b))
+
+	// no temporary files are left behind
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+}
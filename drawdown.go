@@ -0,0 +1,122 @@
+package fhfa
+
+// Drawdown describes the largest peak-to-trough decline of a series over a range of dates.
+type Drawdown struct {
+	PeakDt     int     // date of the peak (CCYYQ)
+	Peak       float64 // index at the peak
+	TroughDt   int     // date of the trough (CCYYQ)
+	Trough     float64 // index at the trough
+	Depth      float64 // Trough/Peak - 1, so a 20% decline is -0.2
+	RecoveryDt int     // first date after the trough at which the index regains Peak, 0 if it hasn't
+}
+
+// Peak returns the date (CCYYQ) and value of the maximum of h between dtStart and dtEnd (CCYYQ).
+func (h *HPIseries) Peak(dtStart, dtEnd int) (dt int, indx float64, e error) {
+	var first, last int
+	if first, last, e = h.span(dtStart, dtEnd); e != nil {
+		return 0, 0, e
+	}
+
+	mx := first
+	for j := first + 1; j <= last; j++ {
+		if h.indx[j] > h.indx[mx] {
+			mx = j
+		}
+	}
+
+	return h.dates[mx], h.indx[mx], nil
+}
+
+// Trough returns the date (CCYYQ) and value of the minimum of h between dtStart and dtEnd (CCYYQ).
+func (h *HPIseries) Trough(dtStart, dtEnd int) (dt int, indx float64, e error) {
+	var first, last int
+	if first, last, e = h.span(dtStart, dtEnd); e != nil {
+		return 0, 0, e
+	}
+
+	mn := first
+	for j := first + 1; j <= last; j++ {
+		if h.indx[j] < h.indx[mn] {
+			mn = j
+		}
+	}
+
+	return h.dates[mn], h.indx[mn], nil
+}
+
+// MaxDrawdown returns the largest peak-to-trough decline of h between dtStart and dtEnd (CCYYQ).
+// The recovery date is searched for through the end of the series.  If the series never declines, the
+// Depth is 0 and the peak and trough are the first date.
+func (h *HPIseries) MaxDrawdown(dtStart, dtEnd int) (*Drawdown, error) {
+	first, last, e := h.span(dtStart, dtEnd)
+	if e != nil {
+		return nil, e
+	}
+
+	pk, bestPk, bestTr := first, first, first
+	for j := first + 1; j <= last; j++ {
+		if h.indx[j] > h.indx[pk] {
+			pk = j
+			continue
+		}
+
+		if h.indx[j]/h.indx[pk] < h.indx[bestTr]/h.indx[bestPk] {
+			bestPk, bestTr = pk, j
+		}
+	}
+
+	dd := &Drawdown{
+		PeakDt:   h.dates[bestPk],
+		Peak:     h.indx[bestPk],
+		TroughDt: h.dates[bestTr],
+		Trough:   h.indx[bestTr],
+		Depth:    h.indx[bestTr]/h.indx[bestPk] - 1,
+	}
+
+	if bestTr == bestPk {
+		return dd, nil
+	}
+
+	for j := bestTr + 1; j < len(h.indx); j++ {
+		if h.indx[j] >= dd.Peak {
+			dd.RecoveryDt = h.dates[j]
+			break
+		}
+	}
+
+	return dd, nil
+}
+
+// FromPeak returns the ratio of the index at dt (CCYYQ) to its peak at or before dt, so 0.9 is 10% below the
+// prior high and 1 is at a new high.  The index at dt is found as Index does.
+func (h *HPIseries) FromPeak(dt int) (float64, error) {
+	v, e := h.Index(dt)
+	if e != nil {
+		return 0, e
+	}
+
+	_, pk, e := h.Peak(h.dates[0], dt)
+	if e != nil {
+		return 0, e
+	}
+
+	return v / max(pk, v), nil
+}
+
+// FromPeakAll returns FromPeak at dt (CCYYQ) for every geo in hd.  Geos below 1 are underwater relative to
+// their prior high.  Geos without data at dt are reported in errs.
+func (hd *HPIdata) FromPeakAll(dt int) (ratios map[string]float64, errs map[string]error) {
+	ratios = make(map[string]float64)
+	errs = make(map[string]error)
+	for geo, s := range hd.series {
+		r, e := s.FromPeak(dt)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		ratios[geo] = r
+	}
+
+	return ratios, errs
+}
@@ -0,0 +1,67 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_MaxDrawdown(t *testing.T) {
+	dts := []int{20061, 20062, 20063, 20064, 20071, 20072, 20073, 20074}
+	indx := []float64{100, 120, 110, 90, 95, 115, 125, 130}
+	s, e := NewHPIseries("XX", "", dts, indx)
+	assert.Nil(t, e)
+
+	dd, e := s.MaxDrawdown(20061, 20071)
+	assert.Nil(t, e)
+	assert.Equal(t, 20062, dd.PeakDt)
+	assert.Equal(t, 20064, dd.TroughDt)
+	assert.InEpsilon(t, 90.0/120.0-1, dd.Depth, 1e-9)
+	assert.Equal(t, 20073, dd.RecoveryDt)
+
+	dd, e = s.MaxDrawdown(20072, 20074)
+	assert.Nil(t, e)
+	assert.Equal(t, 0.0, dd.Depth)
+	assert.Equal(t, 0, dd.RecoveryDt)
+
+	dt, v, e := s.Peak(20061, 20064)
+	assert.Nil(t, e)
+	assert.Equal(t, 20062, dt)
+	assert.Equal(t, 120.0, v)
+
+	dt, v, e = s.Trough(20063, 20074)
+	assert.Nil(t, e)
+	assert.Equal(t, 20064, dt)
+	assert.Equal(t, 90.0, v)
+
+	_, e = s.MaxDrawdown(20081, 20094)
+	assert.NotNil(t, e)
+}
+
+func TestHPIseries_FromPeak(t *testing.T) {
+	dts := []int{20061, 20062, 20063, 20064, 20071}
+	indx := []float64{100, 120, 110, 90, 130}
+	s, e := NewHPIseries("XX", "XX", dts, indx)
+	assert.Nil(t, e)
+
+	r, e := s.FromPeak(20064)
+	assert.Nil(t, e)
+	assert.InDelta(t, 0.75, r, 1e-12)
+
+	r, e = s.FromPeak(20071)
+	assert.Nil(t, e)
+	assert.Equal(t, 1.0, r)
+
+	_, e = s.FromPeak(20053)
+	assert.NotNil(t, e)
+
+	hd := testData()
+	ratios, errs := hd.FromPeakAll(20094)
+	assert.Empty(t, errs)
+	assert.Equal(t, 1.0, ratios["CA"])
+	assert.InDelta(t, math.Pow(0.995, 39), ratios["NY"], 1e-12)
+
+	_, errs = hd.FromPeakAll(19994)
+	assert.Equal(t, 3, len(errs))
+}
@@ -0,0 +1,127 @@
+package fhfa
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetRow is a row of the long-format table written by SaveParquet.
+type parquetRow struct {
+	Geo   string  `parquet:"geo"`
+	Name  string  `parquet:"name"`
+	YrQtr int32   `parquet:"yrqtr"`
+	Year  int32   `parquet:"year"`
+	Qtr   int32   `parquet:"qtr"`
+	Index float64 `parquet:"index"`
+}
+
+// SaveParquet saves the data as a parquet file in long format, one row per geo and quarter in geo and date order,
+// with the columns geo, name, yrqtr (CCYYQ), year, qtr and index.  The columns are zstd-compressed.
+func (hd *HPIdata) SaveParquet(localFile string) error {
+	var rows []parquetRow
+	for _, r := range hd.Records() {
+		rows = append(rows, parquetRow{
+			Geo:   r.Code,
+			Name:  r.Geo,
+			YrQtr: int32(r.YrQtr),
+			Year:  int32(r.YrQtr / 10),
+			Qtr:   int32(r.YrQtr % 10),
+			Index: r.Index,
+		})
+	}
+
+	file, e := create(localFile)
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	if e := parquet.Write(file, rows, parquet.Compression(&parquet.Zstd)); e != nil {
+		return e
+	}
+
+	return file.Close()
+}
+
+// LoadParquet loads data at geoLevel from a parquet file written by SaveParquet or by DuckDB from a table with
+// the DDL of DuckDBDDL, e.g.
+//
+//	COPY hpi TO 'hpi.parquet' (FORMAT parquet)
+func LoadParquet(localFile, geoLevel string) (*HPIdata, error) {
+	if e := checkGeoLevel(geoLevel); e != nil {
+		return nil, e
+	}
+
+	local, cleanup, e := localCopy(localFile)
+	if e != nil {
+		return nil, e
+	}
+	defer cleanup()
+
+	rows, e := parquet.ReadFile[parquetRow](local)
+	if e != nil {
+		return nil, e
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no data in %s", localFile)
+	}
+
+	recs := make([]Record, len(rows))
+	for j, row := range rows {
+		recs[j] = Record{GeoLevel: geoLevel, Geo: row.Name, Code: row.Geo, YrQtr: int(row.YrQtr), Index: row.Index}
+	}
+
+	hd, e := FromRecords(recs)
+	if e != nil {
+		return nil, e
+	}
+
+	hd.source = localFile
+
+	return hd, nil
+}
+
+// DuckDBDDL returns DuckDB statements that create table from the parquet file written by SaveParquet.
+func DuckDBDDL(table, parquetFile string) string {
+	return fmt.Sprintf(`CREATE OR REPLACE TABLE %s (
+    geo   VARCHAR NOT NULL,
+    name  VARCHAR,
+    yrqtr INTEGER NOT NULL,
+    year  INTEGER NOT NULL,
+    qtr   INTEGER NOT NULL,
+    "index" DOUBLE NOT NULL,
+    PRIMARY KEY (geo, yrqtr)
+);
+INSERT INTO %s SELECT geo, name, yrqtr, year, qtr, "index" FROM read_parquet('%s');
+`, table, table, strings.ReplaceAll(parquetFile, "'", "''"))
+}
+
+// SaveDuckDB saves the data for DuckDB as table: a parquet file (see SaveParquet) and, alongside it, a .sql
+// file of the statements that load it (see DuckDBDDL).  The SQL file can be run in the DuckDB CLI with .read.
+func (hd *HPIdata) SaveDuckDB(parquetFile, table string) error {
+	if e := hd.SaveParquet(parquetFile); e != nil {
+		return e
+	}
+
+	file, e := create(strings.TrimSuffix(parquetFile, filepath.Ext(parquetFile)) + ".sql")
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	if _, e := io.WriteString(file, DuckDBDDL(table, parquetFile)); e != nil {
+		return e
+	}
+
+	return file.Close()
+}
+
+// LoadDuckDB loads data at geoLevel exported from DuckDB as parquet.  It is LoadParquet.
+func LoadDuckDB(parquetFile, geoLevel string) (*HPIdata, error) {
+	return LoadParquet(parquetFile, geoLevel)
+}
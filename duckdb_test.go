@@ -0,0 +1,42 @@
+package fhfa
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_SaveDuckDB(t *testing.T) {
+	hd := testData()
+	file := filepath.Join(t.TempDir(), "hpi.parquet")
+	assert.Nil(t, hd.SaveDuckDB(file, "hpi"))
+
+	ddl, e := os.ReadFile(strings.TrimSuffix(file, ".parquet") + ".sql")
+	assert.Nil(t, e)
+	assert.Contains(t, string(ddl), "CREATE OR REPLACE TABLE hpi")
+	assert.Contains(t, string(ddl), "read_parquet('"+file+"')")
+
+	hd1, e := LoadDuckDB(file, "state")
+	assert.Nil(t, e)
+	assert.ElementsMatch(t, hd.Geos(), hd1.Geos())
+
+	for geo, s := range hd.All() {
+		s1, e := hd1.Geo(geo)
+		assert.Nil(t, e)
+		assert.Equal(t, s.Dates(), s1.Dates())
+		assert.Equal(t, s.Values(), s1.Values())
+	}
+
+	_, e = LoadParquet(filepath.Join(t.TempDir(), "none.parquet"), "state")
+	assert.NotNil(t, e)
+
+	_, e = LoadParquet(file, "State")
+	assert.ErrorContains(t, e, "invalid geo level")
+}
+
+func TestDuckDBDDL(t *testing.T) {
+	assert.Contains(t, DuckDBDDL("hpi", "it's.parquet"), "read_parquet('it''s.parquet')")
+}
@@ -0,0 +1,60 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Equal reports whether h and other have the same dates and values within tol, an absolute tolerance.
+// If not, diff describes the first mismatch.
+func (h *HPIseries) Equal(other *HPIseries, tol float64) (equal bool, diff string) {
+	if len(h.dates) != len(other.dates) {
+		return false, fmt.Sprintf("%d observations vs %d", len(h.dates), len(other.dates))
+	}
+
+	for j, dt := range h.dates {
+		if dt != other.dates[j] {
+			return false, fmt.Sprintf("observation %d is at %d vs %d", j, dt, other.dates[j])
+		}
+
+		if math.Abs(h.indx[j]-other.indx[j]) > tol {
+			return false, fmt.Sprintf("value at %d is %v vs %v", dt, h.indx[j], other.indx[j])
+		}
+	}
+
+	return true, ""
+}
+
+// Equal reports whether hd and other have the same geo level, geos, dates and values within tol, an
+// absolute tolerance.  If not, diff describes the first mismatch, checking geos in order.
+func (hd *HPIdata) Equal(other *HPIdata, tol float64) (equal bool, diff string) {
+	if hd.geoLevel != other.geoLevel {
+		return false, fmt.Sprintf("geo level %s vs %s", hd.geoLevel, other.geoLevel)
+	}
+
+	geos := hd.Geos()
+	for geo := range other.series {
+		if !in(geo, geos) {
+			geos = append(geos, geo)
+		}
+	}
+	sort.Strings(geos)
+
+	for _, geo := range geos {
+		s, ok := hd.series[geo]
+		so, oko := other.series[geo]
+		switch {
+		case !ok:
+			return false, fmt.Sprintf("geo %s only in other", geo)
+		case !oko:
+			return false, fmt.Sprintf("geo %s missing from other", geo)
+		}
+
+		if eq, d := s.Equal(so, tol); !eq {
+			return false, fmt.Sprintf("geo %s: %s", geo, d)
+		}
+	}
+
+	return true, ""
+}
@@ -0,0 +1,40 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_Equal(t *testing.T) {
+	hd := testData()
+	other := hd.Copy()
+
+	eq, diff := hd.Equal(other, 0)
+	assert.True(t, eq)
+	assert.Equal(t, "", diff)
+
+	s, _ := other.Geo("NY")
+	s.indx[3] += 0.001
+	eq, _ = hd.Equal(other, 0.01)
+	assert.True(t, eq)
+
+	eq, diff = hd.Equal(other, 0)
+	assert.False(t, eq)
+	assert.Contains(t, diff, "geo NY: value at 20004")
+
+	delete(other.series, "CA")
+	eq, diff = hd.Equal(other, 1)
+	assert.False(t, eq)
+	assert.Equal(t, "geo CA missing from other", diff)
+
+	sc, _ := hd.Geo("CA")
+	sn, _ := hd.Geo("NY")
+	eq, diff = sc.Equal(sn, 1000)
+	assert.True(t, eq, diff)
+
+	assert.Nil(t, sc.Append([]int{20101}, []float64{1}))
+	eq, diff = sc.Equal(sn, 1000)
+	assert.False(t, eq)
+	assert.Equal(t, "41 observations vs 40", diff)
+}
@@ -0,0 +1,165 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Loan is a mortgage secured by a Property.  The current balance is Balance if it is positive, otherwise it
+// is the scheduled balance from the amortization terms OrigBalance, Rate and TermMonths.
+type Loan struct {
+	Property
+
+	Balance       float64 // current balance; 0 to amortize from the terms below
+	OrigBalance   float64 // original balance
+	Rate          float64 // annual note rate, e.g. 0.065 for 6.5%
+	TermMonths    int     // original term in months, e.g. 360
+	SeniorBalance float64 // balance of other liens on the property, included in CLTV
+}
+
+// EquityMark is the HPI-updated value of a loan's property and the borrower's equity in it.
+type EquityMark struct {
+	Mark
+
+	Balance float64 // current balance of the loan
+	Equity  float64 // Value less the balances of the loan and other liens
+	CLTV    float64 // combined loan-to-value: balances of the loan and other liens over Value
+}
+
+// CLTVBucket summarizes the loans with CLTV in (Lo, Hi].
+type CLTVBucket struct {
+	Lo, Hi  float64 // bounds of the bucket
+	N       int     // number of loans
+	Balance float64 // total current balance
+	Value   float64 // total property value
+	Equity  float64 // total equity
+}
+
+// EquitySummary summarizes the equity of a set of loans.
+type EquitySummary struct {
+	N              int          // number of loans marked
+	Errors         int          // number of loans that couldn't be marked
+	Balance        float64      // total current balance
+	Value          float64      // total property value
+	Equity         float64      // total equity
+	CLTV           float64      // balance-weighted average CLTV
+	NegativeEquity int          // number of loans with CLTV above 1
+	Buckets        []CLTVBucket // loans by CLTV
+}
+
+// DefaultCLTVBounds are the upper bounds of the usual CLTV buckets.  A final bucket holds CLTVs above the last.
+var DefaultCLTVBounds = []float64{0.6, 0.8, 0.9, 1.0, 1.2}
+
+// MarkLoans estimates the current property value, balance and equity of each loan as of asOfDt (CCYYQ), in the
+// order of the loans.  Values are as in Portfolio.Value: each property uses the first HPIdata in hpis with data
+// for its key.  The work is split across workers goroutines; if workers is not positive, runtime.NumCPU() is used.
+func MarkLoans(loans []Loan, asOfDt int, hpis []*HPIdata, workers int) []EquityMark {
+	marks := make([]EquityMark, len(loans))
+
+	parallel(len(loans), workers, func(start, end int) {
+		for j := start; j < end; j++ {
+			marks[j] = markLoan(&loans[j], asOfDt, hpis)
+		}
+	})
+
+	return marks
+}
+
+// markLoan values a single loan.
+func markLoan(ln *Loan, asOfDt int, hpis []*HPIdata) EquityMark {
+	em := EquityMark{Mark: mark(&ln.Property, asOfDt, hpis)}
+	if em.Err != nil {
+		return em
+	}
+
+	if em.Balance, em.Err = ln.CurrentBalance(asOfDt); em.Err != nil {
+		return em
+	}
+
+	liens := em.Balance + ln.SeniorBalance
+	em.Equity = em.Value - liens
+	if em.Value > 0 {
+		em.CLTV = liens / em.Value
+	}
+
+	return em
+}
+
+// CurrentBalance returns the balance of ln at asOfDt (CCYYQ): Balance if it is positive, otherwise the scheduled
+// balance after the months from OrigDt to asOfDt.
+func (ln *Loan) CurrentBalance(asOfDt int) (float64, error) {
+	if ln.Balance > 0 {
+		return ln.Balance, nil
+	}
+
+	if ln.OrigBalance <= 0 || ln.TermMonths <= 0 {
+		return 0, fmt.Errorf("loan %s has neither a balance nor amortization terms", ln.ID)
+	}
+
+	n := 3 * QtrDiffSigned(ln.OrigDt, asOfDt)
+	if n <= 0 {
+		return ln.OrigBalance, nil
+	}
+
+	if n >= ln.TermMonths {
+		return 0, nil
+	}
+
+	if ln.Rate == 0 {
+		return ln.OrigBalance * (1 - float64(n)/float64(ln.TermMonths)), nil
+	}
+
+	r := ln.Rate / 12
+	bigN := math.Pow(1+r, float64(ln.TermMonths))
+
+	return ln.OrigBalance * (bigN - math.Pow(1+r, float64(n))) / (bigN - 1), nil
+}
+
+// SummarizeEquity totals marks and buckets them by CLTV, with bucket upper bounds bounds (DefaultCLTVBounds if
+// nil) and a final bucket for CLTVs above the last bound.  Marks with errors are only counted.
+func SummarizeEquity(marks []EquityMark, bounds []float64) *EquitySummary {
+	if bounds == nil {
+		bounds = DefaultCLTVBounds
+	}
+
+	bounds = append([]float64{}, bounds...)
+	sort.Float64s(bounds)
+
+	sum := &EquitySummary{}
+	lo := math.Inf(-1)
+	for _, hi := range append(bounds, math.Inf(1)) {
+		sum.Buckets = append(sum.Buckets, CLTVBucket{Lo: lo, Hi: hi})
+		lo = hi
+	}
+
+	weighted := 0.0
+	for _, m := range marks {
+		if m.Err != nil {
+			sum.Errors++
+			continue
+		}
+
+		sum.N++
+		sum.Balance += m.Balance
+		sum.Value += m.Value
+		sum.Equity += m.Equity
+		weighted += m.Balance * m.CLTV
+
+		if m.CLTV > 1 {
+			sum.NegativeEquity++
+		}
+
+		b := &sum.Buckets[sort.SearchFloat64s(bounds, m.CLTV)]
+		b.N++
+		b.Balance += m.Balance
+		b.Value += m.Value
+		b.Equity += m.Equity
+	}
+
+	if sum.Balance > 0 {
+		sum.CLTV = weighted / sum.Balance
+	}
+
+	return sum
+}
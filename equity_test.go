@@ -0,0 +1,56 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkLoans(t *testing.T) {
+	hpis := []*HPIdata{testData(), testUS()}
+	loans := []Loan{
+		{Property: Property{ID: "a", Keys: []string{"CA", "USA"}, OrigDt: 20001, OrigValue: 100}, Balance: 90},
+		{Property: Property{ID: "b", Keys: []string{"NY", "USA"}, OrigDt: 20001, OrigValue: 100}, Balance: 95, SeniorBalance: 10},
+		{Property: Property{ID: "c", Keys: []string{"TX", "USA"}, OrigDt: 20001, OrigValue: 100},
+			OrigBalance: 80, Rate: 0.06, TermMonths: 360},
+		{Property: Property{ID: "d", Keys: []string{"ZZ"}, OrigDt: 20001, OrigValue: 100}, Balance: 1},
+	}
+
+	marks := MarkLoans(loans, 20011, hpis, 2)
+	assert.Len(t, marks, 4)
+
+	ca := math.Pow(1.02, 4) * 100
+	assert.InDelta(t, ca, marks[0].Value, 1e-9)
+	assert.InDelta(t, ca-90, marks[0].Equity, 1e-9)
+	assert.InDelta(t, 90/ca, marks[0].CLTV, 1e-9)
+
+	ny := math.Pow(0.995, 4) * 100
+	assert.InDelta(t, 105/ny, marks[1].CLTV, 1e-9)
+	assert.True(t, marks[1].Equity < 0)
+
+	// 12 payments on a 30-year loan at 6% pay down about 1.2% of the balance
+	assert.InDelta(t, 79.02, marks[2].Balance, 0.01)
+	assert.NotNil(t, marks[3].Err)
+
+	sum := SummarizeEquity(marks, nil)
+	assert.Equal(t, 3, sum.N)
+	assert.Equal(t, 1, sum.Errors)
+	assert.Equal(t, 1, sum.NegativeEquity)
+	assert.Len(t, sum.Buckets, 6)
+	assert.Equal(t, 1, sum.Buckets[2].N) // CA at 0.83
+	assert.Equal(t, 1, sum.Buckets[1].N) // TX at 0.76
+	assert.Equal(t, 1, sum.Buckets[4].N) // NY at 1.07
+	assert.InDelta(t, marks[0].Balance+marks[1].Balance+marks[2].Balance, sum.Balance, 1e-9)
+
+	bal, e := (&Loan{Property: Property{OrigDt: 20001}, OrigBalance: 120, TermMonths: 12}).CurrentBalance(20001)
+	assert.Nil(t, e)
+	assert.Equal(t, 120.0, bal)
+
+	bal, e = (&Loan{Property: Property{OrigDt: 20001}, OrigBalance: 120, TermMonths: 12}).CurrentBalance(20003)
+	assert.Nil(t, e)
+	assert.Equal(t, 60.0, bal)
+
+	_, e = (&Loan{}).CurrentBalance(20003)
+	assert.NotNil(t, e)
+}
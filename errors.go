@@ -0,0 +1,72 @@
+package fhfa
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrGeoNotFound is returned (wrapped in a *GeoError) when a geo is not in the data.
+	ErrGeoNotFound = errors.New("geo not found")
+
+	// ErrDateOutOfRange is returned (wrapped in a *DateRangeError) when a date is outside a series.
+	ErrDateOutOfRange = errors.New("date out of range")
+
+	// ErrDateMissing is returned when a date within the range of a series is not in it.
+	ErrDateMissing = errors.New("date not in series")
+
+	// ErrFrequencyMismatch is returned when dates don't follow the quarterly frequency of the data.
+	ErrFrequencyMismatch = errors.New("dates don't increment by quarter")
+
+	// ErrBadDate is returned when a date is not a legal CCYYQ date.
+	ErrBadDate = errors.New("illegal date")
+
+	// ErrUnsupportedTerritory is returned (wrapped in a *TerritoryError) for territories without FHFA indices.
+	ErrUnsupportedTerritory = errors.New("unsupported territory")
+)
+
+// GeoError reports a geo missing from the data.  It matches ErrGeoNotFound with errors.Is.
+type GeoError struct {
+	Geo   string // geo requested
+	Level string // geo level of the data searched
+}
+
+// Error returns the error message.
+func (ge *GeoError) Error() string {
+	if ge.Level == "" {
+		return fmt.Sprintf("geo %s not found", ge.Geo)
+	}
+
+	return fmt.Sprintf("geo %s not found in %s data", ge.Geo, ge.Level)
+}
+
+// Unwrap returns ErrGeoNotFound.
+func (ge *GeoError) Unwrap() error {
+	return ErrGeoNotFound
+}
+
+// DateRangeError reports a date outside the range of a series.  It matches ErrDateOutOfRange with errors.Is.
+type DateRangeError struct {
+	Dt    int // date requested (CCYYQ)
+	First int // first date of the series (CCYYQ)
+	Last  int // last date of the series (CCYYQ)
+}
+
+// Error returns the error message.
+func (de *DateRangeError) Error() string {
+	if de.Dt > de.Last {
+		return fmt.Sprintf("date too large: %d is after the end of the series, %d", de.Dt, de.Last)
+	}
+
+	return fmt.Sprintf("date too small: %d is before the start of the series, %d", de.Dt, de.First)
+}
+
+// Unwrap returns ErrDateOutOfRange.
+func (de *DateRangeError) Unwrap() error {
+	return ErrDateOutOfRange
+}
+
+// badDate returns an ErrBadDate error for dt.
+func badDate(dt int) error {
+	return fmt.Errorf("%w: %d", ErrBadDate, dt)
+}
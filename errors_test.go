@@ -0,0 +1,39 @@
+package fhfa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	hd := testData()
+
+	_, e := hd.Index("ZZ", 20051)
+	assert.ErrorIs(t, e, ErrGeoNotFound)
+
+	var ge *GeoError
+	assert.True(t, errors.As(e, &ge))
+	assert.Equal(t, "ZZ", ge.Geo)
+	assert.Equal(t, "state", ge.Level)
+
+	_, e = hd.Index("CA", 19994)
+	assert.ErrorIs(t, e, ErrDateOutOfRange)
+
+	var de *DateRangeError
+	assert.True(t, errors.As(e, &de))
+	assert.Equal(t, 20001, de.First)
+	assert.Equal(t, 20094, de.Last)
+
+	_, e = ToTime(20235)
+	assert.ErrorIs(t, e, ErrBadDate)
+
+	ca, _ := hd.Geo("CA")
+	assert.ErrorIs(t, ca.Append([]int{20102, 20101}, []float64{1, 1}), ErrFrequencyMismatch)
+
+	// Best reports why each level failed
+	_, _, e = Best(19991, []string{"ZZ", "CA"}, []*HPIdata{hd, hd})
+	assert.ErrorIs(t, e, ErrGeoNotFound)
+	assert.ErrorIs(t, e, ErrDateOutOfRange)
+}
@@ -0,0 +1,38 @@
+package fhfa
+
+import (
+	"bufio"
+	"encoding/json"
+)
+
+// Observation is a single quarter of a series in long format, as written by SaveNDJSON.
+type Observation struct {
+	Geo   string  `json:"geo"`   // geo key (e.g. TX, 10180)
+	Name  string  `json:"name"`  // geo name, the metro name for metro data
+	Dt    int     `json:"dt"`    // date (CCYYQ)
+	Index float64 `json:"index"` // index value
+}
+
+// SaveNDJSON saves the data as newline-delimited JSON, one Observation per line, in geo and date order.  The
+// file is compressed if localFile ends in .gz or .zst.
+func (hd *HPIdata) SaveNDJSON(localFile string) error {
+	file, e := create(localFile)
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	for _, r := range hd.Records() {
+		if e := enc.Encode(Observation{Geo: r.Code, Name: r.Geo, Dt: r.YrQtr, Index: r.Index}); e != nil {
+			return e
+		}
+	}
+
+	if e := w.Flush(); e != nil {
+		return e
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,34 @@
+package fhfa
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_SaveNDJSON(t *testing.T) {
+	hd := testData()
+	file := filepath.Join(t.TempDir(), "hpi.ndjson")
+	assert.Nil(t, hd.SaveNDJSON(file))
+
+	f, e := os.Open(file)
+	assert.Nil(t, e)
+	defer f.Close()
+
+	var obs []Observation
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var o Observation
+		assert.Nil(t, json.Unmarshal(sc.Bytes(), &o))
+		obs = append(obs, o)
+	}
+
+	assert.Len(t, obs, 120)
+	assert.Equal(t, Observation{Geo: "CA", Name: "CA", Dt: 20001, Index: 100}, obs[0])
+	assert.Equal(t, "TX", obs[119].Geo)
+	assert.Equal(t, 20094, obs[119].Dt)
+}
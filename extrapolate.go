@@ -0,0 +1,105 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+)
+
+// ExtrapolationMethod determines how a series is extended past its last quarter.
+type ExtrapolationMethod int
+
+const (
+	// ExtrapNone returns an error for dates after the last quarter (the default).
+	ExtrapNone ExtrapolationMethod = iota
+
+	// ExtrapFlat carries the last value forward.
+	ExtrapFlat
+
+	// ExtrapTrend compounds the trailing 4-quarter growth rate of the series.
+	ExtrapTrend
+
+	// ExtrapGrowth compounds a user-supplied growth rate.
+	ExtrapGrowth
+)
+
+// Extrapolation specifies the policy used by Index for dates after the end of a series.
+type Extrapolation struct {
+	Method        ExtrapolationMethod // extrapolation method
+	Growth        float64             // growth rate used by ExtrapGrowth (e.g. 0.01 is 1% per quarter)
+	Annualization Annualization       // convention Growth is quoted in, quarterly by default
+}
+
+// SetExtrapolation sets the extrapolation policy for every series in hd.
+func (hd *HPIdata) SetExtrapolation(x Extrapolation) error {
+	if e := x.check(); e != nil {
+		return e
+	}
+
+	for _, s := range hd.series {
+		s.extrap = x
+	}
+
+	return nil
+}
+
+// SetExtrapolation sets the extrapolation policy for h.  Extrapolation is off by default.
+func (h *HPIseries) SetExtrapolation(x Extrapolation) error {
+	if e := x.check(); e != nil {
+		return e
+	}
+
+	h.extrap = x
+
+	return nil
+}
+
+// Extrapolation returns the extrapolation policy of h.
+func (h *HPIseries) Extrapolation() Extrapolation {
+	return h.extrap
+}
+
+// check validates the policy
+func (x Extrapolation) check() error {
+	if x.Method < ExtrapNone || x.Method > ExtrapGrowth {
+		return fmt.Errorf("unknown extrapolation method: %d", x.Method)
+	}
+
+	if e := x.Annualization.check(); e != nil {
+		return e
+	}
+
+	if x.Method == ExtrapGrowth && x.Annualization.Quarterly(x.Growth) <= -1 {
+		return fmt.Errorf("extrapolation growth must exceed -1, got %v", x.Growth)
+	}
+
+	return nil
+}
+
+// extrapolate returns the index at dt (CCYYQ), which must be after the last date in h.
+func (h *HPIseries) extrapolate(dt int) (float64, error) {
+	if h.extrap.Method == ExtrapNone {
+		return 0, &DateRangeError{Dt: dt, First: h.dates[0], Last: h.dates[len(h.dates)-1]}
+	}
+
+	if qtr := dt % 10; qtr < 1 || qtr > 4 {
+		return 0, badDate(dt)
+	}
+
+	n := len(h.indx)
+	nQtrs := QtrDiff(h.dates[n-1], dt)
+	last := h.indx[n-1]
+
+	switch h.extrap.Method {
+	case ExtrapFlat:
+		return last, nil
+	case ExtrapTrend:
+		growth, e := h.trendGrowth(4)
+		if e != nil {
+			return 0, e
+		}
+
+		return last * math.Pow(1+growth, float64(nQtrs)), nil
+	default:
+		return last * math.Pow(1+h.extrap.Annualization.Quarterly(h.extrap.Growth), float64(nQtrs)), nil
+	}
+}
@@ -0,0 +1,52 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_SetExtrapolation(t *testing.T) {
+	hd := testData()
+	s, e := hd.Geo("CA")
+	assert.Nil(t, e)
+
+	ld, li := s.Last()
+	target := NextQtr(NextQtr(ld))
+
+	_, e = s.Index(target)
+	assert.NotNil(t, e)
+
+	assert.Nil(t, s.SetExtrapolation(Extrapolation{Method: ExtrapFlat}))
+	v, e := s.Index(target)
+	assert.Nil(t, e)
+	assert.Equal(t, li, v)
+
+	assert.Nil(t, s.SetExtrapolation(Extrapolation{Method: ExtrapTrend}))
+	v, e = s.Index(target)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, li*math.Pow(1+testGrowth["CA"], 2), v, 1e-9)
+
+	assert.Nil(t, s.SetExtrapolation(Extrapolation{Method: ExtrapGrowth, Growth: 0.05}))
+	v, e = s.Index(target)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, li*1.05*1.05, v, 1e-9)
+
+	_, e = s.Index(20235)
+	assert.NotNil(t, e)
+
+	assert.NotNil(t, s.SetExtrapolation(Extrapolation{Method: ExtrapGrowth, Growth: -2}))
+}
+
+func TestHPIdata_SetExtrapolation(t *testing.T) {
+	hd := testData()
+	assert.Nil(t, hd.SetExtrapolation(Extrapolation{Method: ExtrapFlat}))
+
+	for _, geo := range hd.Geos() {
+		ld, li := hd.series[geo].Last()
+		v, e := hd.Index(geo, NextQtr(ld))
+		assert.Nil(t, e)
+		assert.Equal(t, li, v)
+	}
+}
@@ -0,0 +1,64 @@
+package fhfa
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FactorFit is the regression of the quarterly log returns of a series on those of a benchmark, such as the
+// national or state index: r = Alpha + Beta*rb + error.
+type FactorFit struct {
+	Geo   string  // geo of the series
+	Alpha float64 // intercept, the quarterly log return not explained by the benchmark
+	Beta  float64 // sensitivity to the benchmark
+	R2    float64 // share of the variance of the returns explained by the benchmark
+	N     int     // number of quarterly returns in the regression
+}
+
+// Factor regresses the quarterly log returns of series on those of benchmark over the dates they have in
+// common between dtStart and dtEnd (CCYYQ).
+func Factor(series, benchmark *HPIseries, dtStart, dtEnd int) (*FactorFit, error) {
+	rs, rb, e := alignedReturns(series, benchmark, dtStart, dtEnd)
+	if e != nil {
+		return nil, e
+	}
+
+	ms, ss := meanSD(rs)
+	mb, sb := meanSD(rb)
+	if sb == 0 {
+		return nil, fmt.Errorf("benchmark returns have no variation")
+	}
+
+	fit := &FactorFit{Geo: series.geoCode, N: len(rs)}
+	cov := covariance(rs, rb)
+	fit.Beta = cov / (sb * sb)
+	fit.Alpha = ms - fit.Beta*mb
+
+	if ss > 0 {
+		corr := cov / (ss * sb)
+		fit.R2 = corr * corr
+	}
+
+	return fit, nil
+}
+
+// FactorAll regresses the returns of every geo in hd on those of benchmark between dtStart and dtEnd (CCYYQ),
+// sorted by geo.  Sort the result by Beta to rank markets by their sensitivity to the benchmark.  Geos without
+// enough data in common with benchmark are reported in errs.
+func (hd *HPIdata) FactorAll(benchmark *HPIseries, dtStart, dtEnd int) (fits []*FactorFit, errs map[string]error) {
+	errs = make(map[string]error)
+	for geo, s := range hd.series {
+		fit, e := Factor(s, benchmark, dtStart, dtEnd)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		fit.Geo = geo
+		fits = append(fits, fit)
+	}
+
+	sort.Slice(fits, func(i, j int) bool { return fits[i].Geo < fits[j].Geo })
+
+	return fits, errs
+}
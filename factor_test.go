@@ -0,0 +1,47 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFactor(t *testing.T) {
+	var (
+		dts        []int
+		bench, ser []float64
+	)
+
+	dt, b, s := 20001, 100.0, 100.0
+	for j := range 20 {
+		dts = append(dts, dt)
+		bench, ser = append(bench, b), append(ser, s)
+
+		rb := 0.01 * math.Sin(float64(j))
+		dt, b, s = NextQtr(dt), b*math.Exp(rb), s*math.Exp(0.005+1.5*rb)
+	}
+
+	benchmark, _ := NewHPIseries("US", "US", dts, bench)
+	series, _ := NewHPIseries("X", "X", dts, ser)
+
+	fit, e := Factor(series, benchmark, 20001, 20044)
+	assert.Nil(t, e)
+	assert.Equal(t, "X", fit.Geo)
+	assert.Equal(t, 19, fit.N)
+	assert.InDelta(t, 0.005, fit.Alpha, 1e-9)
+	assert.InDelta(t, 1.5, fit.Beta, 1e-9)
+	assert.InDelta(t, 1, fit.R2, 1e-9)
+
+	_, e = Factor(series, benchmark, 20001, 20003)
+	assert.NotNil(t, e)
+
+	hd, _ := NewHPIdata("metro", map[string]*HPIseries{"X": series, "Y": benchmark})
+	fits, errs := hd.FactorAll(benchmark, 20001, 20044)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"X", "Y"}, []string{fits[0].Geo, fits[1].Geo})
+	assert.InDelta(t, 1, fits[1].Beta, 1e-9)
+
+	_, errs = hd.FactorAll(benchmark, 20001, 20002)
+	assert.Equal(t, 2, len(errs))
+}
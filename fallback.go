@@ -0,0 +1,105 @@
+package fhfa
+
+import "fmt"
+
+// FallbackChain finds the HPI for a property from the first of a list of HPIdata, ordered by preference,
+// with data for it.  The key for each level is derived from the property's zip3, CBSA and state:
+//
+//   - zip3     - the zip3
+//   - metro    - the CBSA
+//   - nonmetro - the state, for properties not in a CBSA
+//   - state    - the state
+//   - pr       - "PR", for properties in Puerto Rico
+//   - us, mh   - "USA"
+//
+// Property in GU, VI, AS and MP is handled according to the chain's TerritoryPolicy and series that have been
+// discontinued according to its DiscontinuedPolicy.
+type FallbackChain struct {
+	hpis         []*HPIdata
+	delin        *Delineation
+	policy       DivisionPolicy
+	territory    TerritoryPolicy
+	discontinued DiscontinuedPolicy
+}
+
+// NewFallbackChain creates a FallbackChain from hpis, which are ordered by preference (e.g. zip3, metro,
+// nonmetro, state, us).
+func NewFallbackChain(hpis ...*HPIdata) (*FallbackChain, error) {
+	if len(hpis) == 0 {
+		return nil, fmt.Errorf("no HPI data for fallback chain")
+	}
+
+	for _, hd := range hpis {
+		if !in(hd.geoLevel, []string{"zip3", "metro", "nonmetro", "state", "us", "pr", "mh"}) {
+			return nil, fmt.Errorf("unsupported geo level in fallback chain: %s", hd.geoLevel)
+		}
+	}
+
+	return &FallbackChain{hpis: hpis}, nil
+}
+
+// Change returns the ratio of the index at dtEnd (CCYYQ) to dtStart (CCYYQ) and the geo level of the series used.
+func (fc *FallbackChain) Change(zip3, cbsa, state string, dtStart, dtEnd int) (ratio float64, geoLevel string, e error) {
+	if e := fc.checkTerritory(state); e != nil {
+		return 0, "", e
+	}
+
+	return fc.bestChange(dtStart, dtEnd, fc.Keys(zip3, cbsa, state), fc.hpis)
+}
+
+// HPIs returns the HPIdata of the chain in order of preference.
+func (fc *FallbackChain) HPIs() []*HPIdata {
+	return fc.hpis
+}
+
+// Keys returns the key for each level of the chain.  Levels that don't apply to the property have an empty key.
+// A property is outside a metro area if cbsa is empty or "nonmetro" (as returned by Delineation.Route), in which
+// case it routes to its state's nonmetro series.  Property in GU, VI, AS and MP has only the zip3 key and those
+// chosen by the territory policy.  state may be a postal abbreviation, FIPS code or name; the keys use the
+// postal abbreviation.
+func (fc *FallbackChain) Keys(zip3, cbsa, state string) []string {
+	if st, e := NormalizeState(state); e == nil {
+		state = st
+	}
+
+	if IsTerritory(state) {
+		return fc.territoryKeys(zip3)
+	}
+
+	rural := cbsa == "" || cbsa == "nonmetro"
+
+	keys := make([]string, len(fc.hpis))
+	for j, hd := range fc.hpis {
+		switch hd.geoLevel {
+		case "zip3":
+			keys[j] = zip3
+		case "metro":
+			if !rural {
+				keys[j] = cbsa
+			}
+		case "nonmetro":
+			if rural {
+				keys[j] = state
+			}
+		case "state":
+			keys[j] = state
+		case "pr":
+			if state == "PR" {
+				keys[j] = state
+			}
+		case "us", "mh":
+			keys[j] = "USA"
+		}
+	}
+
+	return keys
+}
+
+// Lookup returns the house price index at dt (CCYYQ) and the geo level of the series used.
+func (fc *FallbackChain) Lookup(zip3, cbsa, state string, dt int) (hpi float64, geoLevel string, e error) {
+	if e := fc.checkTerritory(state); e != nil {
+		return 0, "", e
+	}
+
+	return fc.best(dt, fc.Keys(zip3, cbsa, state), fc.hpis)
+}
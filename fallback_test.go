@@ -0,0 +1,84 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testLevel returns a copy of hd relabeled as geoLevel.
+func testLevel(hd *HPIdata, geoLevel string) *HPIdata {
+	cp := hd.Copy()
+	cp.geoLevel = geoLevel
+
+	return cp
+}
+
+func TestFallbackChain(t *testing.T) {
+	metro := testLevel(testData(), "metro")
+	metro.series = map[string]*HPIseries{"31080": metro.series["CA"]}
+
+	fc, e := NewFallbackChain(metro, testLevel(testData(), "nonmetro"), testData(), testUS())
+	assert.Nil(t, e)
+
+	assert.Equal(t, []string{"31080", "", "CA", "USA"}, fc.Keys("900", "31080", "CA"))
+	assert.Equal(t, []string{"", "CA", "CA", "USA"}, fc.Keys("936", "nonmetro", "CA"))
+
+	_, level, e := fc.Lookup("900", "31080", "CA", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, "metro", level)
+
+	_, level, e = fc.Lookup("936", "", "CA", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, "nonmetro", level)
+
+	r, level, e := fc.Change("820", "", "WY", 20051, 20052)
+	assert.Nil(t, e)
+	assert.Equal(t, "us", level)
+	assert.InEpsilon(t, 1.015, r, 1e-9)
+
+	_, e = NewFallbackChain()
+	assert.NotNil(t, e)
+}
+
+func TestHPIdata_Nonmetro(t *testing.T) {
+	nonmetro := testLevel(testData(), "nonmetro")
+
+	s, e := nonmetro.Nonmetro("Texas")
+	assert.Nil(t, e)
+	assert.Equal(t, "TX", s.geoCode)
+
+	_, e = nonmetro.Nonmetro("XX")
+	assert.NotNil(t, e)
+
+	_, e = testData().Nonmetro("TX")
+	assert.NotNil(t, e)
+
+	// a metro property without a metro series falls back to the state, not the nonmetro series
+	fc, e := NewFallbackChain(testLevel(testData(), "metro"), nonmetro, testData())
+	assert.Nil(t, e)
+
+	_, level, e := fc.LookupZip("77002", "26420", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, "state", level)
+
+	_, level, e = fc.LookupZip("79901", "", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, "nonmetro", level)
+}
+
+func TestFallbackChain_KeysPR(t *testing.T) {
+	pr := testLevel(testData(), "pr")
+	pr.series = map[string]*HPIseries{"PR": pr.series["NY"]}
+
+	fc, e := NewFallbackChain(pr, testUS())
+	assert.Nil(t, e)
+
+	for _, st := range []string{"PR", "pr", "Puerto Rico", "72"} {
+		assert.Equal(t, []string{"PR", "USA"}, fc.Keys("006", "", st), st)
+
+		_, level, e := fc.Lookup("006", "", st, 20051)
+		assert.Nil(t, e)
+		assert.Equal(t, "pr", level, st)
+	}
+}
@@ -0,0 +1,90 @@
+package fhfa
+
+// FeatureSource supplies a numeric feature for a modeling pipeline.  keys describe the record (e.g. its zip3,
+// cbsa and state) and dt (CCYYQ) is the quarter of the feature.  ok is false if the feature isn't available.
+type FeatureSource interface {
+	Value(keys map[string]string, dt int) (v float64, ok bool)
+}
+
+// FeatureFunc is a function that is a FeatureSource.
+type FeatureFunc func(keys map[string]string, dt int) (float64, bool)
+
+// Value calls f.
+func (f FeatureFunc) Value(keys map[string]string, dt int) (float64, bool) {
+	return f(keys, dt)
+}
+
+// IndexFeature returns a FeatureSource of the index of hd for the geo keys[geoKey].
+func IndexFeature(hd *HPIdata, geoKey string) FeatureSource {
+	return FeatureFunc(func(keys map[string]string, dt int) (float64, bool) {
+		v, e := hd.Index(keys[geoKey], dt)
+
+		return v, e == nil
+	})
+}
+
+// ChainFeature returns a FeatureSource of the index from fc.  The keys are zip3, cbsa and state.  If zip3 or
+// state is missing and zip (a 5-digit ZIP) is present, they are derived from it.
+func ChainFeature(fc *FallbackChain) FeatureSource {
+	return FeatureFunc(func(keys map[string]string, dt int) (float64, bool) {
+		zip3, state := chainKeys(keys)
+		v, _, e := fc.Lookup(zip3, keys["cbsa"], state, dt)
+
+		return v, e == nil
+	})
+}
+
+// ChainChangeFeature returns a FeatureSource of the ratio of the index from fc at dt to the index at the quarter
+// keys[dtKey], taking both from the same geo level (see FallbackChain.Change).  The keys are as for ChainFeature.
+func ChainChangeFeature(fc *FallbackChain, dtKey string) FeatureSource {
+	return FeatureFunc(func(keys map[string]string, dt int) (float64, bool) {
+		base, e := ParseYrQtr(keys[dtKey])
+		if e != nil {
+			return 0, false
+		}
+
+		zip3, state := chainKeys(keys)
+		v, _, e := fc.Change(zip3, keys["cbsa"], state, int(base), dt)
+
+		return v, e == nil
+	})
+}
+
+// chainKeys returns the zip3 and state of keys, deriving them from zip if they are missing.
+func chainKeys(keys map[string]string) (zip3, state string) {
+	zip3, state = keys["zip3"], keys["state"]
+	if zip := keys["zip"]; zip != "" && (zip3 == "" || state == "") {
+		if z3, st, e := ZipKeys(zip); e == nil {
+			if zip3 == "" {
+				zip3 = z3
+			}
+
+			if state == "" {
+				state = st
+			}
+		}
+	}
+
+	return zip3, state
+}
+
+// ChangeSince returns a FeatureSource of the ratio of fs at dt to fs at the quarter keys[dtKey], e.g. the
+// appreciation since a loan's origination.  The quarter may be in any format accepted by ParseYrQtr.  fs must
+// draw both values from the same series, such as IndexFeature; a ChainFeature may take them from different geo
+// levels, so use ChainChangeFeature for a FallbackChain.
+func ChangeSince(fs FeatureSource, dtKey string) FeatureSource {
+	return FeatureFunc(func(keys map[string]string, dt int) (float64, bool) {
+		base, e := ParseYrQtr(keys[dtKey])
+		if e != nil {
+			return 0, false
+		}
+
+		v0, ok0 := fs.Value(keys, int(base))
+		v1, ok1 := fs.Value(keys, dt)
+		if !ok0 || !ok1 || v0 == 0 {
+			return 0, false
+		}
+
+		return v1 / v0, true
+	})
+}
@@ -0,0 +1,71 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexFeature(t *testing.T) {
+	fs := IndexFeature(testData(), "state")
+
+	v, ok := fs.Value(map[string]string{"state": "TX"}, 20001)
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, v)
+
+	_, ok = fs.Value(map[string]string{"state": "XX"}, 20001)
+	assert.False(t, ok)
+
+	chg := ChangeSince(fs, "origDt")
+	v, ok = chg.Value(map[string]string{"state": "TX", "origDt": "2000Q1"}, 20011)
+	assert.True(t, ok)
+	assert.InEpsilon(t, 1.01*1.01*1.01*1.01, v, 1e-10)
+
+	_, ok = chg.Value(map[string]string{"state": "TX", "origDt": "bad"}, 20011)
+	assert.False(t, ok)
+}
+
+func TestChainFeature(t *testing.T) {
+	fc, e := NewFallbackChain(testData(), testUS())
+	assert.Nil(t, e)
+
+	fs := ChainFeature(fc)
+	v, ok := fs.Value(map[string]string{"zip": "77002"}, 20001)
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, v)
+
+	// falls back to the US
+	_, ok = fs.Value(map[string]string{"state": "FL"}, 20001)
+	assert.True(t, ok)
+}
+
+func TestChainChangeFeature(t *testing.T) {
+	// the metro series starts after origination and at a different level
+	var (
+		dts  []int
+		indx []float64
+	)
+	for dt, v := 20051, 500.0; dt <= 20094; dt, v = NextQtr(dt), v*1.03 {
+		dts, indx = append(dts, dt), append(indx, v)
+	}
+
+	s, _ := NewHPIseries("Houston", "26420", dts, indx)
+	metro, _ := NewHPIdata("metro", map[string]*HPIseries{"26420": s})
+	fc, e := NewFallbackChain(metro, testData(), testUS())
+	assert.Nil(t, e)
+
+	keys := map[string]string{"cbsa": "26420", "state": "TX", "origDt": "2001Q1"}
+	v, ok := ChainChangeFeature(fc, "origDt").Value(keys, 20094)
+	assert.True(t, ok)
+	assert.InEpsilon(t, math.Pow(1.01, 35), v, 1e-10)
+
+	keys["origDt"] = "2006Q1"
+	v, ok = ChainChangeFeature(fc, "origDt").Value(keys, 20094)
+	assert.True(t, ok)
+	assert.InEpsilon(t, math.Pow(1.03, 15), v, 1e-10)
+
+	keys["origDt"] = "bad"
+	_, ok = ChainChangeFeature(fc, "origDt").Value(keys, 20094)
+	assert.False(t, ok)
+}
@@ -27,6 +27,10 @@ The series available are:
 
 Note that all dates are ints in CCYYQ format.
 
+Index values are stored and computed as float64, so chained ratios (Change, scenarios, forecasts) don't
+lose precision.  The FHFA publishes the indices to two decimals, well within float64 precision, so there
+is no separate value type.
+
 The XLSX format is chosen since not all of the data is available as a CSV but all are as XLSX.
 
 There are two basic data types here:
@@ -38,9 +42,16 @@ There are two basic data types here:
 package fhfa
 
 import (
+	"bufio"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
+	"maps"
 	"os"
+	"runtime"
 	"sort"
 	"strings"
 	"time"
@@ -49,29 +60,49 @@ import (
 )
 
 // HPIdata manages all the series at a geographic level (e.g. all states, MSAs, etc)
+//
+// Lookups (Index, Geo, Change, etc.) are safe for concurrent use provided no goroutine is modifying the
+// data (Append, ApplyScenario, SetExtrapolation, etc.).  Use a Manager to replace data while lookups continue.
 type HPIdata struct {
 	source   string
 	geoLevel string
 	series   map[string]*HPIseries
+	strict   bool
+	aliases  map[string]string // alias (upper case) -> geo, see Alias
+	tags     IndexTags
 }
 
 // NewHPIdata creates a HPIdata struct
 //
-// geoLevel - geographic level of the data, e.g. zip3, msa, state
+// geoLevel - geographic level of the data, e.g. zip3, msa, state, or cs for Case-Shiller data
 //
-// series - individual series
+// series - individual series, keyed by geo.  Keys are normalized by NormalizeGeo.
 func NewHPIdata(geoLevel string, series map[string]*HPIseries) (*HPIdata, error) {
-	if !in(geoLevel, []string{"zip3", "metro", "nonmetro", "state", "us", "pr", "mh"}) {
-		return nil, fmt.Errorf("invalid geo level: %s", geoLevel)
+	if e := checkGeoLevel(geoLevel); e != nil {
+		return nil, e
+	}
+
+	s := make(map[string]*HPIseries)
+	for k, v := range series {
+		s[NormalizeGeo(geoLevel, k)] = v
 	}
 
 	return &HPIdata{
 		source:   "NewHPIdata()",
 		geoLevel: geoLevel,
-		series:   series,
+		series:   s,
 	}, nil
 }
 
+// checkGeoLevel returns an error if geoLevel isn't one of the levels of the data.
+func checkGeoLevel(geoLevel string) error {
+	if !in(geoLevel, []string{"zip3", "metro", "nonmetro", "state", "us", "pr", "mh", "cs"}) {
+		return fmt.Errorf("invalid geo level: %s", geoLevel)
+	}
+
+	return nil
+}
+
 // LoadSQL loads from a query. The query must have these columns:
 //
 //   - year     - year of data
@@ -115,67 +146,70 @@ func LoadSQL(query, geoLevel string, db *sql.DB) (*HPIdata, error) {
 	return hd, nil
 }
 
-// Load loads the data from source - either a local file or a web address
+// Load loads the data from source - either a local file or a web address.  Sources ending in .gz or .zst are
+// decompressed first.  source may be a CSV laid out as the workbook is, or a zip archive holding one such
+// file (see LoadZip for archives with several).
 func Load(source string) (*HPIdata, error) {
-	var (
-		r    [][]string
-		rows *dass.Rows
-		e    error
-	)
+	start := time.Now()
+	hd, e := loadSource(source)
+	currentMetrics().Loaded(source, time.Since(start), e)
 
-	if r, e = dass.FetchXLSX(source); e != nil {
-		return nil, e
+	return hd, e
+}
+
+// loadSource does the work of Load.
+func loadSource(source string) (*HPIdata, error) {
+	if isZip(source) {
+		return loadZip(source)
 	}
 
-	geoLevel := geoLevel(r[0][0])
-	template := []string{"string", "int", "int", "float"}
-	names := []string{"geoCode", "year", "qtr", "index"}
-	miss := []string{"skip", "skip", "skip", "skip"}
+	local, cleanup, e := localCopy(source)
+	if e != nil {
+		return nil, e
+	}
+	defer cleanup()
 
-	if geoLevel == "metro" {
-		template = []string{"string", "string", "int", "int", "float"}
-		names = []string{"areaName", "geoCode", "year", "qtr", "index"}
-		miss = []string{"skip", "skip", "skip", "skip", "skip"}
+	var r [][]string
+	if strings.HasSuffix(strings.ToLower(local), ".csv") {
+		r, e = readCSV(local)
+	} else {
+		r, e = dass.FetchXLSX(local)
 	}
 
-	if rows, e = dass.ParseRows(r, names, template, miss, 0); e != nil {
+	if e != nil {
 		return nil, e
 	}
 
-	hd := &HPIdata{
-		source:   source,
-		geoLevel: geoLevel,
-		series:   make(map[string]*HPIseries),
+	hd, e := parseXLSX(r, runtime.GOMAXPROCS(0))
+	if e != nil {
+		return nil, e
 	}
 
-	if e2 := load(hd, rows); e2 != nil {
-		return nil, e2
-	}
+	hd.source = source
 
 	return hd, nil
 }
 
-// Append appends ta to the existing HPIData.
-func (hd *HPIdata) Append(ta *HPIdata) error {
-	if hd.geoLevel != ta.geoLevel {
-		return fmt.Errorf("geoLevel not the same in append")
-	}
-
-	for k, v := range hd.series {
-		var (
-			va *HPIseries
-			e  error
-		)
-		if va, e = ta.Geo(k); e != nil {
-			return fmt.Errorf("cannot find geo %s in append data", k)
-		}
+// All returns an iterator over the geos and series of hd in geo order.
+func (hd *HPIdata) All() iter.Seq2[string, *HPIseries] {
+	return func(yield func(string, *HPIseries) bool) {
+		geos := hd.Geos()
+		sort.Strings(geos)
 
-		if e1 := v.Append(va.dates, va.indx); e1 != nil {
-			return e1
+		for _, geo := range geos {
+			if !yield(geo, hd.series[geo]) {
+				return
+			}
 		}
 	}
+}
 
-	return nil
+// Append appends ta to the existing HPIData.  Every geo in hd must be in ta; geos only in ta are ignored.
+// See AppendWith for other options.
+func (hd *HPIdata) Append(ta *HPIdata) error {
+	_, e := hd.AppendWith(ta, AppendOptions{})
+
+	return e
 }
 
 // Change returns the ratio of the house price index at dtEnd (CCYYQ) to dtStart (CCYYQ)
@@ -213,21 +247,29 @@ func (hd *HPIdata) Copy() *HPIdata {
 		s[k] = v.Copy()
 	}
 
-	return &HPIdata{
+	cp := &HPIdata{
+		source:   hd.source,
 		geoLevel: hd.geoLevel,
 		series:   s,
+		strict:   hd.strict,
+		aliases:  maps.Clone(hd.aliases),
+		tags:     hd.tags,
 	}
+	cp.shareDates()
+
+	return cp
 }
 
-// Geo returns the house price data for location geo (e.g. TX).
+// Geo returns the house price data for location geo (e.g. TX).  Unless hd is strict, geo is normalized
+// (see NormalizeGeo), so for state and nonmetro data geo may also be the state name or FIPS code.
 func (hd *HPIdata) Geo(geo string) (*HPIseries, error) {
 	var (
 		h  *HPIseries
 		ok bool
 	)
 
-	if h, ok = hd.series[geo]; !ok {
-		return nil, fmt.Errorf("geo %s not found", geo)
+	if h, ok = hd.lookup(geo); !ok {
+		return nil, &GeoError{Geo: geo, Level: hd.geoLevel}
 	}
 
 	return h, nil
@@ -248,7 +290,8 @@ func (hd *HPIdata) Geos() []string {
 	return geos
 }
 
-// Last returns the date and value of the last date that was not appended.
+// Last returns the date and value of the last date that was not appended.  See End() for the last date
+// including appended data.
 func (hd *HPIdata) Last(geo string) (int, float64, error) {
 	var (
 		s *HPIseries
@@ -264,6 +307,20 @@ func (hd *HPIdata) Last(geo string) (int, float64, error) {
 	return dt, indx, nil
 }
 
+// Nonmetro returns the nonmetro series for the state st (postal code, name or FIPS code).  hd must be nonmetro data.
+func (hd *HPIdata) Nonmetro(st string) (*HPIseries, error) {
+	if hd.geoLevel != "nonmetro" {
+		return nil, fmt.Errorf("need nonmetro data, got %s", hd.geoLevel)
+	}
+
+	postal, e := NormalizeState(st)
+	if e != nil {
+		return nil, e
+	}
+
+	return hd.Geo(postal)
+}
+
 // Index returns the house price index for location geo (e.g. CA) at date dt (CCYYQ)
 func (hd *HPIdata) Index(geo string, dt int) (float64, error) {
 	var (
@@ -278,50 +335,75 @@ func (hd *HPIdata) Index(geo string, dt int) (float64, error) {
 	return s.Index(dt)
 }
 
-// Save saves the data as a CSV.
+// Save saves the data as a CSV, compressed if localFile ends in .gz (gzip) or .zst (zstd).  See SaveContext.
 func (hd *HPIdata) Save(localFile string) error {
-	var (
-		e    error
-		file *os.File
-	)
+	return hd.SaveContext(context.Background(), localFile)
+}
 
-	if file, e = os.Create(localFile); e != nil {
+// SaveContext saves the data as a CSV, streaming it row by row so memory use doesn't grow with the size of
+// the data.  The export stops if ctx is cancelled, in which case a partial local file is removed.
+func (hd *HPIdata) SaveContext(ctx context.Context, localFile string) error {
+	file, e := create(localFile)
+	if e != nil {
 		return e
 	}
 	defer file.Close()
 
-	var line strings.Builder
+	w := bufio.NewWriter(file)
+	if e := hd.WriteCSV(ctx, w); e != nil {
+		if o, _ := blobOpener(localFile); o == nil {
+			_ = file.Close()
+			_ = os.Remove(localFile)
+		}
+
+		return e
+	}
+
+	if e := w.Flush(); e != nil {
+		return e
+	}
 
+	return file.Close()
+}
+
+// WriteCSV writes the data to w as a CSV in geo and date order.  ctx is checked between geos.
+func (hd *HPIdata) WriteCSV(ctx context.Context, w io.Writer) error {
 	var geos []string
 	for g := range hd.series {
 		geos = append(geos, g)
 	}
 	sort.Strings(geos)
 
-	hasCode := hd.series[geos[0]].geoCode != ""
+	hasCode := len(geos) > 0 && hd.series[geos[0]].geoCode != ""
 	header := "geo,date,index\n"
 	if hasCode {
 		header = "geo,code,date,index\n"
 	}
 
-	line.WriteString(header)
+	if _, e := io.WriteString(w, header); e != nil {
+		return e
+	}
 
 	for _, g := range geos {
+		if e := ctx.Err(); e != nil {
+			return e
+		}
+
 		v := hd.series[g]
 		for j := range len(v.dates) {
-			linex := fmt.Sprintf("%s,%v,%0.2f\n", v.geoName, v.dates[j], v.indx[j])
+			var e error
 			if hasCode {
-				linex = fmt.Sprintf("\"%s\",%s,%v,%0.2f\n", v.geoName, v.geoCode, v.dates[j], v.indx[j])
+				_, e = fmt.Fprintf(w, "\"%s\",%s,%v,%0.2f\n", v.geoName, v.geoCode, v.dates[j], v.indx[j])
+			} else {
+				_, e = fmt.Fprintf(w, "%s,%v,%0.2f\n", v.geoName, v.dates[j], v.indx[j])
 			}
 
-			line.WriteString(linex)
+			if e != nil {
+				return e
+			}
 		}
 	}
 
-	if _, e := file.WriteString(line.String()); e != nil {
-		return e
-	}
-
 	return nil
 }
 
@@ -354,10 +436,13 @@ func (hd *HPIdata) Source() string {
 type HPIseries struct {
 	geoName  string
 	geoCode  string
-	dates    []int
+	dates    []int // may share its array with other series (see shareDates), so never modified in place
 	indx     []float64
 	lastDt   int
 	lastIndx float64
+	extrap   Extrapolation
+	match    LookupPolicy
+	prov     []Provenance // provenance of each observation, nil if all are published
 }
 
 func NewHPIseries(geoName, geoCode string, dates []int, indx []float64) (*HPIseries, error) {
@@ -366,7 +451,7 @@ func NewHPIseries(geoName, geoCode string, dates []int, indx []float64) (*HPIser
 	}
 
 	if !QtrsOK(dates) {
-		return nil, fmt.Errorf("dates don't increment by quarter")
+		return nil, ErrFrequencyMismatch
 	}
 
 	return &HPIseries{
@@ -379,15 +464,37 @@ func NewHPIseries(geoName, geoCode string, dates []int, indx []float64) (*HPIser
 	}, nil
 }
 
-// Append appends (dts,indx) to h. Note this does not change the values returned by Last().
+// Append appends (dts,indx) to h. The observations are flagged as projected and this does not change the
+// values returned by Last().
 func (h *HPIseries) Append(dts []int, indx []float64) error {
-	// check dates are OK
-	if QtrDiff(dts[0], h.lastDt) != 1 || !QtrsOK(dts) {
-		return fmt.Errorf("dates don't increment by quarter")
+	if e := h.checkAppend(dts, indx); e != nil {
+		return e
 	}
 
+	n := len(h.dates)
 	h.dates = append(h.dates, dts...)
 	h.indx = append(h.indx, indx...)
+	h.flag(n, Projected)
+
+	return nil
+}
+
+// checkAppend checks that (dts, indx) can be appended to h.
+func (h *HPIseries) checkAppend(dts []int, indx []float64) error {
+	if len(dts) == 0 || len(dts) != len(indx) {
+		return fmt.Errorf("dates and indx don't agree")
+	}
+
+	if !QtrsOK(dts) {
+		return ErrFrequencyMismatch
+	}
+
+	end := h.dates[len(h.dates)-1]
+	if n := QtrDiffSigned(end, dts[0]); n < 1 {
+		return fmt.Errorf("new data starts at %d, overlapping the series, which ends at %d", dts[0], end)
+	} else if n > 1 {
+		return fmt.Errorf("new data starts at %d, leaving a gap after %d", dts[0], end)
+	}
 
 	return nil
 }
@@ -439,29 +546,47 @@ func (h *HPIseries) Copy() *HPIseries {
 		indx:     indx,
 		lastDt:   h.lastDt,
 		lastIndx: h.lastIndx,
+		extrap:   h.extrap,
+		match:    h.match,
+		prov:     copyFlags(h.prov, 0, len(h.prov)),
+	}
+}
+
+// At returns the date (CCYYQ) and index value of the ith observation of h.
+func (h *HPIseries) At(i int) (dt int, indx float64, e error) {
+	if i < 0 || i >= len(h.dates) {
+		return 0, 0, fmt.Errorf("observation %d out of range [0,%d)", i, len(h.dates))
 	}
+
+	return h.dates[i], h.indx[i], nil
 }
 
-// data returns the data.
+// Data returns copies of the dates (CCYYQ) and index values.
 func (h *HPIseries) Data() (dts []int, hpi []float64) {
+	return h.Dates(), h.Values()
+}
+
+// Dates returns a copy of the dates (CCYYQ) of h.
+func (h *HPIseries) Dates() []int {
+	dts := make([]int, len(h.dates))
 	copy(dts, h.dates)
-	copy(hpi, h.indx)
 
-	return dts, hpi
+	return dts
 }
 
 // DateIndex returns the index in h.dates of the target date, dt. If dt is in the range of the
 // data but not there, dateIndex returns the largest date less than dt.
 // An error is returned if dt is outside the range of dates in h.date.
 //
-// -- dt -- date to find the index for, in CCYYMMDD format.
+// -- dt -- date to find the index for, in CCYYQ format.
 func (h *HPIseries) DateIndex(dt int) (int, error) {
-	if dt > h.dates[len(h.dates)-1] {
-		return -1, fmt.Errorf("date too large")
+	if dt > h.dates[len(h.dates)-1] || dt < h.dates[0] {
+		return -1, &DateRangeError{Dt: dt, First: h.dates[0], Last: h.dates[len(h.dates)-1]}
 	}
 
-	if dt < h.dates[0] {
-		return -1, fmt.Errorf("date too small")
+	// quarters are usually contiguous, so the offset from the first date is the position
+	if indx := QtrDiffSigned(h.dates[0], dt); indx < len(h.dates) && h.dates[indx] == dt {
+		return indx, nil
 	}
 
 	indx := sort.SearchInts(h.dates, dt)
@@ -474,30 +599,83 @@ func (h *HPIseries) DateIndex(dt int) (int, error) {
 	return indx, nil
 }
 
-// Index returns the house price index at date dt (CCYYQ).
+// exact returns the position of dt in h.dates, -1 if it's not there.
+func (h *HPIseries) exact(dt int) int {
+	if indx, e := h.DateIndex(dt); e == nil && h.dates[indx] == dt {
+		return indx
+	}
+
+	return -1
+}
+
+// span returns the positions in h.dates of the first and last dates within [dtStart, dtEnd].
+func (h *HPIseries) span(dtStart, dtEnd int) (first, last int, e error) {
+	if dtEnd < dtStart {
+		return -1, -1, fmt.Errorf("dtEnd %d precedes dtStart %d", dtEnd, dtStart)
+	}
+
+	first = sort.SearchInts(h.dates, dtStart)
+	last = sort.SearchInts(h.dates, dtEnd+1) - 1
+
+	if first > last {
+		return -1, -1, fmt.Errorf("no data between %d and %d", dtStart, dtEnd)
+	}
+
+	return first, last, nil
+}
+
+// First returns the date (CCYYQ) and index value of the first date in the series.
+func (h *HPIseries) First() (dt int, indx float64) {
+	return h.dates[0], h.indx[0]
+}
+
+// Index returns the house price index at date dt (CCYYQ).  Dates missing from the series are matched
+// according to the lookup policy (the prior quarter by default).  Dates after the end of the series
+// are extrapolated if an extrapolation policy has been set.
 func (h *HPIseries) Index(dt int) (float64, error) {
 	var (
 		indx int
 		e    error
 	)
 
-	if indx, e = h.DateIndex(dt); e != nil {
+	if indx, e = h.position(dt, h.match); e != nil {
+		if dt > h.dates[len(h.dates)-1] && h.extrap.Method != ExtrapNone {
+			return h.extrapolate(dt)
+		}
+
 		return 0, e
 	}
 
 	return h.indx[indx], nil
 }
 
+// Len returns the number of observations in h, including appended data.
+func (h *HPIseries) Len() int {
+	return len(h.dates)
+}
+
 // Name returns the series Name.  Uninteresting unless this is MSA-level data.
 func (h *HPIseries) Name() string {
 	return h.geoName
 }
 
-// Last returns the date and index value of the last date in the series.  This is unchanged if Append() is used.
+// Last returns the date and index value of the last loaded (published) date in the series.  This is unchanged
+// if Append() is used and is the same as LastLoaded().  See End() for the last date including appended data.
 func (h *HPIseries) Last() (dt int, indx float64) {
 	return h.lastDt, h.lastIndx
 }
 
+// Observations returns an iterator over the dates (CCYYQ) and index values of h, including appended data.
+func (h *HPIseries) Observations() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for j, dt := range h.dates {
+			if !yield(dt, h.indx[j]) {
+				return
+			}
+		}
+	}
+}
+
 func (h *HPIseries) String() string {
 	var s strings.Builder
 	s.WriteString(fmt.Sprintf("name: %s\ngeocode: %s\n", h.geoName, h.geoCode))
@@ -515,6 +693,41 @@ func (h *HPIseries) String() string {
 	return s.String()
 }
 
+// Values returns a copy of the index values of h.
+func (h *HPIseries) Values() []float64 {
+	indx := make([]float64, len(h.indx))
+	copy(indx, h.indx)
+
+	return indx
+}
+
+// Window returns a copy of h restricted to the dates between dtStart and dtEnd (CCYYQ).  Last() of the
+// result is the last loaded (not projected) date within the window, or the end of the window if every quarter
+// in it is projected.
+func (h *HPIseries) Window(dtStart, dtEnd int) (*HPIseries, error) {
+	first, last, e := h.span(dtStart, dtEnd)
+	if e != nil {
+		return nil, e
+	}
+
+	lastDt, lastIndx := h.dates[last], h.indx[last]
+	for j := first; j <= last && h.dates[j] <= h.lastDt; j++ {
+		lastDt, lastIndx = h.dates[j], h.indx[j]
+	}
+
+	return &HPIseries{
+		geoName:  h.geoName,
+		geoCode:  h.geoCode,
+		dates:    append([]int{}, h.dates[first:last+1]...),
+		indx:     append([]float64{}, h.indx[first:last+1]...),
+		lastDt:   lastDt,
+		lastIndx: lastIndx,
+		extrap:   h.extrap,
+		match:    h.match,
+		prov:     copyFlags(h.prov, first, last+1),
+	}, nil
+}
+
 /////////////
 
 // Best looks through the HPI series returning the first one that has data for the geo.
@@ -530,13 +743,37 @@ func Best(dt int, keys []string, hpis []*HPIdata) (hpi float64, geoLevel string,
 		return 0, "", fmt.Errorf("invalid series")
 	}
 
+	errs := make([]error, len(hpis))
 	for j, s := range hpis {
-		if indx, e := s.Index(keys[j], dt); e == nil {
+		indx, e := s.Index(keys[j], dt)
+		if e == nil {
 			return indx, s.geoLevel, nil
 		}
+
+		errs[j] = e
+	}
+
+	return 0, "", fmt.Errorf("geo/dt not found in Best: %w", errors.Join(errs...))
+}
+
+// BestChange looks through the HPI series returning the ratio of the index at dtEnd to dtStart (CCYYQ) from the
+// first one that has data for the geo at both dates.  keys and hpis are as in Best.
+func BestChange(dtStart, dtEnd int, keys []string, hpis []*HPIdata) (ratio float64, geoLevel string, e error) {
+	if len(keys) != len(hpis) || len(hpis) == 0 {
+		return 0, "", fmt.Errorf("invalid series")
+	}
+
+	errs := make([]error, len(hpis))
+	for j, s := range hpis {
+		r, e := s.Change(keys[j], dtStart, dtEnd)
+		if e == nil {
+			return r, s.geoLevel, nil
+		}
+
+		errs[j] = e
 	}
 
-	return 0, "", fmt.Errorf("geo/dt not found in Best")
+	return 0, "", fmt.Errorf("geo/dt not found in BestChange: %w", errors.Join(errs...))
 }
 
 // ToDate converts a CCYYQ int to a date. The date returned is the first day of the first
@@ -545,8 +782,8 @@ func ToTime(dt int) (time.Time, error) {
 	yr := dt / 10
 	qtr := dt - 10*yr
 
-	if yr < 1960 || yr > 2060 || qtr < 1 || qtr > 4 {
-		return time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), fmt.Errorf("illegal date conversion")
+	if !YrQtr(dt).Valid() {
+		return time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), badDate(dt)
 	}
 
 	month := time.Month(1 + 3*(qtr-1))
@@ -569,7 +806,7 @@ func NextQtr(dt int) int {
 	qtr := dt - 10*yr
 
 	if yr < 1960 || qtr < 1 || qtr > 4 {
-		panic(fmt.Errorf("illegal date: %v", dt))
+		panic(badDate(dt))
 	}
 
 	qtr++
@@ -581,7 +818,58 @@ func NextQtr(dt int) int {
 	return 10*yr + qtr
 }
 
-// QtrDiff returns the number of quarters between dt0 (CCYYQ) and dt1 (CCYYQ)
+// PrevQtr moves dt (CCYYQ) back by 1 quarter
+func PrevQtr(dt int) int {
+	yr := dt / 10
+	qtr := dt - 10*yr
+
+	if yr < 1960 || qtr < 1 || qtr > 4 {
+		panic(badDate(dt))
+	}
+
+	qtr--
+	if qtr == 0 {
+		qtr = 4
+		yr--
+	}
+
+	return 10*yr + qtr
+}
+
+// AddQtrs moves dt (CCYYQ) by n quarters, n may be negative.  dt is not checked.
+func AddQtrs(dt, n int) int {
+	q := 4*(dt/10) + dt%10 - 1 + n
+
+	return 10*(q/4) + q%4 + 1
+}
+
+// QtrRange returns the quarters (CCYYQ) from start through end.  It returns nil if end precedes start or
+// either is not a legal date.
+func QtrRange(start, end int) []int {
+	if end < start || !YrQtr(start).Valid() || !YrQtr(end).Valid() {
+		return nil
+	}
+
+	dts := make([]int, 0, QtrDiff(start, end)+1)
+	for dt := start; dt <= end; dt = AddQtrs(dt, 1) {
+		dts = append(dts, dt)
+	}
+
+	return dts
+}
+
+// QtrEndDate returns the last day of quarter dt (CCYYQ).
+func QtrEndDate(dt int) (time.Time, error) {
+	t, e := ToTime(dt)
+	if e != nil {
+		return t, e
+	}
+
+	return t.AddDate(0, 3, -1), nil
+}
+
+// QtrDiff returns the number of quarters between dt0 (CCYYQ) and dt1 (CCYYQ), regardless of order.
+// See QtrDiffSigned for a directional difference.
 func QtrDiff(dt0, dt1 int) int {
 	if dt1 < dt0 {
 		dt1, dt0 = dt0, dt1 //TODO: check
@@ -593,10 +881,19 @@ func QtrDiff(dt0, dt1 int) int {
 	return 4*(yr1-yr0) + qtr1 - qtr0
 }
 
+// QtrDiffSigned returns the number of quarters from from (CCYYQ) to to (CCYYQ), which is negative if to
+// precedes from.
+func QtrDiffSigned(from, to int) int {
+	yr0, yr1 := from/10, to/10
+	qtr0, qtr1 := from-10*yr0, to-10*yr1
+
+	return 4*(yr1-yr0) + qtr1 - qtr0
+}
+
 // QtrsOK checks that the elements of dt increment 1 quarter at a time.
 func QtrsOK(dt []int) bool {
 	for j := 1; j < len(dt); j++ {
-		if QtrDiff(dt[j-1], dt[j]) != 1 {
+		if QtrDiffSigned(dt[j-1], dt[j]) != 1 {
 			return false
 		}
 	}
@@ -629,36 +926,12 @@ func URLs(series string) string {
 
 ////////////
 
-// geoLevel returns the geographic level of the data (e.g. metro, us,..)
+// geoLevel returns the geographic level of the data (e.g. metro, us,..) from the title of a workbook
 func geoLevel(header string) string {
-	header = strings.ToLower(header)
-
-	if strings.Contains(header, "three-digit zip") {
-		return "zip3"
-	}
-
-	if strings.Contains(header, "metropolitan areas") {
-		return "metro"
-	}
-
-	if strings.Contains(header, "not in metropolitan statistical areas") {
-		return "nonmetro"
-	}
-
-	if strings.Contains(header, "states and the district of columbia") {
-		return "state"
-	}
-
-	if strings.Contains(header, "census divisions") {
-		return "us"
-	}
-
-	if strings.Contains(header, "puerto rico") {
-		return "pr"
-	}
-
-	if strings.Contains(header, "manufactured homes") {
-		return "mh"
+	for _, s := range Schemas() {
+		if strings.Contains(strings.ToLower(header), strings.ToLower(s.Title)) {
+			return s.GeoLevel
+		}
 	}
 
 	return "unknown"
@@ -676,12 +949,23 @@ func in[T comparable](needle T, haystack []T) bool {
 
 // load works through rows to load the dates and indices into hd
 func load(hd *HPIdata, rows *dass.Rows) error {
+	if e := loadRows(hd, rows); e != nil {
+		return e
+	}
+
+	hd.shareDates()
+
+	return nil
+}
+
+// loadRows adds the series in rows, which must be grouped by geo, to hd.
+func loadRows(hd *HPIdata, rows *dass.Rows) error {
 	var series *HPIseries
 
 	lastGeo := ""
 
 	for _, row := range rows.Iter() {
-		geo := row["geoCode"].(string)
+		geo := NormalizeGeo(hd.geoLevel, fmt.Sprint(row["geoCode"]))
 
 		// New geo?
 		if geo != lastGeo {
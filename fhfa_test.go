@@ -1,10 +1,12 @@
 package fhfa
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -35,6 +37,43 @@ func sources() []string {
 	return srcs
 }
 
+// testGrowth is the constant quarterly growth rate of each geo in testData.
+var testGrowth = map[string]float64{"CA": 0.02, "TX": 0.01, "NY": -0.005}
+
+// testData returns synthetic state-level data that starts at 100 in 20001 and runs 40 quarters.
+// Each geo grows at the rate in testGrowth.
+func testData() *HPIdata {
+	series := make(map[string]*HPIseries)
+	for geo, g := range testGrowth {
+		var (
+			dts  []int
+			indx []float64
+		)
+
+		dt, v := 20001, 100.0
+		for range 40 {
+			dts = append(dts, dt)
+			indx = append(indx, v)
+			dt = NextQtr(dt)
+			v *= 1 + g
+		}
+
+		s, e := NewHPIseries(geo, geo, dts, indx)
+		if e != nil {
+			panic(e)
+		}
+
+		series[geo] = s
+	}
+
+	hd, e := NewHPIdata("state", series)
+	if e != nil {
+		panic(e)
+	}
+
+	return hd
+}
+
 // NewConnect established a new connection to ClickHouse.
 // host is IP address (assumes port 9000), memory is max_memory_usage
 func newConnectCH() *sql.DB {
@@ -295,3 +334,169 @@ func TestLoadSQL(t *testing.T) {
 	assert.Equal(t, v1, v)
 
 }
+
+func TestHPIseries_Window(t *testing.T) {
+	s := testData().series["TX"]
+
+	w, e := s.Window(20031, 20044)
+	assert.Nil(t, e)
+	assert.Equal(t, 8, len(w.dates))
+
+	dt, v := w.Last()
+	assert.Equal(t, 20044, dt)
+	assert.Equal(t, s.indx[19], v)
+
+	_, e = w.Index(20051)
+	assert.NotNil(t, e)
+
+	w.indx[0] = 0
+	assert.NotEqual(t, 0.0, s.indx[12])
+
+	_, e = s.Window(19801, 19904)
+	assert.NotNil(t, e)
+
+	// Last() skips projected quarters
+	s = s.Copy()
+	assert.Nil(t, s.Append([]int{20101, 20102}, []float64{300, 301}))
+	w, e = s.Window(20091, 20102)
+	assert.Nil(t, e)
+	dt, v = w.Last()
+	assert.Equal(t, 20094, dt)
+	assert.Equal(t, s.indx[39], v)
+}
+
+func TestHPIseries_Data(t *testing.T) {
+	s := testData().series["CA"]
+
+	dts, indx := s.Data()
+	assert.Equal(t, s.Len(), len(dts))
+	assert.Equal(t, s.Len(), len(indx))
+
+	dts[0], indx[0] = 0, 0
+	dt, v := s.First()
+	assert.Equal(t, 20001, dt)
+	assert.Equal(t, 100.0, v)
+
+	dt, v, e := s.At(4)
+	assert.Nil(t, e)
+	assert.Equal(t, 20011, dt)
+	assert.Equal(t, s.Values()[4], v)
+
+	_, _, e = s.At(s.Len())
+	assert.NotNil(t, e)
+
+	c := s.Copy()
+	assert.Equal(t, s.Dates(), c.Dates())
+}
+
+func TestHPIdata_All(t *testing.T) {
+	hd := testData()
+
+	var geos []string
+	for geo, s := range hd.All() {
+		geos = append(geos, geo)
+
+		n := 0
+		for dt, v := range s.Observations() {
+			exp, e := s.Index(dt)
+			assert.Nil(t, e)
+			assert.Equal(t, exp, v)
+			n++
+		}
+		assert.Equal(t, s.Len(), n)
+	}
+
+	assert.Equal(t, []string{"CA", "NY", "TX"}, geos)
+}
+
+func TestHPIseries_DateIndex(t *testing.T) {
+	// contiguous
+	s := testData().series["NY"]
+	for j, dt := range s.dates {
+		indx, e := s.DateIndex(dt)
+		assert.Nil(t, e)
+		assert.Equal(t, j, indx)
+	}
+
+	// with gaps
+	s = &HPIseries{dates: []int{20101, 20103, 20104, 20121}, indx: []float64{1, 2, 3, 4}}
+	dts := []int{20101, 20102, 20103, 20104, 20111, 20121}
+	exp := []int{0, 0, 1, 2, 2, 3}
+	for j, dt := range dts {
+		indx, e := s.DateIndex(dt)
+		assert.Nil(t, e)
+		assert.Equal(t, exp[j], indx)
+	}
+
+	_, e := s.DateIndex(20122)
+	assert.NotNil(t, e)
+}
+
+func BenchmarkHPIdata_Index(b *testing.B) {
+	hd := testData()
+	geos := hd.Geos()
+
+	for j := range b.N {
+		_, _ = hd.Index(geos[j%len(geos)], AddQtrs(20001, j%40))
+	}
+}
+
+func TestQtrRange(t *testing.T) {
+	assert.Equal(t, []int{20223, 20224, 20231}, QtrRange(20223, 20231))
+	assert.Equal(t, []int{20223}, QtrRange(20223, 20223))
+	assert.Nil(t, QtrRange(20231, 20223))
+	assert.Nil(t, QtrRange(20225, 20231))
+
+	assert.Equal(t, 20224, PrevQtr(20231))
+	assert.Equal(t, 20232, PrevQtr(20233))
+	assert.Panics(t, func() { PrevQtr(20230) })
+
+	end, e := QtrEndDate(20231)
+	assert.Nil(t, e)
+	assert.Equal(t, time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC), end)
+
+	end, e = QtrEndDate(20234)
+	assert.Nil(t, e)
+	assert.Equal(t, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), end)
+
+	_, e = QtrEndDate(20235)
+	assert.NotNil(t, e)
+}
+
+func TestQtrDiffSigned(t *testing.T) {
+	assert.Equal(t, 5, QtrDiffSigned(20224, 20241))
+	assert.Equal(t, -5, QtrDiffSigned(20241, 20224))
+	assert.Equal(t, 0, QtrDiffSigned(20241, 20241))
+	assert.False(t, QtrsOK([]int{20232, 20231}))
+
+	// appending twice extends from the end of the series, not the last loaded date
+	h, e := NewHPIseries("X", "X", []int{20231, 20232}, []float64{100, 101})
+	assert.Nil(t, e)
+	assert.Nil(t, h.Append([]int{20233}, []float64{102}))
+	assert.Nil(t, h.Append([]int{20234}, []float64{103}))
+	assert.NotNil(t, h.Append([]int{20234}, []float64{103}))
+	assert.NotNil(t, h.Append([]int{20242}, []float64{103}))
+}
+
+func TestHPIdata_SaveContext(t *testing.T) {
+	hd := testData()
+	file := filepath.Join(t.TempDir(), "state.csv")
+	assert.Nil(t, hd.SaveContext(context.Background(), file))
+
+	b, e := os.ReadFile(file)
+	assert.Nil(t, e)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Len(t, lines, 121)
+	assert.Equal(t, "geo,code,date,index", lines[0])
+	assert.Equal(t, `"CA",CA,20001,100.00`, lines[1])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, hd.SaveContext(ctx, file), context.Canceled)
+	_, e = os.Stat(file)
+	assert.True(t, os.IsNotExist(e))
+
+	var buf strings.Builder
+	assert.Nil(t, (&HPIdata{series: map[string]*HPIseries{}}).WriteCSV(context.Background(), &buf))
+	assert.Equal(t, "geo,date,index\n", buf.String())
+}
@@ -0,0 +1,129 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+)
+
+// FillMethod determines how FillGaps fills missing interior quarters.
+type FillMethod int
+
+const (
+	// FillNone leaves gaps in place.
+	FillNone FillMethod = iota
+
+	// FillLinear interpolates linearly between the quarters on either side of the gap.
+	FillLinear
+
+	// FillLogLinear interpolates the log of the index, so growth is constant across the gap.
+	FillLogLinear
+
+	// FillCarryForward carries the value before the gap forward.
+	FillCarryForward
+)
+
+// LoadOptions are options for LoadWith.
+type LoadOptions struct {
+	Fill FillMethod // method used to fill missing interior quarters
+
+	// CBSAChanges are applied to metro data by RemapCBSAs.  Use CBSAChanges() for the embedded table.
+	CBSAChanges []CBSAChange
+}
+
+// LoadWith loads the data as Load does and then applies opts.
+func LoadWith(source string, opts LoadOptions) (*HPIdata, error) {
+	if e := opts.Fill.check(); e != nil {
+		return nil, e
+	}
+
+	hd, e := Load(source)
+	if e != nil {
+		return nil, e
+	}
+
+	if _, e := hd.FillGaps(opts.Fill); e != nil {
+		return nil, e
+	}
+
+	if hd.geoLevel == "metro" && len(opts.CBSAChanges) > 0 {
+		if _, e := hd.RemapCBSAs(opts.CBSAChanges); e != nil {
+			return nil, e
+		}
+	}
+
+	return hd, nil
+}
+
+// FillGaps fills the missing interior quarters of every series in hd using method.  The loader skips rows
+// with blank index values, so small zip3 and metro series may have gaps.  It returns the number of quarters
+// filled in each series that had gaps.
+func (hd *HPIdata) FillGaps(method FillMethod) (map[string]int, error) {
+	if e := method.check(); e != nil {
+		return nil, e
+	}
+
+	filled := make(map[string]int)
+	for geo, s := range hd.series {
+		if n, _ := s.FillGaps(method); n > 0 {
+			filled[geo] = n
+		}
+	}
+
+	return filled, nil
+}
+
+// FillGaps fills the missing interior quarters of h using method and returns the number of quarters filled.
+// The filled quarters have provenance Filled.
+func (h *HPIseries) FillGaps(method FillMethod) (int, error) {
+	if e := method.check(); e != nil {
+		return 0, e
+	}
+
+	if method == FillNone || QtrsOK(h.dates) {
+		return 0, nil
+	}
+
+	var (
+		dts  = []int{h.dates[0]}
+		indx = []float64{h.indx[0]}
+		prov = []Provenance{h.provenance(0)}
+	)
+
+	for j := 1; j < len(h.dates); j++ {
+		a, b := h.indx[j-1], h.indx[j]
+		n := QtrDiff(h.dates[j-1], h.dates[j])
+		for k := 1; k < n; k++ {
+			frac := float64(k) / float64(n)
+
+			v := a
+			switch method {
+			case FillLinear:
+				v = a + frac*(b-a)
+			case FillLogLinear:
+				v = a * math.Pow(b/a, frac)
+			}
+
+			dts = append(dts, AddQtrs(h.dates[j-1], k))
+			indx = append(indx, v)
+			prov = append(prov, Filled)
+		}
+
+		dts = append(dts, h.dates[j])
+		indx = append(indx, b)
+		prov = append(prov, h.provenance(j))
+	}
+
+	n := len(dts) - len(h.dates)
+	h.dates, h.indx, h.prov = dts, indx, prov
+
+	return n, nil
+}
+
+// check validates the method
+func (m FillMethod) check() error {
+	if m < FillNone || m > FillCarryForward {
+		return fmt.Errorf("unknown fill method: %d", m)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_FillGaps(t *testing.T) {
+	gapped := func() *HPIseries {
+		return &HPIseries{geoName: "X", geoCode: "X", dates: []int{20201, 20204, 20211}, indx: []float64{100, 100 * 1.1 * 1.1 * 1.1, 140},
+			lastDt: 20211, lastIndx: 140}
+	}
+
+	h := gapped()
+	n, e := h.FillGaps(FillLogLinear)
+	assert.Nil(t, e)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []int{20201, 20202, 20203, 20204, 20211}, h.Dates())
+	assert.InDelta(t, 110.0, h.indx[1], 1e-9)
+	assert.InDelta(t, 121.0, h.indx[2], 1e-9)
+	assert.True(t, QtrsOK(h.dates))
+
+	p, _ := h.Provenance(20202)
+	assert.Equal(t, Filled, p)
+	p, _ = h.Provenance(20204)
+	assert.Equal(t, Published, p)
+
+	h = gapped()
+	_, e = h.FillGaps(FillLinear)
+	assert.Nil(t, e)
+	assert.InDelta(t, 100+(133.1-100)/3, h.indx[1], 1e-9)
+
+	h = gapped()
+	_, e = h.FillGaps(FillCarryForward)
+	assert.Nil(t, e)
+	assert.Equal(t, 100.0, h.indx[2])
+
+	h = gapped()
+	n, e = h.FillGaps(FillNone)
+	assert.Nil(t, e)
+	assert.Equal(t, 0, n)
+
+	_, e = h.FillGaps(FillMethod(10))
+	assert.NotNil(t, e)
+
+	hd, e := NewHPIdata("zip3", map[string]*HPIseries{"X": gapped()})
+	assert.Nil(t, e)
+	filled, e := hd.FillGaps(FillLinear)
+	assert.Nil(t, e)
+	assert.Equal(t, map[string]int{"X": 2}, filled)
+}
@@ -0,0 +1,138 @@
+package fhfa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/invertedv/dass"
+)
+
+// FMHPIURL is the address of the Freddie Mac House Price Index (FMHPI) master file.
+const FMHPIURL = "https://www.freddiemac.com/fmac-resources/research/docs/fmhpi_master_file.csv"
+
+// LoadFMHPI loads the Freddie Mac House Price Index for geoLevel (us, state or metro) from source - either a
+// local file or a web address (e.g. FMHPIURL).  The file has a row per geo and month with the columns
+// Year, Month, GEO_Type, GEO_Name, GEO_Code and Index_NSA.  The monthly values are averaged to quarters,
+// keeping only quarters with all 3 months present.
+//
+// The result has the same geo level and geo keys as the FHFA data (USA, state postal codes and CBSA codes), so
+// it can be compared with, or used as a fallback for, the FHFA indices (see Providers).
+func LoadFMHPI(source, geoLevel string) (*HPIdata, error) {
+	geoType, ok := map[string]string{"us": "us", "state": "state", "metro": "cbsa"}[geoLevel]
+	if !ok {
+		return nil, fmt.Errorf("geo level %s is not in the FMHPI", geoLevel)
+	}
+
+	r, e := dass.FetchCSV(source)
+	if e != nil {
+		return nil, e
+	}
+
+	if len(r) < 2 {
+		return nil, fmt.Errorf("no data in %s", source)
+	}
+
+	cols := make(map[string]int)
+	for j, name := range r[0] {
+		cols[strings.ToLower(strings.TrimSpace(name))] = j
+	}
+
+	// maxCol is the largest index of the columns used
+	maxCol := 0
+	for _, name := range []string{"year", "month", "geo_type", "geo_name", "geo_code", "index_nsa"} {
+		col, ok := cols[name]
+		if !ok {
+			return nil, fmt.Errorf("column %s not in %s", name, source)
+		}
+
+		maxCol = max(maxCol, col)
+	}
+
+	type obs struct {
+		name string
+		ts   []time.Time
+		vals []float64
+	}
+
+	geos := make(map[string]*obs)
+	for j, row := range r[1:] {
+		if len(row) <= maxCol {
+			return nil, fmt.Errorf("line %d of %s has %d fields, need %d", j+2, source, len(row), maxCol+1)
+		}
+
+		if strings.ToLower(strings.TrimSpace(row[cols["geo_type"]])) != geoType {
+			continue
+		}
+
+		yr, e := strconv.Atoi(strings.TrimSpace(row[cols["year"]]))
+		if e != nil {
+			return nil, fmt.Errorf("bad year in %s: %s", source, row[cols["year"]])
+		}
+
+		mo, e := strconv.Atoi(strings.TrimSpace(row[cols["month"]]))
+		if e != nil || mo < 1 || mo > 12 {
+			return nil, fmt.Errorf("bad month in %s: %s", source, row[cols["month"]])
+		}
+
+		v, e := strconv.ParseFloat(strings.TrimSpace(row[cols["index_nsa"]]), 64)
+		if e != nil {
+			continue
+		}
+
+		name := unquote(strings.TrimSpace(row[cols["geo_name"]]))
+		geo := fmhpiGeo(geoLevel, name, strings.TrimSpace(row[cols["geo_code"]]))
+		if geos[geo] == nil {
+			geos[geo] = &obs{name: name}
+		}
+
+		o := geos[geo]
+		o.ts = append(o.ts, time.Date(yr, time.Month(mo), 1, 0, 0, 0, 0, time.UTC))
+		o.vals = append(o.vals, v)
+	}
+
+	hd := &HPIdata{
+		source:   source,
+		geoLevel: geoLevel,
+		series:   make(map[string]*HPIseries),
+		tags:     IndexTags{Adjustment: NSA, Frequency: Monthly},
+	}
+
+	for geo, o := range geos {
+		dts, indx := toQuarterly(o.ts, o.vals)
+		if len(dts) == 0 {
+			continue
+		}
+
+		name := o.name
+		if geoLevel != "metro" {
+			name = geo
+		}
+
+		s, e := NewHPIseries(name, geo, dts, indx)
+		if e != nil {
+			return nil, fmt.Errorf("geo %s: %w", geo, e)
+		}
+
+		hd.series[geo] = s
+	}
+
+	if len(hd.series) == 0 {
+		return nil, fmt.Errorf("no %s data in %s", geoLevel, source)
+	}
+
+	return hd, nil
+}
+
+// fmhpiGeo returns the FHFA geo key of an FMHPI geo.
+func fmhpiGeo(geoLevel, name, code string) string {
+	switch geoLevel {
+	case "us":
+		return "USA"
+	case "state":
+		return NormalizeGeo(geoLevel, name)
+	default:
+		return NormalizeGeo(geoLevel, code)
+	}
+}
@@ -0,0 +1,77 @@
+package fhfa
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFMHPI(t *testing.T) {
+	csv := `Year,Month,GEO_Type,GEO_Name,GEO_Code,Index_NSA,Index_SA
+2020,1,State,TX,.,100,100
+2020,2,State,TX,.,101,101
+2020,3,State,TX,.,102,102
+2020,4,State,TX,.,103,103
+2020,1,CBSA,"Abilene, TX",10180,50,50
+2020,2,CBSA,"Abilene, TX",10180,51,51
+2020,3,CBSA,"Abilene, TX",10180,52,52
+2020,1,US,USA,USA,200,200
+2020,2,US,USA,USA,200,200
+2020,3,US,USA,USA,200,200
+`
+	file := filepath.Join(t.TempDir(), "fmhpi.csv")
+	assert.Nil(t, os.WriteFile(file, []byte(csv), 0o644))
+
+	hd, e := LoadFMHPI(file, "state")
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"TX"}, hd.Geos())
+	v, e := hd.Index("TX", 20201)
+	assert.Nil(t, e)
+	assert.Equal(t, 101.0, v)
+
+	// 20202 has only one month
+	_, e = hd.Index("TX", 20202)
+	assert.ErrorIs(t, e, ErrDateOutOfRange)
+	dt, _, _ := hd.Last("TX")
+	assert.Equal(t, 20201, dt)
+
+	hd, e = LoadFMHPI(file, "metro")
+	assert.Nil(t, e)
+	s, e := hd.Geo("10180")
+	assert.Nil(t, e)
+	assert.Equal(t, "Abilene, TX", s.Name())
+
+	hd, e = LoadFMHPI(file, "us")
+	assert.Nil(t, e)
+	v, e = hd.Index("USA", 20201)
+	assert.Nil(t, e)
+	assert.Equal(t, 200.0, v)
+
+	_, e = LoadFMHPI(file, "zip3")
+	assert.NotNil(t, e)
+
+	// FMHPI as a fallback behind FHFA, which ends in 20094
+	fm, e := LoadFMHPI(file, "state")
+	assert.Nil(t, e)
+	p := Providers{testData(), fm}
+	v, e = p.Index("TX", 20001)
+	assert.Nil(t, e)
+	assert.Equal(t, 100.0, v)
+	v, e = p.Index("TX", 20201)
+	assert.Nil(t, e)
+	assert.Equal(t, 101.0, v)
+}
+
+func TestLoadFMHPI_shortRow(t *testing.T) {
+	csv := `Year,Month,GEO_Type,GEO_Name,GEO_Code,Index_NSA,Index_SA
+2020,1,State,TX,.,100,100
+2020,2,State
+`
+	file := filepath.Join(t.TempDir(), "fmhpi.csv")
+	assert.Nil(t, os.WriteFile(file, []byte(csv), 0o644))
+
+	_, e := LoadFMHPI(file, "state")
+	assert.ErrorContains(t, e, "line 3")
+}
@@ -0,0 +1,167 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+)
+
+// ForecastModel projects quarterly growth rates for the quarters following the end of a series.
+type ForecastModel interface {
+	Growth(h *HPIseries, nQtrs int) ([]float64, error)
+}
+
+// TrendModel projects the average quarterly growth over the trailing Qtrs quarters (4 if Qtrs is 0).
+type TrendModel struct {
+	Qtrs int
+}
+
+// SmoothingModel projects the exponentially smoothed quarterly growth rate.  Alpha is the weight on
+// the most recent quarter and must be in (0, 1].
+type SmoothingModel struct {
+	Alpha float64
+}
+
+// MeanReversionModel starts at the trailing 4-quarter growth rate and reverts to LongRun.
+// Speed is the fraction of the gap to LongRun closed each quarter and must be in [0, 1].
+type MeanReversionModel struct {
+	LongRun       float64       // long-run growth rate
+	Speed         float64       // speed of reversion
+	Annualization Annualization // convention LongRun is quoted in, quarterly by default
+}
+
+// Growth returns nQtrs quarters of the trailing average growth of h.
+func (m TrendModel) Growth(h *HPIseries, nQtrs int) ([]float64, error) {
+	n := m.Qtrs
+	if n == 0 {
+		n = 4
+	}
+
+	g, e := h.trendGrowth(n)
+	if e != nil {
+		return nil, e
+	}
+
+	return constant(g, nQtrs), nil
+}
+
+// Growth returns nQtrs quarters of the smoothed growth of h.
+func (m SmoothingModel) Growth(h *HPIseries, nQtrs int) ([]float64, error) {
+	if m.Alpha <= 0 || m.Alpha > 1 {
+		return nil, fmt.Errorf("alpha must be in (0,1], got %v", m.Alpha)
+	}
+
+	if len(h.indx) < 2 {
+		return nil, fmt.Errorf("need at least 2 quarters to smooth growth")
+	}
+
+	s := h.indx[1]/h.indx[0] - 1
+	for j := 2; j < len(h.indx); j++ {
+		s = m.Alpha*(h.indx[j]/h.indx[j-1]-1) + (1-m.Alpha)*s
+	}
+
+	return constant(s, nQtrs), nil
+}
+
+// Growth returns nQtrs quarters of growth reverting from the recent trend of h to the long-run rate.
+func (m MeanReversionModel) Growth(h *HPIseries, nQtrs int) ([]float64, error) {
+	if m.Speed < 0 || m.Speed > 1 {
+		return nil, fmt.Errorf("speed must be in [0,1], got %v", m.Speed)
+	}
+
+	if e := m.Annualization.check(); e != nil {
+		return nil, e
+	}
+
+	g, e := h.trendGrowth(4)
+	if e != nil {
+		return nil, e
+	}
+
+	longRun := m.Annualization.Quarterly(m.LongRun)
+	out := make([]float64, nQtrs)
+	for j := range nQtrs {
+		g = longRun + (1-m.Speed)*(g-longRun)
+		out[j] = g
+	}
+
+	return out, nil
+}
+
+// Forecast returns a new series holding the nQtrs quarters following the end of h as projected by model.
+// The observations are flagged as projected.
+func (h *HPIseries) Forecast(nQtrs int, model ForecastModel) (*HPIseries, error) {
+	if nQtrs <= 0 {
+		return nil, fmt.Errorf("nQtrs must be positive")
+	}
+
+	growth, e := model.Growth(h, nQtrs)
+	if e != nil {
+		return nil, e
+	}
+
+	dt, v := h.dates[len(h.dates)-1], h.indx[len(h.indx)-1]
+
+	var (
+		dts  []int
+		indx []float64
+	)
+	for _, g := range growth {
+		dt = NextQtr(dt)
+		v *= 1 + g
+		dts = append(dts, dt)
+		indx = append(indx, v)
+	}
+
+	f, e := NewHPIseries(h.geoName, h.geoCode, dts, indx)
+	if e != nil {
+		return nil, e
+	}
+
+	f.flag(0, Projected)
+
+	return f, nil
+}
+
+// Extend returns a copy of h with the nQtrs quarters projected by model appended.  As with Append,
+// the new observations are flagged as projected and Last() is unchanged.
+func (h *HPIseries) Extend(nQtrs int, model ForecastModel) (*HPIseries, error) {
+	f, e := h.Forecast(nQtrs, model)
+	if e != nil {
+		return nil, e
+	}
+
+	ext := &HPIseries{
+		geoName:  h.geoName,
+		geoCode:  h.geoCode,
+		dates:    append(append([]int{}, h.dates...), f.dates...),
+		indx:     append(append([]float64{}, h.indx...), f.indx...),
+		lastDt:   h.lastDt,
+		lastIndx: h.lastIndx,
+		extrap:   h.extrap,
+		match:    h.match,
+		prov:     copyFlags(h.prov, 0, len(h.prov)),
+	}
+	ext.flag(len(h.dates), Projected)
+
+	return ext, nil
+}
+
+// trendGrowth returns the average quarterly growth over the last nQtrs quarters of h.
+func (h *HPIseries) trendGrowth(nQtrs int) (float64, error) {
+	n := len(h.indx)
+	if nQtrs < 1 || n <= nQtrs {
+		return 0, fmt.Errorf("need at least %d quarters to compute trend", nQtrs+1)
+	}
+
+	return math.Pow(h.indx[n-1]/h.indx[n-1-nQtrs], 1/float64(nQtrs)) - 1, nil
+}
+
+// constant returns a slice of length n with every element equal to x.
+func constant(x float64, n int) []float64 {
+	out := make([]float64, n)
+	for j := range out {
+		out[j] = x
+	}
+
+	return out
+}
@@ -0,0 +1,52 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_Forecast(t *testing.T) {
+	s := testData().series["TX"]
+	g := testGrowth["TX"]
+	ld, li := s.Last()
+
+	models := []ForecastModel{TrendModel{}, TrendModel{Qtrs: 8}, SmoothingModel{Alpha: 0.3},
+		MeanReversionModel{LongRun: g, Speed: 0.5}}
+	for _, m := range models {
+		f, e := s.Forecast(4, m)
+		assert.Nil(t, e)
+
+		dt, v := f.Last()
+		assert.Equal(t, 4, QtrDiff(ld, dt))
+		assert.InEpsilon(t, li*math.Pow(1+g, 4), v, 1e-9)
+	}
+
+	m := MeanReversionModel{LongRun: 0, Speed: 1}
+	f, e := s.Forecast(2, m)
+	assert.Nil(t, e)
+	_, v := f.Last()
+	assert.InEpsilon(t, li, v, 1e-9)
+
+	_, e = s.Forecast(2, SmoothingModel{Alpha: 0})
+	assert.NotNil(t, e)
+}
+
+func TestHPIseries_Extend(t *testing.T) {
+	s := testData().series["CA"]
+	ld, li := s.Last()
+
+	ext, e := s.Extend(3, TrendModel{})
+	assert.Nil(t, e)
+
+	v, e := ext.Index(NextQtr(NextQtr(NextQtr(ld))))
+	assert.Nil(t, e)
+	assert.InEpsilon(t, li*math.Pow(1+testGrowth["CA"], 3), v, 1e-9)
+
+	// Last is unchanged and the original is untouched
+	dt, _ := ext.Last()
+	assert.Equal(t, ld, dt)
+	_, e = s.Index(NextQtr(ld))
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,247 @@
+package fhfa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FREDAPI is the base address of the FRED API.
+const FREDAPI = "https://api.stlouisfed.org/fred"
+
+// FRED fetches the FHFA all-transactions indices from the FRED API of the Federal Reserve Bank of St. Louis.
+// It is a fallback source for when fhfa.gov is down or blocked.  The series are returned with the same geo
+// levels and geo keys as Load, so the data can be used interchangeably.  Only the us, state, pr and metro
+// levels are on FRED.
+type FRED struct {
+	APIKey  string       // FRED API key
+	BaseURL string       // FREDAPI if blank
+	Client  *http.Client // http.DefaultClient if nil
+}
+
+// NewFRED creates a FRED with the API key apiKey.
+func NewFRED(apiKey string) *FRED {
+	return &FRED{APIKey: apiKey}
+}
+
+// FREDSeriesID returns the FRED series ID of the FHFA all-transactions index for geo at geoLevel, e.g.
+// USSTHPI for us, CASTHPI for state CA and ATNHPIUS10180Q for metro 10180.
+func FREDSeriesID(geoLevel, geo string) (string, error) {
+	switch geoLevel {
+	case "us":
+		return "USSTHPI", nil
+	case "state", "pr":
+		st, e := NormalizeState(geo)
+		if e != nil {
+			return "", e
+		}
+
+		return st + "STHPI", nil
+	case "metro":
+		code := NormalizeGeo(geoLevel, geo)
+		if _, e := strconv.Atoi(code); e != nil || len(code) != 5 {
+			return "", fmt.Errorf("bad CBSA code: %s", geo)
+		}
+
+		return "ATNHPIUS" + code + "Q", nil
+	default:
+		return "", fmt.Errorf("geo level %s is not on FRED", geoLevel)
+	}
+}
+
+// Load returns the data for geos at geoLevel.  If geos is empty, the default set for the level is fetched:
+// the 50 states and DC for state, the CBSAs of the reference table (see CBSAInfo) for metro.  Every geo must
+// be found.
+func (f *FRED) Load(ctx context.Context, geoLevel string, geos ...string) (*HPIdata, error) {
+	if len(geos) == 0 {
+		geos = fredGeos(geoLevel)
+	}
+
+	series := make(map[string]*HPIseries)
+	for _, geo := range geos {
+		s, e := f.Series(ctx, geoLevel, geo)
+		if e != nil {
+			return nil, fmt.Errorf("geo %s: %w", geo, e)
+		}
+
+		series[s.geoCode] = s
+	}
+
+	hd, e := NewHPIdata(geoLevel, series)
+	if e != nil {
+		return nil, e
+	}
+
+	hd.source = f.baseURL()
+
+	return hd, nil
+}
+
+// Series returns the series of geo at geoLevel.
+func (f *FRED) Series(ctx context.Context, geoLevel, geo string) (*HPIseries, error) {
+	id, e := FREDSeriesID(geoLevel, geo)
+	if e != nil {
+		return nil, e
+	}
+
+	dts, indx, e := f.observations(ctx, id)
+	if e != nil {
+		return nil, e
+	}
+
+	code, name := fredGeo(geoLevel, geo)
+
+	return NewHPIseries(name, code, dts, indx)
+}
+
+// observations fetches the quarterly observations of the FRED series id.
+func (f *FRED) observations(ctx context.Context, id string) (dts []int, indx []float64, e error) {
+	q := url.Values{}
+	q.Set("series_id", id)
+	q.Set("api_key", f.APIKey)
+	q.Set("file_type", "json")
+
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet, f.baseURL()+"/series/observations?"+q.Encode(), nil)
+	if e != nil {
+		return nil, nil, e
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	start := time.Now()
+	resp, e := client.Do(req)
+	if e != nil {
+		// drop the address, it includes the API key
+		var ue *url.Error
+		if errors.As(e, &ue) {
+			e = ue.Err
+		}
+
+		e = fmt.Errorf("FRED series %s: %w", id, e)
+		currentMetrics().Downloaded(f.baseURL(), 0, time.Since(start), e)
+
+		return nil, nil, e
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// don't report the address, it includes the API key
+		e = fmt.Errorf("FRED series %s: %s", id, resp.Status)
+		currentMetrics().Downloaded(f.baseURL(), 0, time.Since(start), e)
+
+		return nil, nil, e
+	}
+
+	var body struct {
+		Observations []struct {
+			Date  string `json:"date"`
+			Value string `json:"value"`
+		} `json:"observations"`
+	}
+
+	if e := json.NewDecoder(resp.Body).Decode(&body); e != nil {
+		return nil, nil, fmt.Errorf("FRED series %s: %w", id, e)
+	}
+
+	currentMetrics().Downloaded(f.baseURL(), resp.ContentLength, time.Since(start), nil)
+
+	for _, obs := range body.Observations {
+		// FRED marks missing values with "."
+		v, e := strconv.ParseFloat(obs.Value, 64)
+		if e != nil {
+			continue
+		}
+
+		t, e := time.Parse(time.DateOnly, obs.Date)
+		if e != nil {
+			return nil, nil, fmt.Errorf("FRED series %s: %w", id, e)
+		}
+
+		dts = append(dts, ToYrQtr(t))
+		indx = append(indx, v)
+	}
+
+	if len(dts) == 0 {
+		return nil, nil, fmt.Errorf("no data in FRED series %s", id)
+	}
+
+	return dts, indx, nil
+}
+
+// baseURL returns the address of the API.
+func (f *FRED) baseURL() string {
+	if f.BaseURL != "" {
+		return f.BaseURL
+	}
+
+	return FREDAPI
+}
+
+// LoadOrFRED loads geoLevel from fhfa.gov (see URLs) and, if that fails, from FRED.  The error is returned
+// only if both fail.
+func LoadOrFRED(ctx context.Context, geoLevel string, f *FRED) (*HPIdata, error) {
+	hd, e := Load(URLs(geoLevel))
+	if e == nil {
+		return hd, nil
+	}
+
+	hd, e1 := f.Load(ctx, geoLevel)
+	if e1 != nil {
+		return nil, fmt.Errorf("fhfa.gov: %v; FRED: %w", e, e1)
+	}
+
+	return hd, nil
+}
+
+// fredGeo returns the geo code and name of geo at geoLevel as Load would.
+func fredGeo(geoLevel, geo string) (code, name string) {
+	switch geoLevel {
+	case "us":
+		return "USA", "USA"
+	case "metro":
+		code = NormalizeGeo(geoLevel, geo)
+		name, _ = CBSAName(code)
+
+		return code, name
+	default:
+		code = NormalizeGeo(geoLevel, geo)
+
+		return code, code
+	}
+}
+
+// fredGeos returns the default geos of geoLevel.
+func fredGeos(geoLevel string) []string {
+	switch geoLevel {
+	case "us":
+		return []string{"USA"}
+	case "pr":
+		return []string{"PR"}
+	case "state":
+		statesOnce.Do(loadStates)
+
+		var geos []string
+		for _, s := range states {
+			// territories have FIPS codes of 60 and above
+			if s.fips < "60" && !in(s.postal, geos) {
+				geos = append(geos, s.postal)
+			}
+		}
+		sort.Strings(geos)
+
+		return geos
+	case "metro":
+		return FindCBSA("")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package fhfa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFREDSeriesID(t *testing.T) {
+	for _, c := range []struct {
+		geoLevel, geo, id string
+	}{
+		{"us", "USA", "USSTHPI"},
+		{"state", "California", "CASTHPI"},
+		{"pr", "PR", "PRSTHPI"},
+		{"metro", "10180", "ATNHPIUS10180Q"},
+	} {
+		id, e := FREDSeriesID(c.geoLevel, c.geo)
+		assert.Nil(t, e)
+		assert.Equal(t, c.id, id)
+	}
+
+	_, e := FREDSeriesID("zip3", "100")
+	assert.NotNil(t, e)
+	_, e = FREDSeriesID("metro", "Abilene")
+	assert.NotNil(t, e)
+}
+
+func TestFRED_Load(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/series/observations", r.URL.Path)
+		assert.Equal(t, "key", r.URL.Query().Get("api_key"))
+
+		switch r.URL.Query().Get("series_id") {
+		case "TXSTHPI":
+			fmt.Fprint(w, `{"observations":[{"date":"2020-01-01","value":"100"},{"date":"2020-04-01","value":"."},`+
+				`{"date":"2020-04-01","value":"101"},{"date":"2020-07-01","value":"102.5"}]}`)
+		case "CASTHPI":
+			fmt.Fprint(w, `{"observations":[{"date":"2020-01-01","value":"200"},{"date":"2020-04-01","value":"210"}]}`)
+		default:
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	f := &FRED{APIKey: "key", BaseURL: srv.URL}
+	hd, e := f.Load(context.Background(), "state", "tx", "CA")
+	assert.Nil(t, e)
+	assert.Equal(t, "state", hd.GeoLevel())
+
+	v, e := hd.Index("TX", 20203)
+	assert.Nil(t, e)
+	assert.Equal(t, 102.5, v)
+
+	chg, e := hd.Change("CA", 20201, 20202)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.05, chg, 1e-10)
+
+	_, e = f.Load(context.Background(), "state", "NY")
+	assert.ErrorContains(t, e, "NY")
+	assert.NotContains(t, e.Error(), "key")
+
+	_, e = f.Load(context.Background(), "zip3", "100")
+	assert.NotNil(t, e)
+}
+
+func TestFredGeos(t *testing.T) {
+	geos := fredGeos("state")
+	assert.Len(t, geos, 51)
+	assert.Contains(t, geos, "DC")
+	assert.NotContains(t, geos, "PR")
+	assert.Equal(t, []string{"USA"}, fredGeos("us"))
+}
+
+func TestFRED_Unreachable(t *testing.T) {
+	m := &testMetrics{released: make(map[string]int)}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	f := &FRED{APIKey: "SECRETKEY", BaseURL: srv.URL}
+	_, e := f.Load(context.Background(), "state", "TX")
+	assert.ErrorContains(t, e, "TXSTHPI")
+	assert.NotContains(t, e.Error(), "SECRETKEY")
+	assert.Equal(t, 1, m.dlErrs)
+}
@@ -0,0 +1,124 @@
+package fhfa
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Boundaries holds the geometries of geos (e.g. states or CBSAs) from a GeoJSON FeatureCollection, keyed by geo.
+type Boundaries struct {
+	geoLevel string
+	keys     []string                   // geos in file order
+	shapes   map[string]json.RawMessage // GeoJSON geometry of each geo
+}
+
+// LoadBoundaries loads the boundaries of geos at geoLevel from a GeoJSON FeatureCollection in localFile, such as the
+// Census Bureau cartographic boundary files converted to GeoJSON.  The geo of each feature is the value of its
+// property keyProp (e.g. STUSPS for states, CBSAFP for CBSAs), normalized by NormalizeGeo.
+func LoadBoundaries(localFile, geoLevel, keyProp string) (*Boundaries, error) {
+	b, e := os.ReadFile(localFile)
+	if e != nil {
+		return nil, e
+	}
+
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry   json.RawMessage `json:"geometry"`
+			Properties map[string]any  `json:"properties"`
+		} `json:"features"`
+	}
+
+	if e := json.Unmarshal(b, &fc); e != nil {
+		return nil, e
+	}
+
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("%s is not a GeoJSON FeatureCollection", localFile)
+	}
+
+	bd := &Boundaries{geoLevel: geoLevel, shapes: make(map[string]json.RawMessage)}
+	for _, f := range fc.Features {
+		k, ok := f.Properties[keyProp]
+		if !ok {
+			return nil, fmt.Errorf("feature without property %s in %s", keyProp, localFile)
+		}
+
+		geo := NormalizeGeo(geoLevel, fmt.Sprint(k))
+		if _, ok := bd.shapes[geo]; !ok {
+			bd.keys = append(bd.keys, geo)
+		}
+
+		bd.shapes[geo] = f.Geometry
+	}
+
+	return bd, nil
+}
+
+// geoFeature is a GeoJSON feature written by ToGeoJSON.
+type geoFeature struct {
+	Type       string          `json:"type"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Properties geoProperties   `json:"properties"`
+}
+
+// geoProperties are the properties of a geoFeature.  Change and Appreciation are null if the geo has
+// no data for the range.
+type geoProperties struct {
+	Geo          string   `json:"geo"`
+	Name         string   `json:"name"`
+	DtStart      int      `json:"dtStart"`
+	DtEnd        int      `json:"dtEnd"`
+	Change       *float64 `json:"change"`       // ratio of the index at DtEnd to DtStart
+	Appreciation *float64 `json:"appreciation"` // Change - 1
+}
+
+// ToGeoJSON returns a GeoJSON FeatureCollection with a feature per geo whose properties are the change in the
+// index from dtStart (CCYYQ) to dtEnd (CCYYQ), ready for a choropleth map.  If bd is nil, there is a feature
+// for each geo in hd, in geo order, with a null geometry so the values can be joined to shapes by the client.
+// Otherwise, there is a feature for each geo of bd, in the order of its file, and geos of bd without data
+// have null values.
+func (hd *HPIdata) ToGeoJSON(dtStart, dtEnd int, bd *Boundaries) ([]byte, error) {
+	geos := hd.Geos()
+	sort.Strings(geos)
+
+	if bd != nil {
+		if bd.geoLevel != hd.geoLevel {
+			return nil, fmt.Errorf("boundaries are for %s, data is %s", bd.geoLevel, hd.geoLevel)
+		}
+
+		geos = bd.keys
+	}
+
+	null := json.RawMessage("null")
+	fc := struct {
+		Type     string       `json:"type"`
+		Features []geoFeature `json:"features"`
+	}{Type: "FeatureCollection", Features: []geoFeature{}}
+
+	for _, geo := range geos {
+		f := geoFeature{
+			Type:       "Feature",
+			Geometry:   null,
+			Properties: geoProperties{Geo: geo, DtStart: dtStart, DtEnd: dtEnd},
+		}
+
+		if bd != nil && len(bd.shapes[geo]) > 0 {
+			f.Geometry = bd.shapes[geo]
+		}
+
+		if s, ok := hd.series[geo]; ok {
+			f.Properties.Name = s.geoName
+			if r, e := s.Change(dtStart, dtEnd); e == nil {
+				apr := r - 1
+				f.Properties.Change, f.Properties.Appreciation = &r, &apr
+			}
+		}
+
+		fc.Features = append(fc.Features, f)
+	}
+
+	return json.Marshal(fc)
+}
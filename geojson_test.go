@@ -0,0 +1,56 @@
+package fhfa
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_ToGeoJSON(t *testing.T) {
+	hd := testData()
+
+	b, e := hd.ToGeoJSON(20001, 20011, nil)
+	assert.Nil(t, e)
+
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry   any            `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+
+	assert.Nil(t, json.Unmarshal(b, &fc))
+	assert.Equal(t, "FeatureCollection", fc.Type)
+	assert.Len(t, fc.Features, 3)
+	assert.Equal(t, "CA", fc.Features[0].Properties["geo"])
+	assert.Nil(t, fc.Features[0].Geometry)
+	assert.InEpsilon(t, 1.02*1.02*1.02*1.02, fc.Features[0].Properties["change"], 1e-10)
+
+	shapes := `{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{"STUSPS":"TX"},"geometry":{"type":"Point","coordinates":[-99.9,31.9]}},
+{"type":"Feature","properties":{"STUSPS":"FL"},"geometry":{"type":"Point","coordinates":[-81.5,27.7]}}]}`
+	file := filepath.Join(t.TempDir(), "states.geojson")
+	assert.Nil(t, os.WriteFile(file, []byte(shapes), 0o644))
+
+	bd, e := LoadBoundaries(file, "state", "STUSPS")
+	assert.Nil(t, e)
+
+	b, e = hd.ToGeoJSON(20001, 20011, bd)
+	assert.Nil(t, e)
+	assert.Nil(t, json.Unmarshal(b, &fc))
+	assert.Len(t, fc.Features, 2)
+	assert.Equal(t, "TX", fc.Features[0].Properties["geo"])
+	assert.NotNil(t, fc.Features[0].Geometry)
+	assert.Nil(t, fc.Features[1].Properties["change"])
+
+	_, e = LoadBoundaries(file, "state", "NAME")
+	assert.NotNil(t, e)
+
+	bd.geoLevel = "metro"
+	_, e = hd.ToGeoJSON(20001, 20011, bd)
+	assert.NotNil(t, e)
+}
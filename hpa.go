@@ -0,0 +1,173 @@
+package fhfa
+
+import (
+	"fmt"
+	"slices"
+)
+
+// HPAseries holds quarterly house price appreciation (HPA): the growth of the index into each quarter, so 0.01
+// at 20231 is 1% growth from 20224 to 20231.  Use it for models that consume growth rates rather than levels.
+type HPAseries struct {
+	geoName string
+	geoCode string
+	dates   []int // contiguous quarters (CCYYQ)
+	hpa     []float64
+}
+
+// NewHPAseries creates an HPAseries.  dates (CCYYQ) must be contiguous quarters.
+func NewHPAseries(geoName, geoCode string, dates []int, hpa []float64) (*HPAseries, error) {
+	if len(dates) == 0 || len(dates) != len(hpa) {
+		return nil, fmt.Errorf("dates and hpa don't agree")
+	}
+
+	if !QtrsOK(dates) {
+		return nil, ErrFrequencyMismatch
+	}
+
+	return &HPAseries{geoName: geoName, geoCode: geoCode, dates: slices.Clone(dates), hpa: slices.Clone(hpa)}, nil
+}
+
+// HPA returns the quarterly appreciation of h, starting with the growth into its second quarter.  h must have
+// at least two quarters and no gaps (see FillGaps).
+func (h *HPIseries) HPA() (*HPAseries, error) {
+	if len(h.dates) < 2 {
+		return nil, fmt.Errorf("need at least 2 quarters for HPA")
+	}
+
+	if !QtrsOK(h.dates) {
+		return nil, ErrFrequencyMismatch
+	}
+
+	a := &HPAseries{geoName: h.geoName, geoCode: h.geoCode, dates: slices.Clone(h.dates[1:])}
+	for j := 1; j < len(h.indx); j++ {
+		a.hpa = append(a.hpa, h.indx[j]/h.indx[j-1]-1)
+	}
+
+	return a, nil
+}
+
+// HPI converts a back to an index with value base at baseDt (CCYYQ), which may be any quarter of a or the one
+// before it.
+func (a *HPAseries) HPI(baseDt int, base float64) (*HPIseries, error) {
+	if !YrQtr(baseDt).Valid() {
+		return nil, badDate(baseDt)
+	}
+
+	first := PrevQtr(a.dates[0])
+	if baseDt < first || baseDt > a.dates[len(a.dates)-1] {
+		return nil, &DateRangeError{Dt: baseDt, First: first, Last: a.dates[len(a.dates)-1]}
+	}
+
+	dates := append([]int{first}, a.dates...)
+	indx := []float64{1}
+	for j, g := range a.hpa {
+		indx = append(indx, indx[j]*(1+g))
+	}
+
+	scale := base / indx[QtrDiff(first, baseDt)]
+	for j := range indx {
+		indx[j] *= scale
+	}
+
+	return NewHPIseries(a.geoName, a.geoCode, dates, indx)
+}
+
+// Append appends the appreciation (dts, hpa) to a.  dts must continue a without a gap or overlap.
+func (a *HPAseries) Append(dts []int, hpa []float64) error {
+	if len(dts) == 0 || len(dts) != len(hpa) {
+		return fmt.Errorf("dates and hpa don't agree")
+	}
+
+	if !QtrsOK(dts) {
+		return ErrFrequencyMismatch
+	}
+
+	if end := a.dates[len(a.dates)-1]; dts[0] != NextQtr(end) {
+		return fmt.Errorf("new data starts at %d, which doesn't follow the end of the series, %d", dts[0], end)
+	}
+
+	a.dates = append(a.dates, dts...)
+	a.hpa = append(a.hpa, hpa...)
+
+	return nil
+}
+
+// Rate returns the appreciation into quarter dt (CCYYQ).
+func (a *HPAseries) Rate(dt int) (float64, error) {
+	j, e := a.position(dt)
+	if e != nil {
+		return 0, e
+	}
+
+	return a.hpa[j], nil
+}
+
+// Change returns the cumulative appreciation ratio from dtStart to dtEnd (CCYYQ), the ratio an HPIseries
+// would give.  dtStart may be the quarter before the first of a.
+func (a *HPAseries) Change(dtStart, dtEnd int) (float64, error) {
+	for _, dt := range []int{dtStart, dtEnd} {
+		if !YrQtr(dt).Valid() {
+			return 0, badDate(dt)
+		}
+	}
+
+	if dtEnd < dtStart {
+		return 0, fmt.Errorf("dtEnd %d precedes dtStart %d", dtEnd, dtStart)
+	}
+
+	first, last := PrevQtr(a.dates[0]), a.dates[len(a.dates)-1]
+	if dtStart < first {
+		return 0, &DateRangeError{Dt: dtStart, First: first, Last: last}
+	}
+
+	if dtEnd > last {
+		return 0, &DateRangeError{Dt: dtEnd, First: first, Last: last}
+	}
+
+	// hpa[j] is the growth into the quarter j+1 after first
+	ratio := 1.0
+	for j := QtrDiff(first, dtStart); j < QtrDiff(first, dtEnd); j++ {
+		ratio *= 1 + a.hpa[j]
+	}
+
+	return ratio, nil
+}
+
+// Copy returns a copy of a.
+func (a *HPAseries) Copy() *HPAseries {
+	return &HPAseries{geoName: a.geoName, geoCode: a.geoCode, dates: slices.Clone(a.dates), hpa: slices.Clone(a.hpa)}
+}
+
+// Data returns copies of the dates (CCYYQ) and rates of a.
+func (a *HPAseries) Data() (dts []int, hpa []float64) {
+	return slices.Clone(a.dates), slices.Clone(a.hpa)
+}
+
+// Geo returns the geo code of a.
+func (a *HPAseries) Geo() string {
+	return a.geoCode
+}
+
+// Len returns the number of quarters in a.
+func (a *HPAseries) Len() int {
+	return len(a.dates)
+}
+
+// Name returns the geo name of a.
+func (a *HPAseries) Name() string {
+	return a.geoName
+}
+
+// position returns the position of dt in a.
+func (a *HPAseries) position(dt int) (int, error) {
+	if !YrQtr(dt).Valid() {
+		return 0, badDate(dt)
+	}
+
+	first, last := a.dates[0], a.dates[len(a.dates)-1]
+	if dt < first || dt > last {
+		return 0, &DateRangeError{Dt: dt, First: first, Last: last}
+	}
+
+	return QtrDiff(first, dt), nil
+}
@@ -0,0 +1,70 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_HPA(t *testing.T) {
+	hd := testData()
+	s, _ := hd.Geo("CA")
+
+	a, e := s.HPA()
+	assert.Nil(t, e)
+	assert.Equal(t, 39, a.Len())
+	assert.Equal(t, "CA", a.Geo())
+
+	r, e := a.Rate(20002)
+	assert.Nil(t, e)
+	assert.InDelta(t, 0.02, r, 1e-12)
+
+	_, e = a.Rate(20001)
+	assert.NotNil(t, e)
+
+	// Change agrees with the index
+	want, _ := s.Change(20001, 20054)
+	got, e := a.Change(20001, 20054)
+	assert.Nil(t, e)
+	assert.InDelta(t, want, got, 1e-12)
+
+	got, e = a.Change(20033, 20033)
+	assert.Nil(t, e)
+	assert.Equal(t, 1.0, got)
+
+	_, e = a.Change(20054, 20001)
+	assert.NotNil(t, e)
+	_, e = a.Change(20001, 20101)
+	assert.NotNil(t, e)
+
+	// round trip
+	h, e := a.HPI(20001, 100)
+	assert.Nil(t, e)
+	ok, diff := h.Equal(s, 1e-9)
+	assert.True(t, ok, diff)
+	v, _ := h.Index(20094)
+	w, _ := s.Index(20094)
+	assert.InDelta(t, w, v, 1e-9)
+
+	h, e = a.HPI(20054, 1)
+	assert.Nil(t, e)
+	v, _ = h.Index(20054)
+	assert.InDelta(t, 1, v, 1e-12)
+
+	_, e = a.HPI(19994, 1)
+	assert.NotNil(t, e)
+	_, e = a.HPI(20009, 1)
+	assert.ErrorIs(t, e, ErrBadDate)
+
+	assert.Nil(t, a.Append([]int{20101, 20102}, []float64{0.01, 0.01}))
+	assert.Equal(t, 41, a.Len())
+	assert.NotNil(t, a.Append([]int{20104}, []float64{0.01}))
+	assert.NotNil(t, a.Append([]int{20103, 20104}, []float64{0.01}))
+
+	c := a.Copy()
+	assert.Nil(t, c.Append([]int{20103}, []float64{0.01}))
+	assert.Equal(t, 41, a.Len())
+
+	_, e = NewHPAseries("x", "x", []int{20001, 20003}, []float64{0, 0})
+	assert.ErrorIs(t, e, ErrFrequencyMismatch)
+}
@@ -0,0 +1,105 @@
+package hpigrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+)
+
+// Client queries an HPI service.
+type Client struct {
+	c HPIClient
+}
+
+// NewClient returns a Client using conn (e.g. from grpc.NewClient).
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{c: NewHPIClient(conn)}
+}
+
+// Index returns the index for geo at level at dt (CCYYQ).
+func (c *Client) Index(ctx context.Context, level, geo string, dt int) (float64, error) {
+	reply, e := c.c.Index(ctx, &IndexRequest{Level: level, Geo: geo, Dt: int32(dt)})
+	if e != nil {
+		return 0, e
+	}
+
+	return reply.GetIndex(), nil
+}
+
+// Change returns the ratio of the index for geo at level at dtEnd to dtStart (CCYYQ).
+func (c *Client) Change(ctx context.Context, level, geo string, dtStart, dtEnd int) (float64, error) {
+	reply, e := c.c.Change(ctx, &ChangeRequest{Level: level, Geo: geo, Start: int32(dtStart), End: int32(dtEnd)})
+	if e != nil {
+		return 0, e
+	}
+
+	return reply.GetChange(), nil
+}
+
+// BatchIndex streams reqs to the service and returns the index for each.  errs is nil if every lookup
+// succeeds, otherwise errs[i] is the error for the ith request.  The error return is for failures of
+// the stream itself.
+func (c *Client) BatchIndex(ctx context.Context, reqs []*IndexRequest) (hpis []float64, errs []error, e error) {
+	// cancelling ctx on return unblocks the sender if we stop receiving early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, e := c.c.BatchIndex(ctx)
+	if e != nil {
+		return nil, nil, e
+	}
+
+	// send while receiving so neither side's buffers fill
+	sendErr, sendDone := make(chan error, 1), make(chan struct{})
+	defer func() {
+		cancel()
+		<-sendDone
+	}()
+
+	go func() {
+		defer close(sendDone)
+
+		for _, req := range reqs {
+			if e := stream.Send(req); e != nil {
+				sendErr <- e
+				return
+			}
+		}
+
+		sendErr <- stream.CloseSend()
+	}()
+
+	hpis = make([]float64, len(reqs))
+	errs = make([]error, len(reqs))
+	bad := false
+	for j := range reqs {
+		reply, e := stream.Recv()
+		if e != nil {
+			if errors.Is(e, io.EOF) {
+				e = fmt.Errorf("stream ended after %d of %d replies", j, len(reqs))
+			}
+
+			return nil, nil, e
+		}
+
+		if reply.GetError() != "" {
+			errs[j], bad = errors.New(reply.GetError()), true
+			continue
+		}
+
+		hpis[j] = reply.GetIndex()
+	}
+
+	if e := <-sendErr; e != nil {
+		return nil, nil, e
+	}
+
+	if !bad {
+		errs = nil
+	}
+
+	return hpis, errs, nil
+}
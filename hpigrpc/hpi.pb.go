@@ -0,0 +1,605 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: hpi.proto
+
+package hpigrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // geo level (zip3, metro, nonmetro, state, us, pr, mh)
+	Geo           string                 `protobuf:"bytes,2,opt,name=geo,proto3" json:"geo,omitempty"`
+	Dt            int32                  `protobuf:"varint,3,opt,name=dt,proto3" json:"dt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexRequest) Reset() {
+	*x = IndexRequest{}
+	mi := &file_hpi_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexRequest) ProtoMessage() {}
+
+func (x *IndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hpi_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexRequest.ProtoReflect.Descriptor instead.
+func (*IndexRequest) Descriptor() ([]byte, []int) {
+	return file_hpi_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IndexRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *IndexRequest) GetGeo() string {
+	if x != nil {
+		return x.Geo
+	}
+	return ""
+}
+
+func (x *IndexRequest) GetDt() int32 {
+	if x != nil {
+		return x.Dt
+	}
+	return 0
+}
+
+type IndexReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Geo           string                 `protobuf:"bytes,2,opt,name=geo,proto3" json:"geo,omitempty"`
+	Dt            int32                  `protobuf:"varint,3,opt,name=dt,proto3" json:"dt,omitempty"`
+	Index         float64                `protobuf:"fixed64,4,opt,name=index,proto3" json:"index,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"` // set by BatchIndex if the lookup failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexReply) Reset() {
+	*x = IndexReply{}
+	mi := &file_hpi_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexReply) ProtoMessage() {}
+
+func (x *IndexReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hpi_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexReply.ProtoReflect.Descriptor instead.
+func (*IndexReply) Descriptor() ([]byte, []int) {
+	return file_hpi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IndexReply) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *IndexReply) GetGeo() string {
+	if x != nil {
+		return x.Geo
+	}
+	return ""
+}
+
+func (x *IndexReply) GetDt() int32 {
+	if x != nil {
+		return x.Dt
+	}
+	return 0
+}
+
+func (x *IndexReply) GetIndex() float64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IndexReply) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Geo           string                 `protobuf:"bytes,2,opt,name=geo,proto3" json:"geo,omitempty"`
+	Start         int32                  `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeRequest) Reset() {
+	*x = ChangeRequest{}
+	mi := &file_hpi_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRequest) ProtoMessage() {}
+
+func (x *ChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hpi_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRequest.ProtoReflect.Descriptor instead.
+func (*ChangeRequest) Descriptor() ([]byte, []int) {
+	return file_hpi_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChangeRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetGeo() string {
+	if x != nil {
+		return x.Geo
+	}
+	return ""
+}
+
+func (x *ChangeRequest) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ChangeRequest) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type ChangeReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Change        float64                `protobuf:"fixed64,1,opt,name=change,proto3" json:"change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeReply) Reset() {
+	*x = ChangeReply{}
+	mi := &file_hpi_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeReply) ProtoMessage() {}
+
+func (x *ChangeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hpi_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeReply.ProtoReflect.Descriptor instead.
+func (*ChangeReply) Descriptor() ([]byte, []int) {
+	return file_hpi_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChangeReply) GetChange() float64 {
+	if x != nil {
+		return x.Change
+	}
+	return 0
+}
+
+type SeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Geo           string                 `protobuf:"bytes,2,opt,name=geo,proto3" json:"geo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesRequest) Reset() {
+	*x = SeriesRequest{}
+	mi := &file_hpi_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesRequest) ProtoMessage() {}
+
+func (x *SeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hpi_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesRequest.ProtoReflect.Descriptor instead.
+func (*SeriesRequest) Descriptor() ([]byte, []int) {
+	return file_hpi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SeriesRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SeriesRequest) GetGeo() string {
+	if x != nil {
+		return x.Geo
+	}
+	return ""
+}
+
+type SeriesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Geo           string                 `protobuf:"bytes,2,opt,name=geo,proto3" json:"geo,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	LastLoaded    int32                  `protobuf:"varint,4,opt,name=last_loaded,json=lastLoaded,proto3" json:"last_loaded,omitempty"`
+	Dates         []int32                `protobuf:"varint,5,rep,packed,name=dates,proto3" json:"dates,omitempty"`
+	Index         []float64              `protobuf:"fixed64,6,rep,packed,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesReply) Reset() {
+	*x = SeriesReply{}
+	mi := &file_hpi_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesReply) ProtoMessage() {}
+
+func (x *SeriesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hpi_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesReply.ProtoReflect.Descriptor instead.
+func (*SeriesReply) Descriptor() ([]byte, []int) {
+	return file_hpi_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SeriesReply) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SeriesReply) GetGeo() string {
+	if x != nil {
+		return x.Geo
+	}
+	return ""
+}
+
+func (x *SeriesReply) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SeriesReply) GetLastLoaded() int32 {
+	if x != nil {
+		return x.LastLoaded
+	}
+	return 0
+}
+
+func (x *SeriesReply) GetDates() []int32 {
+	if x != nil {
+		return x.Dates
+	}
+	return nil
+}
+
+func (x *SeriesReply) GetIndex() []float64 {
+	if x != nil {
+		return x.Index
+	}
+	return nil
+}
+
+type GeosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeosRequest) Reset() {
+	*x = GeosRequest{}
+	mi := &file_hpi_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeosRequest) ProtoMessage() {}
+
+func (x *GeosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hpi_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeosRequest.ProtoReflect.Descriptor instead.
+func (*GeosRequest) Descriptor() ([]byte, []int) {
+	return file_hpi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GeosRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type GeosReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Geos          []string               `protobuf:"bytes,1,rep,name=geos,proto3" json:"geos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeosReply) Reset() {
+	*x = GeosReply{}
+	mi := &file_hpi_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeosReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeosReply) ProtoMessage() {}
+
+func (x *GeosReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hpi_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeosReply.ProtoReflect.Descriptor instead.
+func (*GeosReply) Descriptor() ([]byte, []int) {
+	return file_hpi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GeosReply) GetGeos() []string {
+	if x != nil {
+		return x.Geos
+	}
+	return nil
+}
+
+var File_hpi_proto protoreflect.FileDescriptor
+
+const file_hpi_proto_rawDesc = "" +
+	"\n" +
+	"\thpi.proto\x12\vfhfa.hpi.v1\"F\n" +
+	"\fIndexRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x10\n" +
+	"\x03geo\x18\x02 \x01(\tR\x03geo\x12\x0e\n" +
+	"\x02dt\x18\x03 \x01(\x05R\x02dt\"p\n" +
+	"\n" +
+	"IndexReply\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x10\n" +
+	"\x03geo\x18\x02 \x01(\tR\x03geo\x12\x0e\n" +
+	"\x02dt\x18\x03 \x01(\x05R\x02dt\x12\x14\n" +
+	"\x05index\x18\x04 \x01(\x01R\x05index\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"_\n" +
+	"\rChangeRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x10\n" +
+	"\x03geo\x18\x02 \x01(\tR\x03geo\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\x05R\x03end\"%\n" +
+	"\vChangeReply\x12\x16\n" +
+	"\x06change\x18\x01 \x01(\x01R\x06change\"7\n" +
+	"\rSeriesRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x10\n" +
+	"\x03geo\x18\x02 \x01(\tR\x03geo\"\x96\x01\n" +
+	"\vSeriesReply\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x10\n" +
+	"\x03geo\x18\x02 \x01(\tR\x03geo\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vlast_loaded\x18\x04 \x01(\x05R\n" +
+	"lastLoaded\x12\x14\n" +
+	"\x05dates\x18\x05 \x03(\x05R\x05dates\x12\x14\n" +
+	"\x05index\x18\x06 \x03(\x01R\x05index\"#\n" +
+	"\vGeosRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\"\x1f\n" +
+	"\tGeosReply\x12\x12\n" +
+	"\x04geos\x18\x01 \x03(\tR\x04geos2\xc2\x02\n" +
+	"\x03HPI\x12;\n" +
+	"\x05Index\x12\x19.fhfa.hpi.v1.IndexRequest\x1a\x17.fhfa.hpi.v1.IndexReply\x12>\n" +
+	"\x06Change\x12\x1a.fhfa.hpi.v1.ChangeRequest\x1a\x18.fhfa.hpi.v1.ChangeReply\x12>\n" +
+	"\x06Series\x12\x1a.fhfa.hpi.v1.SeriesRequest\x1a\x18.fhfa.hpi.v1.SeriesReply\x128\n" +
+	"\x04Geos\x12\x18.fhfa.hpi.v1.GeosRequest\x1a\x16.fhfa.hpi.v1.GeosReply\x12D\n" +
+	"\n" +
+	"BatchIndex\x12\x19.fhfa.hpi.v1.IndexRequest\x1a\x17.fhfa.hpi.v1.IndexReply(\x010\x01B#Z!github.com/invertedv/fhfa/hpigrpcb\x06proto3"
+
+var (
+	file_hpi_proto_rawDescOnce sync.Once
+	file_hpi_proto_rawDescData []byte
+)
+
+func file_hpi_proto_rawDescGZIP() []byte {
+	file_hpi_proto_rawDescOnce.Do(func() {
+		file_hpi_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hpi_proto_rawDesc), len(file_hpi_proto_rawDesc)))
+	})
+	return file_hpi_proto_rawDescData
+}
+
+var file_hpi_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_hpi_proto_goTypes = []any{
+	(*IndexRequest)(nil),  // 0: fhfa.hpi.v1.IndexRequest
+	(*IndexReply)(nil),    // 1: fhfa.hpi.v1.IndexReply
+	(*ChangeRequest)(nil), // 2: fhfa.hpi.v1.ChangeRequest
+	(*ChangeReply)(nil),   // 3: fhfa.hpi.v1.ChangeReply
+	(*SeriesRequest)(nil), // 4: fhfa.hpi.v1.SeriesRequest
+	(*SeriesReply)(nil),   // 5: fhfa.hpi.v1.SeriesReply
+	(*GeosRequest)(nil),   // 6: fhfa.hpi.v1.GeosRequest
+	(*GeosReply)(nil),     // 7: fhfa.hpi.v1.GeosReply
+}
+var file_hpi_proto_depIdxs = []int32{
+	0, // 0: fhfa.hpi.v1.HPI.Index:input_type -> fhfa.hpi.v1.IndexRequest
+	2, // 1: fhfa.hpi.v1.HPI.Change:input_type -> fhfa.hpi.v1.ChangeRequest
+	4, // 2: fhfa.hpi.v1.HPI.Series:input_type -> fhfa.hpi.v1.SeriesRequest
+	6, // 3: fhfa.hpi.v1.HPI.Geos:input_type -> fhfa.hpi.v1.GeosRequest
+	0, // 4: fhfa.hpi.v1.HPI.BatchIndex:input_type -> fhfa.hpi.v1.IndexRequest
+	1, // 5: fhfa.hpi.v1.HPI.Index:output_type -> fhfa.hpi.v1.IndexReply
+	3, // 6: fhfa.hpi.v1.HPI.Change:output_type -> fhfa.hpi.v1.ChangeReply
+	5, // 7: fhfa.hpi.v1.HPI.Series:output_type -> fhfa.hpi.v1.SeriesReply
+	7, // 8: fhfa.hpi.v1.HPI.Geos:output_type -> fhfa.hpi.v1.GeosReply
+	1, // 9: fhfa.hpi.v1.HPI.BatchIndex:output_type -> fhfa.hpi.v1.IndexReply
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_hpi_proto_init() }
+func file_hpi_proto_init() {
+	if File_hpi_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hpi_proto_rawDesc), len(file_hpi_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hpi_proto_goTypes,
+		DependencyIndexes: file_hpi_proto_depIdxs,
+		MessageInfos:      file_hpi_proto_msgTypes,
+	}.Build()
+	File_hpi_proto = out.File
+	file_hpi_proto_goTypes = nil
+	file_hpi_proto_depIdxs = nil
+}
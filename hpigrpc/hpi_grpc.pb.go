@@ -0,0 +1,284 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: hpi.proto
+
+package hpigrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HPI_Index_FullMethodName      = "/fhfa.hpi.v1.HPI/Index"
+	HPI_Change_FullMethodName     = "/fhfa.hpi.v1.HPI/Change"
+	HPI_Series_FullMethodName     = "/fhfa.hpi.v1.HPI/Series"
+	HPI_Geos_FullMethodName       = "/fhfa.hpi.v1.HPI/Geos"
+	HPI_BatchIndex_FullMethodName = "/fhfa.hpi.v1.HPI/BatchIndex"
+)
+
+// HPIClient is the client API for HPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HPI answers FHFA house price index lookups.  Dates are CCYYQ (e.g. 20231).
+type HPIClient interface {
+	// Index returns the index for a geo at a date.
+	Index(ctx context.Context, in *IndexRequest, opts ...grpc.CallOption) (*IndexReply, error)
+	// Change returns the ratio of the index at end to start.
+	Change(ctx context.Context, in *ChangeRequest, opts ...grpc.CallOption) (*ChangeReply, error)
+	// Series returns the full series for a geo.
+	Series(ctx context.Context, in *SeriesRequest, opts ...grpc.CallOption) (*SeriesReply, error)
+	// Geos returns the geos available at a level.
+	Geos(ctx context.Context, in *GeosRequest, opts ...grpc.CallOption) (*GeosReply, error)
+	// BatchIndex answers a stream of index lookups.  Replies are sent in request order; a failed lookup
+	// sets error rather than ending the stream.
+	BatchIndex(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[IndexRequest, IndexReply], error)
+}
+
+type hPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHPIClient(cc grpc.ClientConnInterface) HPIClient {
+	return &hPIClient{cc}
+}
+
+func (c *hPIClient) Index(ctx context.Context, in *IndexRequest, opts ...grpc.CallOption) (*IndexReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IndexReply)
+	err := c.cc.Invoke(ctx, HPI_Index_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hPIClient) Change(ctx context.Context, in *ChangeRequest, opts ...grpc.CallOption) (*ChangeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeReply)
+	err := c.cc.Invoke(ctx, HPI_Change_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hPIClient) Series(ctx context.Context, in *SeriesRequest, opts ...grpc.CallOption) (*SeriesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeriesReply)
+	err := c.cc.Invoke(ctx, HPI_Series_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hPIClient) Geos(ctx context.Context, in *GeosRequest, opts ...grpc.CallOption) (*GeosReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeosReply)
+	err := c.cc.Invoke(ctx, HPI_Geos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hPIClient) BatchIndex(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[IndexRequest, IndexReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HPI_ServiceDesc.Streams[0], HPI_BatchIndex_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IndexRequest, IndexReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HPI_BatchIndexClient = grpc.BidiStreamingClient[IndexRequest, IndexReply]
+
+// HPIServer is the server API for HPI service.
+// All implementations must embed UnimplementedHPIServer
+// for forward compatibility.
+//
+// HPI answers FHFA house price index lookups.  Dates are CCYYQ (e.g. 20231).
+type HPIServer interface {
+	// Index returns the index for a geo at a date.
+	Index(context.Context, *IndexRequest) (*IndexReply, error)
+	// Change returns the ratio of the index at end to start.
+	Change(context.Context, *ChangeRequest) (*ChangeReply, error)
+	// Series returns the full series for a geo.
+	Series(context.Context, *SeriesRequest) (*SeriesReply, error)
+	// Geos returns the geos available at a level.
+	Geos(context.Context, *GeosRequest) (*GeosReply, error)
+	// BatchIndex answers a stream of index lookups.  Replies are sent in request order; a failed lookup
+	// sets error rather than ending the stream.
+	BatchIndex(grpc.BidiStreamingServer[IndexRequest, IndexReply]) error
+	mustEmbedUnimplementedHPIServer()
+}
+
+// UnimplementedHPIServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHPIServer struct{}
+
+func (UnimplementedHPIServer) Index(context.Context, *IndexRequest) (*IndexReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Index not implemented")
+}
+func (UnimplementedHPIServer) Change(context.Context, *ChangeRequest) (*ChangeReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Change not implemented")
+}
+func (UnimplementedHPIServer) Series(context.Context, *SeriesRequest) (*SeriesReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Series not implemented")
+}
+func (UnimplementedHPIServer) Geos(context.Context, *GeosRequest) (*GeosReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Geos not implemented")
+}
+func (UnimplementedHPIServer) BatchIndex(grpc.BidiStreamingServer[IndexRequest, IndexReply]) error {
+	return status.Error(codes.Unimplemented, "method BatchIndex not implemented")
+}
+func (UnimplementedHPIServer) mustEmbedUnimplementedHPIServer() {}
+func (UnimplementedHPIServer) testEmbeddedByValue()             {}
+
+// UnsafeHPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HPIServer will
+// result in compilation errors.
+type UnsafeHPIServer interface {
+	mustEmbedUnimplementedHPIServer()
+}
+
+func RegisterHPIServer(s grpc.ServiceRegistrar, srv HPIServer) {
+	// If the following call panics, it indicates UnimplementedHPIServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HPI_ServiceDesc, srv)
+}
+
+func _HPI_Index_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HPIServer).Index(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HPI_Index_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HPIServer).Index(ctx, req.(*IndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HPI_Change_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HPIServer).Change(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HPI_Change_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HPIServer).Change(ctx, req.(*ChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HPI_Series_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HPIServer).Series(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HPI_Series_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HPIServer).Series(ctx, req.(*SeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HPI_Geos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HPIServer).Geos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HPI_Geos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HPIServer).Geos(ctx, req.(*GeosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HPI_BatchIndex_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HPIServer).BatchIndex(&grpc.GenericServerStream[IndexRequest, IndexReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HPI_BatchIndexServer = grpc.BidiStreamingServer[IndexRequest, IndexReply]
+
+// HPI_ServiceDesc is the grpc.ServiceDesc for HPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fhfa.hpi.v1.HPI",
+	HandlerType: (*HPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Index",
+			Handler:    _HPI_Index_Handler,
+		},
+		{
+			MethodName: "Change",
+			Handler:    _HPI_Change_Handler,
+		},
+		{
+			MethodName: "Series",
+			Handler:    _HPI_Series_Handler,
+		},
+		{
+			MethodName: "Geos",
+			Handler:    _HPI_Geos_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchIndex",
+			Handler:       _HPI_BatchIndex_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "hpi.proto",
+}
@@ -0,0 +1,138 @@
+// Package hpigrpc serves FHFA house price index lookups over gRPC.  The messages and service are defined in
+// hpi.proto; Server implements the service from an fhfa.Manager and Client wraps the generated client.
+package hpigrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative hpi.proto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/invertedv/fhfa"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements HPIServer from the data held by a Manager.
+type Server struct {
+	UnimplementedHPIServer
+
+	m *fhfa.Manager
+}
+
+// NewServer returns a Server answering queries from m.  Register it with RegisterHPIServer.
+func NewServer(m *fhfa.Manager) *Server {
+	return &Server{m: m}
+}
+
+// Index returns the index for a geo at a date.
+func (s *Server) Index(ctx context.Context, req *IndexRequest) (*IndexReply, error) {
+	hd, e := s.data(req.GetLevel())
+	if e != nil {
+		return nil, e
+	}
+
+	v, e := hd.Index(req.GetGeo(), int(req.GetDt()))
+	if e != nil {
+		return nil, toStatus(e)
+	}
+
+	return &IndexReply{Level: req.GetLevel(), Geo: req.GetGeo(), Dt: req.GetDt(), Index: v}, nil
+}
+
+// Change returns the ratio of the index at end to start.
+func (s *Server) Change(ctx context.Context, req *ChangeRequest) (*ChangeReply, error) {
+	hd, e := s.data(req.GetLevel())
+	if e != nil {
+		return nil, e
+	}
+
+	chg, e := hd.Change(req.GetGeo(), int(req.GetStart()), int(req.GetEnd()))
+	if e != nil {
+		return nil, toStatus(e)
+	}
+
+	return &ChangeReply{Change: chg}, nil
+}
+
+// Series returns the full series for a geo.
+func (s *Server) Series(ctx context.Context, req *SeriesRequest) (*SeriesReply, error) {
+	hd, e := s.data(req.GetLevel())
+	if e != nil {
+		return nil, e
+	}
+
+	h, e := hd.Geo(req.GetGeo())
+	if e != nil {
+		return nil, toStatus(e)
+	}
+
+	dts, indx := h.Data()
+	last, _ := h.Last()
+	reply := &SeriesReply{Level: req.GetLevel(), Geo: req.GetGeo(), Name: h.Name(), LastLoaded: int32(last), Index: indx}
+	for _, dt := range dts {
+		reply.Dates = append(reply.Dates, int32(dt))
+	}
+
+	return reply, nil
+}
+
+// Geos returns the geos available at a level.
+func (s *Server) Geos(ctx context.Context, req *GeosRequest) (*GeosReply, error) {
+	hd, e := s.data(req.GetLevel())
+	if e != nil {
+		return nil, e
+	}
+
+	geos := hd.Geos()
+	sort.Strings(geos)
+
+	return &GeosReply{Geos: geos}, nil
+}
+
+// BatchIndex answers a stream of index lookups in order.  Failed lookups set the error field of the reply.
+func (s *Server) BatchIndex(stream HPI_BatchIndexServer) error {
+	for {
+		req, e := stream.Recv()
+		if errors.Is(e, io.EOF) {
+			return nil
+		}
+
+		if e != nil {
+			return e
+		}
+
+		reply, e := s.Index(stream.Context(), req)
+		if e != nil {
+			reply = &IndexReply{Level: req.GetLevel(), Geo: req.GetGeo(), Dt: req.GetDt(), Error: status.Convert(e).Message()}
+		}
+
+		if e := stream.Send(reply); e != nil {
+			return e
+		}
+	}
+}
+
+// data returns the data for level.
+func (s *Server) data(level string) (*fhfa.HPIdata, error) {
+	hd, e := s.m.Data(level)
+	if e != nil {
+		return nil, status.Error(codes.NotFound, e.Error())
+	}
+
+	return hd, nil
+}
+
+// toStatus converts a lookup error to a gRPC status.
+func toStatus(e error) error {
+	switch {
+	case errors.Is(e, fhfa.ErrBadDate):
+		return status.Error(codes.InvalidArgument, e.Error())
+	case errors.Is(e, fhfa.ErrGeoNotFound), errors.Is(e, fhfa.ErrDateOutOfRange), errors.Is(e, fhfa.ErrDateMissing):
+		return status.Error(codes.NotFound, e.Error())
+	default:
+		return status.Error(codes.Internal, e.Error())
+	}
+}
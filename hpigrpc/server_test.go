@@ -0,0 +1,122 @@
+package hpigrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/invertedv/fhfa"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func testClient(t *testing.T) *Client {
+	tx, e := fhfa.NewHPIseries("TX", "TX", []int{20231, 20232, 20233, 20234}, []float64{100, 101, 102, 103})
+	assert.Nil(t, e)
+	states, e := fhfa.NewHPIdata("state", map[string]*fhfa.HPIseries{"TX": tx})
+	assert.Nil(t, e)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	RegisterHPIServer(srv, NewServer(fhfa.NewManager(states)))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, e := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, e)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return NewClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	v, e := c.Index(ctx, "state", "TX", 20232)
+	assert.Nil(t, e)
+	assert.Equal(t, 101.0, v)
+
+	chg, e := c.Change(ctx, "state", "TX", 20231, 20233)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.02, chg, 1e-9)
+
+	_, e = c.Index(ctx, "state", "ZZ", 20232)
+	assert.Equal(t, codes.NotFound, status.Code(e))
+
+	_, e = c.Index(ctx, "metro", "10180", 20232)
+	assert.Equal(t, codes.NotFound, status.Code(e))
+
+	series, e := c.c.Series(ctx, &SeriesRequest{Level: "state", Geo: "TX"})
+	assert.Nil(t, e)
+	assert.Equal(t, []int32{20231, 20232, 20233, 20234}, series.GetDates())
+
+	geos, e := c.c.Geos(ctx, &GeosRequest{Level: "state"})
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"TX"}, geos.GetGeos())
+
+	reqs := []*IndexRequest{{Level: "state", Geo: "TX", Dt: 20231}, {Level: "state", Geo: "TX", Dt: 20234}}
+	hpis, errs, e := c.BatchIndex(ctx, reqs)
+	assert.Nil(t, e)
+	assert.Nil(t, errs)
+	assert.Equal(t, []float64{100, 103}, hpis)
+
+	reqs = append(reqs, &IndexRequest{Level: "state", Geo: "TX", Dt: 20191})
+	hpis, errs, e = c.BatchIndex(ctx, reqs)
+	assert.Nil(t, e)
+	assert.Nil(t, errs[0])
+	assert.NotNil(t, errs[2])
+	assert.Equal(t, 103.0, hpis[1])
+}
+
+// blockedStream is a BatchIndex stream whose Send blocks until its context is cancelled and whose Recv fails.
+type blockedStream struct {
+	grpc.ClientStream
+	ctx       context.Context
+	unblocked chan struct{}
+}
+
+func (s *blockedStream) Send(*IndexRequest) error {
+	<-s.ctx.Done()
+	close(s.unblocked)
+
+	return s.ctx.Err()
+}
+
+func (s *blockedStream) Recv() (*IndexReply, error) {
+	return nil, status.Error(codes.Unavailable, "down")
+}
+
+func (s *blockedStream) CloseSend() error { return nil }
+
+type blockedClient struct {
+	HPIClient
+	stream *blockedStream
+}
+
+func (c *blockedClient) BatchIndex(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[IndexRequest, IndexReply], error) {
+	c.stream.ctx = ctx
+
+	return c.stream, nil
+}
+
+func TestClient_BatchIndexRecvError(t *testing.T) {
+	bc := &blockedClient{stream: &blockedStream{unblocked: make(chan struct{})}}
+	c := &Client{c: bc}
+
+	_, _, e := c.BatchIndex(context.Background(), []*IndexRequest{{Level: "state", Geo: "TX", Dt: 20231}})
+	assert.Equal(t, codes.Unavailable, status.Code(e))
+
+	// the sender has returned by the time BatchIndex does
+	select {
+	case <-bc.stream.unblocked:
+	default:
+		t.Error("sender still blocked after BatchIndex returned")
+	}
+}
@@ -0,0 +1,258 @@
+// Package hpiserver serves FHFA house price index lookups over HTTP.  The data is held by an fhfa.Manager,
+// so it can be refreshed in the background (see Manager.Run) while the server is running.
+//
+// The endpoints are (dates may be written as 2023Q1 or 20231):
+//
+//   - GET /levels                                - geo levels available
+//   - GET /geos/{level}                          - geos available at a level
+//   - GET /index/{level}/{geo}/{yrqtr}           - index value
+//   - GET /change/{level}/{geo}/{start}/{end}    - ratio of the index at end to start
+//   - GET /series/{level}/{geo}                  - the full series
+//   - GET /best/{yrqtr}?keys=metro:10180,state:TX - index from the first level with data, in keys order
+//
+// Responses are JSON.  Errors are returned as {"error": "..."} with status 400 for malformed requests and
+// 404 for geos, levels or dates that aren't available.
+package hpiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/invertedv/fhfa"
+)
+
+// Server is an http.Handler answering index queries from a Manager.
+type Server struct {
+	m   *fhfa.Manager
+	mux *http.ServeMux
+}
+
+// IndexResponse is the response of /index and /best.
+type IndexResponse struct {
+	Level string     `json:"level"`
+	Geo   string     `json:"geo"`
+	Dt    fhfa.YrQtr `json:"dt"`
+	Index float64    `json:"index"`
+}
+
+// ChangeResponse is the response of /change.
+type ChangeResponse struct {
+	Level  string     `json:"level"`
+	Geo    string     `json:"geo"`
+	Start  fhfa.YrQtr `json:"start"`
+	End    fhfa.YrQtr `json:"end"`
+	Change float64    `json:"change"`
+}
+
+// SeriesResponse is the response of /series.
+type SeriesResponse struct {
+	Level      string       `json:"level"`
+	Geo        string       `json:"geo"`
+	Name       string       `json:"name"`
+	LastLoaded fhfa.YrQtr   `json:"lastLoaded"`
+	Dates      []fhfa.YrQtr `json:"dates"`
+	Index      []float64    `json:"index"`
+}
+
+// New returns a Server answering queries from m.
+func New(m *fhfa.Manager) *Server {
+	s := &Server{m: m, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /levels", s.levels)
+	s.mux.HandleFunc("GET /geos/{level}", s.geos)
+	s.mux.HandleFunc("GET /index/{level}/{geo}/{yrqtr}", s.index)
+	s.mux.HandleFunc("GET /change/{level}/{geo}/{start}/{end}", s.change)
+	s.mux.HandleFunc("GET /series/{level}/{geo}", s.series)
+	s.mux.HandleFunc("GET /best/{yrqtr}", s.best)
+
+	return s
+}
+
+// ServeHTTP dispatches the request to its endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) levels(w http.ResponseWriter, r *http.Request) {
+	write(w, map[string][]string{"levels": s.m.GeoLevels()})
+}
+
+func (s *Server) geos(w http.ResponseWriter, r *http.Request) {
+	hd, ok := s.data(w, r.PathValue("level"))
+	if !ok {
+		return
+	}
+
+	geos := hd.Geos()
+	sort.Strings(geos)
+
+	write(w, map[string]any{"level": hd.GeoLevel(), "geos": geos})
+}
+
+func (s *Server) index(w http.ResponseWriter, r *http.Request) {
+	dt, ok := parseDt(w, r.PathValue("yrqtr"))
+	if !ok {
+		return
+	}
+
+	hd, ok := s.data(w, r.PathValue("level"))
+	if !ok {
+		return
+	}
+
+	geo := r.PathValue("geo")
+	v, e := hd.Index(geo, int(dt))
+	if e != nil {
+		fail(w, e)
+		return
+	}
+
+	write(w, IndexResponse{Level: hd.GeoLevel(), Geo: geo, Dt: dt, Index: v})
+}
+
+func (s *Server) change(w http.ResponseWriter, r *http.Request) {
+	start, ok := parseDt(w, r.PathValue("start"))
+	if !ok {
+		return
+	}
+
+	end, ok := parseDt(w, r.PathValue("end"))
+	if !ok {
+		return
+	}
+
+	hd, ok := s.data(w, r.PathValue("level"))
+	if !ok {
+		return
+	}
+
+	geo := r.PathValue("geo")
+	chg, e := hd.Change(geo, int(start), int(end))
+	if e != nil {
+		fail(w, e)
+		return
+	}
+
+	write(w, ChangeResponse{Level: hd.GeoLevel(), Geo: geo, Start: start, End: end, Change: chg})
+}
+
+func (s *Server) series(w http.ResponseWriter, r *http.Request) {
+	hd, ok := s.data(w, r.PathValue("level"))
+	if !ok {
+		return
+	}
+
+	geo := r.PathValue("geo")
+	h, e := hd.Geo(geo)
+	if e != nil {
+		fail(w, e)
+		return
+	}
+
+	dts, indx := h.Data()
+	last, _ := h.Last()
+	resp := SeriesResponse{Level: hd.GeoLevel(), Geo: geo, Name: h.Name(), LastLoaded: fhfa.YrQtr(last), Index: indx}
+	for _, dt := range dts {
+		resp.Dates = append(resp.Dates, fhfa.YrQtr(dt))
+	}
+
+	write(w, resp)
+}
+
+func (s *Server) best(w http.ResponseWriter, r *http.Request) {
+	dt, ok := parseDt(w, r.PathValue("yrqtr"))
+	if !ok {
+		return
+	}
+
+	var (
+		keys []string
+		hpis []*fhfa.HPIdata
+	)
+
+	for _, kv := range strings.Split(r.URL.Query().Get("keys"), ",") {
+		level, key, found := strings.Cut(kv, ":")
+		if !found || key == "" {
+			fail(w, fmt.Errorf("%w: keys must be level:geo pairs, got %q", errBadRequest, kv))
+			return
+		}
+
+		hd, ok := s.data(w, level)
+		if !ok {
+			return
+		}
+
+		keys = append(keys, key)
+		hpis = append(hpis, hd)
+	}
+
+	v, level, e := fhfa.Best(int(dt), keys, hpis)
+	if e != nil {
+		fail(w, e)
+		return
+	}
+
+	geo := keys[0]
+	for j, hd := range hpis {
+		if hd.GeoLevel() == level {
+			geo = keys[j]
+			break
+		}
+	}
+
+	write(w, IndexResponse{Level: level, Geo: geo, Dt: dt, Index: v})
+}
+
+// errBadRequest marks errors in the request itself
+var errBadRequest = errors.New("bad request")
+
+// errNoLevel marks requests for geo levels the manager doesn't hold
+var errNoLevel = errors.New("geo level not available")
+
+// data returns the data for level, writing the error response if it's not available.
+func (s *Server) data(w http.ResponseWriter, level string) (*fhfa.HPIdata, bool) {
+	hd, e := s.m.Data(level)
+	if e != nil {
+		fail(w, fmt.Errorf("%w: %s", errNoLevel, level))
+		return nil, false
+	}
+
+	return hd, true
+}
+
+// parseDt parses a quarter, writing the error response if it's malformed.
+func parseDt(w http.ResponseWriter, s string) (fhfa.YrQtr, bool) {
+	dt, e := fhfa.ParseYrQtr(s)
+	if e != nil {
+		fail(w, fmt.Errorf("%w: %v", errBadRequest, e))
+		return 0, false
+	}
+
+	return dt, true
+}
+
+// fail writes the error response for e.
+func fail(w http.ResponseWriter, e error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(e, errBadRequest), errors.Is(e, fhfa.ErrBadDate):
+		status = http.StatusBadRequest
+	case errors.Is(e, errNoLevel), errors.Is(e, fhfa.ErrGeoNotFound), errors.Is(e, fhfa.ErrDateOutOfRange),
+		errors.Is(e, fhfa.ErrDateMissing):
+		status = http.StatusNotFound
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": e.Error()})
+}
+
+// write writes v as the JSON response.
+func write(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,75 @@
+package hpiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/invertedv/fhfa"
+	"github.com/stretchr/testify/assert"
+)
+
+func testServer(t *testing.T) *Server {
+	dts := []int{20231, 20232, 20233, 20234}
+
+	tx, e := fhfa.NewHPIseries("TX", "TX", dts, []float64{100, 101, 102, 103})
+	assert.Nil(t, e)
+	states, e := fhfa.NewHPIdata("state", map[string]*fhfa.HPIseries{"TX": tx})
+	assert.Nil(t, e)
+
+	abilene, e := fhfa.NewHPIseries("Abilene, TX", "10180", dts[2:], []float64{200, 210})
+	assert.Nil(t, e)
+	metros, e := fhfa.NewHPIdata("metro", map[string]*fhfa.HPIseries{"10180": abilene})
+	assert.Nil(t, e)
+
+	return New(fhfa.NewManager(states, metros))
+}
+
+func get(t *testing.T, s *Server, url string, out any) int {
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+
+	if out != nil && rec.Code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), out))
+	}
+
+	return rec.Code
+}
+
+func TestServer(t *testing.T) {
+	s := testServer(t)
+
+	var levels map[string][]string
+	assert.Equal(t, http.StatusOK, get(t, s, "/levels", &levels))
+	assert.Equal(t, []string{"metro", "state"}, levels["levels"])
+
+	var ir IndexResponse
+	assert.Equal(t, http.StatusOK, get(t, s, "/index/state/TX/2023Q2", &ir))
+	assert.Equal(t, 101.0, ir.Index)
+	assert.Equal(t, fhfa.YrQtr(20232), ir.Dt)
+
+	var cr ChangeResponse
+	assert.Equal(t, http.StatusOK, get(t, s, "/change/state/TX/20231/2023Q3", &cr))
+	assert.InDelta(t, 1.02, cr.Change, 1e-9)
+
+	var sr SeriesResponse
+	assert.Equal(t, http.StatusOK, get(t, s, "/series/metro/10180", &sr))
+	assert.Equal(t, []fhfa.YrQtr{20233, 20234}, sr.Dates)
+	assert.Equal(t, "Abilene, TX", sr.Name)
+
+	// metro has no data in 2023Q2, so best falls back to the state
+	assert.Equal(t, http.StatusOK, get(t, s, "/best/2023Q2?keys=metro:10180,state:TX", &ir))
+	assert.Equal(t, "state", ir.Level)
+	assert.Equal(t, "TX", ir.Geo)
+
+	assert.Equal(t, http.StatusOK, get(t, s, "/best/2023Q4?keys=metro:10180,state:TX", &ir))
+	assert.Equal(t, "metro", ir.Level)
+	assert.Equal(t, 210.0, ir.Index)
+
+	assert.Equal(t, http.StatusBadRequest, get(t, s, "/index/state/TX/2023Q5", nil))
+	assert.Equal(t, http.StatusBadRequest, get(t, s, "/best/2023Q4?keys=metro", nil))
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/index/state/ZZ/2023Q2", nil))
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/index/state/TX/2020Q2", nil))
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/geos/zip3", nil))
+}
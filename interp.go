@@ -0,0 +1,64 @@
+package fhfa
+
+import "time"
+
+// ChangeTimeInterp returns the ratio of the house price index at dateEnd to dateStart, interpolating
+// within quarters.  ChangeTime uses the index of the quarter containing each date; here the index at a
+// date is the average of its quarter and the next quarter weighted by the fraction of the quarter
+// elapsed, so a date late in a quarter is close to the following quarter.  If the following quarter isn't
+// available (and can't be extrapolated), the quarter's own value is used.
+func (h *HPIseries) ChangeTimeInterp(dateStart, dateEnd time.Time) (float64, error) {
+	var (
+		hpiS, hpiE float64
+		e          error
+	)
+
+	if hpiS, e = h.IndexTime(dateStart); e != nil {
+		return 0, e
+	}
+
+	if hpiE, e = h.IndexTime(dateEnd); e != nil {
+		return 0, e
+	}
+
+	return hpiE / hpiS, nil
+}
+
+// IndexTime returns the index at dt interpolated within its quarter.  See ChangeTimeInterp.
+func (h *HPIseries) IndexTime(dt time.Time) (float64, error) {
+	q := ToYrQtr(dt)
+
+	v0, e := h.Index(q)
+	if e != nil {
+		return 0, e
+	}
+
+	start, e := ToTime(q)
+	if e != nil {
+		return 0, e
+	}
+
+	end := start.AddDate(0, 3, 0)
+	frac := float64(dt.Sub(start)) / float64(end.Sub(start))
+	if frac == 0 {
+		return v0, nil
+	}
+
+	v1, e := h.Index(NextQtr(q))
+	if e != nil {
+		return v0, nil
+	}
+
+	return (1-frac)*v0 + frac*v1, nil
+}
+
+// ChangeTimeInterp returns the ratio of the house price index for geo at dateEnd to dateStart, interpolating
+// within quarters.  See HPIseries.ChangeTimeInterp.
+func (hd *HPIdata) ChangeTimeInterp(geo string, dateStart, dateEnd time.Time) (float64, error) {
+	s, e := hd.Geo(geo)
+	if e != nil {
+		return 0, e
+	}
+
+	return s.ChangeTimeInterp(dateStart, dateEnd)
+}
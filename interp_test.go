@@ -0,0 +1,37 @@
+package fhfa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_ChangeTimeInterp(t *testing.T) {
+	h, e := NewHPIseries("X", "X", []int{20231, 20232, 20233}, []float64{100, 110, 120})
+	assert.Nil(t, e)
+
+	// March 31 to April 1: ChangeTime shows a full quarter, the interpolated change is small
+	mar := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	apr := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	chg, e := h.ChangeTime(mar, apr)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.1, chg, 1e-9)
+
+	chg, e = h.ChangeTimeInterp(mar, apr)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.0, chg, 0.002)
+
+	v, e := h.IndexTime(time.Date(2023, 5, 16, 12, 0, 0, 0, time.UTC))
+	assert.Nil(t, e)
+	assert.InDelta(t, 115, v, 0.1)
+
+	// no following quarter: flat
+	v, e = h.IndexTime(time.Date(2023, 8, 15, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, e)
+	assert.Equal(t, 120.0, v)
+
+	_, e = h.IndexTime(time.Date(2022, 8, 15, 0, 0, 0, 0, time.UTC))
+	assert.NotNil(t, e)
+}
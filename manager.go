@@ -0,0 +1,218 @@
+package fhfa
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Manager holds the current HPIdata for each geo level and allows it to be replaced while other
+// goroutines are querying it.  HPIdata held by a Manager must not be modified in place; use Update or
+// build a new HPIdata and Swap it in.
+type Manager struct {
+	mu   sync.RWMutex // guards data
+	wmu  sync.Mutex   // serializes Update and Swap
+	data map[string]*HPIdata
+
+	notifiers []Notifier              // guarded by mu
+	swapHooks []func(geoLevel string) // called after Swap, guarded by mu
+
+	load func(source string) (*HPIdata, error)
+}
+
+// NewManager creates a Manager holding hds, keyed by their geo level.
+func NewManager(hds ...*HPIdata) *Manager {
+	m := &Manager{
+		data: make(map[string]*HPIdata),
+		load: Load,
+	}
+
+	for _, hd := range hds {
+		m.data[hd.geoLevel] = hd
+	}
+
+	return m
+}
+
+// Change returns the ratio of the index at dtEnd (CCYYQ) to dtStart (CCYYQ) for geo at geoLevel.
+func (m *Manager) Change(geoLevel, geo string, dtStart, dtEnd int) (chg float64, e error) {
+	defer m.timeLookup(geoLevel, time.Now(), &e)
+
+	hd, e := m.Data(geoLevel)
+	if e != nil {
+		return 0, e
+	}
+
+	return hd.Change(geo, dtStart, dtEnd)
+}
+
+// Data returns the current HPIdata for geoLevel.
+func (m *Manager) Data(geoLevel string) (*HPIdata, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hd, ok := m.data[geoLevel]
+	if !ok {
+		return nil, fmt.Errorf("geo level %s not in manager", geoLevel)
+	}
+
+	return hd, nil
+}
+
+// GeoLevels returns the geo levels held by m.
+func (m *Manager) GeoLevels() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var levels []string
+	for k := range m.data {
+		levels = append(levels, k)
+	}
+	sort.Strings(levels)
+
+	return levels
+}
+
+// Index returns the house price index for geo at geoLevel at date dt (CCYYQ).
+func (m *Manager) Index(geoLevel, geo string, dt int) (indx float64, e error) {
+	defer m.timeLookup(geoLevel, time.Now(), &e)
+
+	hd, e := m.Data(geoLevel)
+	if e != nil {
+		return 0, e
+	}
+
+	return hd.Index(geo, dt)
+}
+
+// AddNotifier adds n to the notifiers told when Refresh or Run loads data with a quarter later than the data
+// it replaces.  The summary's QoQ is the change of the USA series over the new quarter for us data and the
+// median change of the geos otherwise.
+func (m *Manager) AddNotifier(n Notifier) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.notifiers = append(m.notifiers, n)
+}
+
+// Refresh loads source and swaps it in for its geo level.  If the data has a new quarter, the notifiers are
+// told and their errors returned; the new data is in place regardless.
+func (m *Manager) Refresh(source string) error {
+	return m.refresh(context.Background(), source)
+}
+
+// refresh does the work of Refresh, passing ctx to the notifiers.
+func (m *Manager) refresh(ctx context.Context, source string) error {
+	hd, e := m.load(source)
+	if e != nil {
+		return e
+	}
+
+	old := m.Swap(hd)
+
+	m.mu.RLock()
+	notifiers := m.notifiers
+	m.mu.RUnlock()
+
+	if len(notifiers) == 0 {
+		return nil
+	}
+
+	if s, ok := summarize(old, hd); ok {
+		if e := notify(ctx, notifiers, s); e != nil {
+			return fmt.Errorf("notify: %w", e)
+		}
+	}
+
+	return nil
+}
+
+// Run refreshes each of sources every period until ctx is cancelled.  Errors are passed to onError,
+// which may be nil.  Lookups continue against the existing data if a refresh fails.
+func (m *Manager) Run(ctx context.Context, sources []string, every time.Duration, onError func(error)) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, src := range sources {
+				if e := m.refresh(ctx, src); e != nil && onError != nil {
+					onError(fmt.Errorf("refresh %s: %w", src, e))
+				}
+			}
+		}
+	}
+}
+
+// Swap replaces the data for the geo level of hd, returning the data it replaced (nil if none).  It waits for
+// an Update in progress, so the update can't overwrite hd with a copy of the older data.
+func (m *Manager) Swap(hd *HPIdata) (old *HPIdata) {
+	m.wmu.Lock()
+	defer m.wmu.Unlock()
+
+	return m.swap(hd)
+}
+
+// swap does the work of Swap.  The caller must hold wmu.
+func (m *Manager) swap(hd *HPIdata) (old *HPIdata) {
+	m.mu.Lock()
+	old = m.data[hd.geoLevel]
+	m.data[hd.geoLevel] = hd
+	hooks := slices.Clone(m.swapHooks)
+	m.mu.Unlock()
+
+	currentMetrics().Released(hd.geoLevel, hd.latest())
+
+	// run after unlocking so hooks may read the Manager
+	for _, fn := range hooks {
+		fn(hd.geoLevel)
+	}
+
+	return old
+}
+
+// onSwap registers fn to be called, with the geo level, whenever data is swapped in.  fn is called without mu
+// held, so it may read the Manager, but it must not call Swap or Update.
+func (m *Manager) onSwap(fn func(geoLevel string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.swapHooks = append(m.swapHooks, fn)
+}
+
+// timeLookup reports a lookup that began at start to the Metrics set by SetMetrics.
+func (m *Manager) timeLookup(geoLevel string, start time.Time, e *error) {
+	currentMetrics().Lookup(geoLevel, time.Since(start), *e)
+}
+
+// Update applies fn to a copy of the data for geoLevel and swaps in the result.  Updates are serialized with
+// each other and with Swap, Refresh and Run, and lookups see either the old or the new data, never a partial
+// update.
+func (m *Manager) Update(geoLevel string, fn func(hd *HPIdata) error) error {
+	m.wmu.Lock()
+	defer m.wmu.Unlock()
+
+	hd, e := m.Data(geoLevel)
+	if e != nil {
+		return e
+	}
+
+	cp := hd.Copy()
+	if e1 := fn(cp); e1 != nil {
+		return e1
+	}
+
+	if cp.geoLevel != geoLevel {
+		return fmt.Errorf("update changed geo level from %s to %s", geoLevel, cp.geoLevel)
+	}
+
+	m.swap(cp)
+
+	return nil
+}
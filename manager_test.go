@@ -0,0 +1,124 @@
+package fhfa
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager(t *testing.T) {
+	m := NewManager(testData())
+	assert.Equal(t, []string{"state"}, m.GeoLevels())
+
+	exp, _ := testData().Index("CA", 20051)
+	v, e := m.Index("state", "CA", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, exp, v)
+
+	_, e = m.Index("metro", "CA", 20051)
+	assert.NotNil(t, e)
+
+	// readers keep querying while the data is updated
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				_, e := m.Change("state", "TX", 20001, 20051)
+				assert.Nil(t, e)
+			}
+		}()
+	}
+
+	for range 10 {
+		e = m.Update("state", func(hd *HPIdata) error {
+			return hd.SetExtrapolation(Extrapolation{Method: ExtrapFlat})
+		})
+		assert.Nil(t, e)
+	}
+	wg.Wait()
+
+	_, e = m.Index("state", "CA", 20201)
+	assert.Nil(t, e)
+
+	old := m.Swap(testData())
+	assert.NotNil(t, old)
+	_, e = m.Index("state", "CA", 20201)
+	assert.NotNil(t, e)
+}
+
+func TestManager_Run(t *testing.T) {
+	m := NewManager()
+
+	loads := 0
+	m.load = func(source string) (*HPIdata, error) {
+		loads++
+		if source == "bad" {
+			return nil, fmt.Errorf("cannot load %s", source)
+		}
+
+		return testData(), nil
+	}
+
+	var errs []error
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	m.Run(ctx, []string{"good", "bad"}, 10*time.Millisecond, func(e error) { errs = append(errs, e) })
+
+	assert.Greater(t, loads, 0)
+	assert.NotEmpty(t, errs)
+	_, e := m.Data("state")
+	assert.Nil(t, e)
+}
+
+func TestManager_UpdateRefresh(t *testing.T) {
+	m := NewManager(testData())
+	fresh := testData()
+	m.load = func(string) (*HPIdata, error) { return fresh, nil }
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- m.Update("state", func(hd *HPIdata) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+
+	// a refresh during the update must not be overwritten by it
+	<-started
+	refreshed := make(chan error)
+	go func() { refreshed <- m.Refresh("new") }()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	assert.Nil(t, <-done)
+	assert.Nil(t, <-refreshed)
+
+	hd, e := m.Data("state")
+	assert.Nil(t, e)
+	assert.Same(t, fresh, hd)
+}
+
+func TestManager_onSwapReads(t *testing.T) {
+	m := NewManager(testData())
+
+	var seen *HPIdata
+	m.onSwap(func(geoLevel string) {
+		// reading the Manager from a hook must not deadlock
+		hd, e := m.Data(geoLevel)
+		assert.Nil(t, e)
+		seen = hd
+	})
+
+	hd := testData()
+	m.Swap(hd)
+	assert.Same(t, hd, seen)
+}
@@ -0,0 +1,126 @@
+package fhfa
+
+import "fmt"
+
+// LookupPolicy determines which observation Index uses for a date within a series that isn't in it.
+type LookupPolicy int
+
+const (
+	// LookupPrior uses the latest earlier quarter (the default).
+	LookupPrior LookupPolicy = iota
+
+	// LookupNext uses the earliest later quarter.
+	LookupNext
+
+	// LookupNearest uses the closest quarter, the earlier one if they are equally close.
+	LookupNearest
+
+	// LookupExact returns an error (ErrDateMissing).
+	LookupExact
+)
+
+// String returns the name of the policy.
+func (p LookupPolicy) String() string {
+	switch p {
+	case LookupPrior:
+		return "prior"
+	case LookupNext:
+		return "next"
+	case LookupNearest:
+		return "nearest"
+	case LookupExact:
+		return "exact"
+	default:
+		return fmt.Sprintf("LookupPolicy(%d)", int(p))
+	}
+}
+
+// SetLookupPolicy sets the lookup policy for every series in hd.
+func (hd *HPIdata) SetLookupPolicy(p LookupPolicy) error {
+	if e := p.check(); e != nil {
+		return e
+	}
+
+	for _, s := range hd.series {
+		s.match = p
+	}
+
+	return nil
+}
+
+// SetLookupPolicy sets the lookup policy of h.
+func (h *HPIseries) SetLookupPolicy(p LookupPolicy) error {
+	if e := p.check(); e != nil {
+		return e
+	}
+
+	h.match = p
+
+	return nil
+}
+
+// LookupPolicy returns the lookup policy of h.
+func (h *HPIseries) LookupPolicy() LookupPolicy {
+	return h.match
+}
+
+// IndexWith returns the index at dt (CCYYQ) as Index does, but using lookup policy p for this call.
+func (h *HPIseries) IndexWith(dt int, p LookupPolicy) (float64, error) {
+	if e := p.check(); e != nil {
+		return 0, e
+	}
+
+	indx, e := h.position(dt, p)
+	if e != nil {
+		if dt > h.dates[len(h.dates)-1] && h.extrap.Method != ExtrapNone {
+			return h.extrapolate(dt)
+		}
+
+		return 0, e
+	}
+
+	return h.indx[indx], nil
+}
+
+// IndexWith returns the index for geo at dt (CCYYQ) using lookup policy p.
+func (hd *HPIdata) IndexWith(geo string, dt int, p LookupPolicy) (float64, error) {
+	s, e := hd.Geo(geo)
+	if e != nil {
+		return 0, e
+	}
+
+	return s.IndexWith(dt, p)
+}
+
+// position returns the position in h.dates used for dt under policy p.
+func (h *HPIseries) position(dt int, p LookupPolicy) (int, error) {
+	j, e := h.DateIndex(dt)
+	if e != nil || h.dates[j] == dt {
+		return j, e
+	}
+
+	// dt is strictly between h.dates[j] and h.dates[j+1]
+	switch p {
+	case LookupNext:
+		return j + 1, nil
+	case LookupNearest:
+		if QtrDiff(dt, h.dates[j+1]) < QtrDiff(h.dates[j], dt) {
+			return j + 1, nil
+		}
+
+		return j, nil
+	case LookupExact:
+		return -1, fmt.Errorf("%w: %d", ErrDateMissing, dt)
+	default:
+		return j, nil
+	}
+}
+
+// check validates the policy
+func (p LookupPolicy) check() error {
+	if p < LookupPrior || p > LookupExact {
+		return fmt.Errorf("unknown lookup policy: %d", p)
+	}
+
+	return nil
+}
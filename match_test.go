@@ -0,0 +1,47 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_LookupPolicy(t *testing.T) {
+	// missing 20202 through 20204
+	h := &HPIseries{geoCode: "X", dates: []int{20201, 20211, 20212}, indx: []float64{100, 110, 111},
+		lastDt: 20212, lastIndx: 111}
+
+	exp := map[LookupPolicy][]float64{
+		LookupPrior:   {100, 100, 100},
+		LookupNext:    {110, 110, 110},
+		LookupNearest: {100, 100, 110},
+	}
+
+	for p, vals := range exp {
+		for j, dt := range []int{20202, 20203, 20204} {
+			v, e := h.IndexWith(dt, p)
+			assert.Nil(t, e)
+			assert.Equal(t, vals[j], v, "policy %s at %d", p, dt)
+		}
+	}
+
+	_, e := h.IndexWith(20202, LookupExact)
+	assert.ErrorIs(t, e, ErrDateMissing)
+
+	v, e := h.IndexWith(20211, LookupExact)
+	assert.Nil(t, e)
+	assert.Equal(t, 110.0, v)
+
+	assert.Nil(t, h.SetLookupPolicy(LookupNext))
+	assert.Equal(t, LookupNext, h.Copy().LookupPolicy())
+	v, _ = h.Index(20203)
+	assert.Equal(t, 110.0, v)
+
+	assert.NotNil(t, h.SetLookupPolicy(LookupPolicy(9)))
+
+	hd, e := NewHPIdata("zip3", map[string]*HPIseries{"X": h})
+	assert.Nil(t, e)
+	assert.Nil(t, hd.SetLookupPolicy(LookupExact))
+	_, e = hd.Index("X", 20203)
+	assert.ErrorIs(t, e, ErrDateMissing)
+}
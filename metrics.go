@@ -0,0 +1,82 @@
+package fhfa
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics receives measurements from the fetch, load and lookup layers.  It is a callback interface so that
+// any metrics system (e.g. Prometheus counters and histograms) can be plugged in with SetMetrics without this
+// package depending on it.  Methods may be called concurrently.  Embed NopMetrics to implement only some of them.
+type Metrics interface {
+	// Downloaded is called after Download with the url, the number of bytes saved and the time taken.
+	Downloaded(url string, bytes int64, d time.Duration, err error)
+
+	// Loaded is called after Load with the source and the time taken to fetch and parse it.
+	Loaded(source string, d time.Duration, err error)
+
+	// Lookup is called after each Manager lookup (Index, Change) with the time taken.
+	Lookup(geoLevel string, d time.Duration, err error)
+
+	// Cache is called when a cached file is used (hit) or must be fetched (miss).
+	Cache(hit bool)
+
+	// Released is called when data for geoLevel is swapped into a Manager.  lastDt (CCYYQ) is the latest
+	// published quarter in the data; see ReleaseAge.
+	Released(geoLevel string, lastDt int)
+}
+
+// NopMetrics is a Metrics that does nothing.  It is the default.
+type NopMetrics struct{}
+
+func (NopMetrics) Downloaded(string, int64, time.Duration, error) {}
+func (NopMetrics) Loaded(string, time.Duration, error)            {}
+func (NopMetrics) Lookup(string, time.Duration, error)            {}
+func (NopMetrics) Cache(bool)                                     {}
+func (NopMetrics) Released(string, int)                           {}
+
+// metricsHolder lets an interface value be stored atomically.
+type metricsHolder struct {
+	m Metrics
+}
+
+var metrics atomic.Pointer[metricsHolder]
+
+// SetMetrics sets the Metrics that receives measurements.  Passing nil restores NopMetrics.
+func SetMetrics(m Metrics) {
+	if m == nil {
+		m = NopMetrics{}
+	}
+
+	metrics.Store(&metricsHolder{m: m})
+}
+
+// currentMetrics returns the Metrics set by SetMetrics.
+func currentMetrics() Metrics {
+	if h := metrics.Load(); h != nil {
+		return h.m
+	}
+
+	return NopMetrics{}
+}
+
+// ReleaseAge returns the time from the end of quarter lastDt (CCYYQ) to now.  This is the age of a release
+// whose latest quarter is lastDt.
+func ReleaseAge(lastDt int, now time.Time) (time.Duration, error) {
+	end, e := QtrEndDate(lastDt)
+	if e != nil {
+		return 0, e
+	}
+
+	return now.Sub(end), nil
+}
+
+// latest returns the latest published quarter (CCYYQ) of any series in hd, 0 if hd is empty.
+func (hd *HPIdata) latest() int {
+	dt := 0
+	for _, s := range hd.series {
+		dt = max(dt, s.lastDt)
+	}
+
+	return dt
+}
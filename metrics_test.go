@@ -0,0 +1,112 @@
+package fhfa
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testMetrics records the calls made to it.
+type testMetrics struct {
+	NopMetrics
+
+	mu       sync.Mutex
+	loads    []string
+	lookups  int
+	errs     int
+	released map[string]int
+	hits     []bool
+	dlErrs   int
+}
+
+func (m *testMetrics) Downloaded(url string, bytes int64, d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.dlErrs++
+	}
+}
+
+func (m *testMetrics) Loaded(source string, d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loads = append(m.loads, source)
+}
+
+func (m *testMetrics) Lookup(geoLevel string, d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lookups++
+	if err != nil {
+		m.errs++
+	}
+}
+
+func (m *testMetrics) Released(geoLevel string, lastDt int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.released[geoLevel] = lastDt
+}
+
+func (m *testMetrics) Cache(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hits = append(m.hits, hit)
+}
+
+func TestSetMetrics(t *testing.T) {
+	m := &testMetrics{released: make(map[string]int)}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	src := testXLSX(t, testData())
+	hd, e := Load(src)
+	assert.Nil(t, e)
+	assert.Equal(t, []string{src}, m.loads)
+
+	mgr := NewManager()
+	mgr.Swap(hd)
+	assert.Equal(t, 20094, m.released["state"])
+
+	_, e = mgr.Index("state", "TX", 20051)
+	assert.Nil(t, e)
+	_, e = mgr.Change("state", "XX", 20051, 20061)
+	assert.NotNil(t, e)
+	assert.Equal(t, 2, m.lookups)
+	assert.Equal(t, 1, m.errs)
+
+	SetMetrics(nil)
+	_, e = mgr.Index("state", "TX", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, 2, m.lookups)
+}
+
+func TestPipeline_RunCache(t *testing.T) {
+	m := &testMetrics{released: make(map[string]int)}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	dir := t.TempDir()
+	b, e := os.ReadFile(testXLSX(t, testData()))
+	assert.Nil(t, e)
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, filepath.Base(URLs("state"))), b, 0o644))
+
+	p := &Pipeline{CacheDir: dir, Levels: []string{"state"}}
+	_, e = p.Run(context.Background())
+	assert.Nil(t, e)
+	assert.Equal(t, []bool{true}, m.hits)
+}
+
+func TestReleaseAge(t *testing.T) {
+	age, e := ReleaseAge(20234, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, e)
+	assert.Equal(t, 31*24*time.Hour, age)
+
+	_, e = ReleaseAge(20235, time.Now())
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package fhfa
+
+import "os"
+
+// mapFile reads localFile into memory; memory-mapping is only used on Unix systems.
+func mapFile(localFile string) (data []byte, unmap func() error, e error) {
+	data, e = os.ReadFile(localFile)
+
+	return data, func() error { return nil }, e
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+package fhfa
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile memory-maps localFile read-only, returning its contents and a function that unmaps it.
+func mapFile(localFile string) (data []byte, unmap func() error, e error) {
+	file, e := os.Open(localFile)
+	if e != nil {
+		return nil, nil, e
+	}
+	defer file.Close()
+
+	fi, e := file.Stat()
+	if e != nil {
+		return nil, nil, e
+	}
+
+	if fi.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+
+	data, e = syscall.Mmap(int(file.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if e != nil {
+		return nil, nil, e
+	}
+
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,138 @@
+package fhfa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// New England property is best located by town (county subdivision) rather than ZIP: ZIPs there often cross
+// town and county lines, and OMB also delineates New England city and town areas (NECTAs) from towns rather
+// than counties.  Town FIPS codes are the 5-digit state + county code followed by the 5-digit county
+// subdivision code.
+
+// newEngland are the FIPS codes of the New England states: CT, ME, MA, NH, RI and VT.
+var newEngland = []string{"09", "23", "25", "33", "44", "50"}
+
+// Town is the NECTA assignment of a New England town.
+type Town struct {
+	FIPS  string // 10-digit state + county + county subdivision FIPS code
+	Name  string // town name
+	State string // state name
+	NECTA string // NECTA code, empty if the town is not in a NECTA
+	Title string // NECTA title
+	Metro bool   // true if the NECTA is metropolitan (rather than micropolitan)
+}
+
+// NECTADelineation maps New England towns to NECTAs for an OMB delineation vintage.  OMB last delineated
+// NECTAs in 2020.
+type NECTADelineation struct {
+	vintage int
+	towns   map[string]*Town
+}
+
+// InNewEngland returns true if fips, a county or town FIPS code, is in New England.
+func InNewEngland(fips string) bool {
+	n := 5
+	if len(strings.TrimSpace(fips)) > n {
+		n = 10
+	}
+
+	f, e := padCode(fips, n)
+	if e != nil {
+		return false
+	}
+
+	return in(f[:2], newEngland)
+}
+
+// LoadNECTA loads the Census Bureau NECTA delineation file from source - a local file or web address, in xlsx
+// or csv format.  vintage is the year of the delineation.
+func LoadNECTA(source string, vintage int) (*NECTADelineation, error) {
+	need := []string{"NECTA Code", "NECTA Title", "Metropolitan/Micropolitan NECTA", "County Subdivision",
+		"State Name", "FIPS State Code", "FIPS County Code", "FIPS County Subdivision Code"}
+
+	r, cols, e := readDelineation(source, need)
+	if e != nil {
+		return nil, e
+	}
+
+	n := &NECTADelineation{vintage: vintage, towns: make(map[string]*Town)}
+	for _, row := range r {
+		if len(row) <= cols["FIPS County Subdivision Code"] {
+			continue
+		}
+
+		fld := func(name string) string { return unquote(row[cols[name]]) }
+
+		st, e1 := padCode(fld("FIPS State Code"), 2)
+		cnty, e2 := padCode(fld("FIPS County Code"), 3)
+		sub, e3 := padCode(fld("FIPS County Subdivision Code"), 5)
+		if e1 != nil || e2 != nil || e3 != nil {
+			continue
+		}
+
+		t := &Town{
+			FIPS:  st + cnty + sub,
+			Name:  fld("County Subdivision"),
+			State: fld("State Name"),
+			NECTA: fld("NECTA Code"),
+			Title: fld("NECTA Title"),
+			Metro: strings.HasPrefix(fld("Metropolitan/Micropolitan NECTA"), "Metropolitan"),
+		}
+
+		n.towns[t.FIPS] = t
+	}
+
+	if len(n.towns) == 0 {
+		return nil, fmt.Errorf("no towns in NECTA delineation file %s", source)
+	}
+
+	return n, nil
+}
+
+// Town returns the delineation data for the town with 10-digit FIPS code fips.
+func (n *NECTADelineation) Town(fips string) (Town, error) {
+	f, e := padCode(fips, 10)
+	if e != nil {
+		return Town{}, e
+	}
+
+	t, ok := n.towns[f]
+	if !ok {
+		return Town{}, fmt.Errorf("town %s not in %d NECTA delineation", fips, n.vintage)
+	}
+
+	return *t, nil
+}
+
+// Route returns the NECTA code of the series for a town (10-digit FIPS), for data keyed by NECTA, or
+// "nonmetro" if the town is not in a metropolitan NECTA.
+func (n *NECTADelineation) Route(fips string) (string, error) {
+	f, e := padCode(fips, 10)
+	if e != nil {
+		return "", e
+	}
+
+	t, ok := n.towns[f]
+	if !ok || !t.Metro {
+		return "nonmetro", nil
+	}
+
+	return t.NECTA, nil
+}
+
+// Vintage returns the year of the delineation.
+func (n *NECTADelineation) Vintage() int {
+	return n.vintage
+}
+
+// RouteTown returns the CBSA code of the metro series for a town (10-digit FIPS), or "nonmetro", as Route
+// does for its county.  Use it for New England property, whose ZIP may not identify its county.
+func (d *Delineation) RouteTown(fips string) (string, error) {
+	f, e := padCode(fips, 10)
+	if e != nil {
+		return "", e
+	}
+
+	return d.Route(f[:5])
+}
@@ -0,0 +1,48 @@
+package fhfa
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadNECTA(t *testing.T) {
+	contents := `NECTAs and NECTA Divisions
+NECTA Code,NECTA Division Code,CNECTA Code,NECTA Title,Metropolitan/Micropolitan NECTA,NECTA Division Title,CNECTA Title,County/County Equivalent,County Subdivision,State Name,FIPS State Code,FIPS County Code,FIPS County Subdivision Code
+71650,71654,715,"Boston-Cambridge-Nashua, MA-NH",Metropolitan NECTA,"Boston-Cambridge-Newton, MA",,Norfolk County,Brookline town,Massachusetts,25,021,09175
+70900,,,"Barre, VT",Micropolitan NECTA,,,Washington County,Barre city,Vermont,50,023,03175
+`
+	file := fmt.Sprintf("%s/necta.csv", t.TempDir())
+	assert.Nil(t, os.WriteFile(file, []byte(contents), 0o644))
+
+	n, e := LoadNECTA(file, 2020)
+	assert.Nil(t, e)
+	assert.Equal(t, 2020, n.Vintage())
+
+	tn, e := n.Town("2502109175")
+	assert.Nil(t, e)
+	assert.Equal(t, "Brookline town", tn.Name)
+	assert.Equal(t, "71650", tn.NECTA)
+	assert.True(t, tn.Metro)
+
+	for fips, exp := range map[string]string{"2502109175": "71650", "5002303175": "nonmetro", "2502100000": "nonmetro"} {
+		r, e := n.Route(fips)
+		assert.Nil(t, e)
+		assert.Equal(t, exp, r)
+	}
+
+	d, e := LoadDelineation(testDelineation(t), 2023)
+	assert.Nil(t, e)
+	r, e := d.RouteTown("2502109175")
+	assert.Nil(t, e)
+	assert.Equal(t, "14460", r)
+
+	assert.True(t, InNewEngland("2502109175"))
+	assert.True(t, InNewEngland("9001"))
+	assert.False(t, InNewEngland("48441"))
+
+	_, e = LoadNECTA(testDelineation(t), 2020)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,81 @@
+package fhfa
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NormalizeGeo returns the canonical form of the key geo at geoLevel.  Spaces are trimmed and:
+//
+//   - zip3              - numeric keys are zero-padded to 3 digits (37 -> 037)
+//   - metro             - numeric keys are zero-padded to 5 digits
+//   - state, nonmetro   - state names and FIPS codes become postal codes, other keys are upper-cased
+//   - pr, cs            - keys are upper-cased
+func NormalizeGeo(geoLevel, geo string) string {
+	geo = strings.TrimSpace(geo)
+
+	switch geoLevel {
+	case "zip3":
+		return padNumeric(geo, 3)
+	case "metro":
+		return padNumeric(geo, 5)
+	case "state", "nonmetro":
+		if st, e := NormalizeState(geo); e == nil {
+			return st
+		}
+
+		return strings.ToUpper(geo)
+	case "pr", "cs":
+		return strings.ToUpper(geo)
+	default:
+		return geo
+	}
+}
+
+// SetStrict turns strict matching of geo keys on or off.  With strict matching, Geo (and so Index, Change, etc.)
+// requires geo to exactly match the key in the data.  Otherwise, geo is normalized by NormalizeGeo and, for us and
+// mh data, matched without regard to case.  Strict matching is off by default.
+func (hd *HPIdata) SetStrict(strict bool) {
+	hd.strict = strict
+}
+
+// Strict returns true if geo keys must match exactly.
+func (hd *HPIdata) Strict() bool {
+	return hd.strict
+}
+
+// lookup finds the series for geo, normalizing it unless hd is strict.  Aliases are tried last.
+func (hd *HPIdata) lookup(geo string) (*HPIseries, bool) {
+	if h, ok := hd.series[geo]; ok {
+		return h, ok
+	}
+
+	if hd.strict {
+		return hd.alias(geo)
+	}
+
+	if h, ok := hd.series[NormalizeGeo(hd.geoLevel, geo)]; ok {
+		return h, true
+	}
+
+	// these have only a handful of geos
+	if hd.geoLevel == "us" || hd.geoLevel == "mh" {
+		geo = strings.TrimSpace(geo)
+		for k, h := range hd.series {
+			if strings.EqualFold(k, geo) {
+				return h, true
+			}
+		}
+	}
+
+	return hd.alias(geo)
+}
+
+// padNumeric left-pads geo with zeros to n digits if it's a non-negative integer
+func padNumeric(geo string, n int) string {
+	if _, e := strconv.ParseUint(geo, 10, 64); e != nil || len(geo) >= n {
+		return geo
+	}
+
+	return strings.Repeat("0", n-len(geo)) + geo
+}
@@ -0,0 +1,47 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeGeo(t *testing.T) {
+	levels := []string{"zip3", "zip3", "metro", "state", "state", "nonmetro", "pr", "us"}
+	geos := []string{"37", " 837 ", "10180 ", "ca", "California", "06", "pr", " USA"}
+	exp := []string{"037", "837", "10180", "CA", "CA", "CA", "PR", "USA"}
+
+	for j, geo := range geos {
+		assert.Equal(t, exp[j], NormalizeGeo(levels[j], geo))
+	}
+}
+
+func TestHPIdata_SetStrict(t *testing.T) {
+	s := testData().series["CA"]
+	zip3, e := NewHPIdata("zip3", map[string]*HPIseries{"37": s})
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"037"}, zip3.Geos())
+
+	for _, geo := range []string{"037", "37", " 37"} {
+		_, e = zip3.Geo(geo)
+		assert.Nil(t, e)
+	}
+
+	us, e := NewHPIdata("us", map[string]*HPIseries{"USA": s, "East North Central": s})
+	assert.Nil(t, e)
+	_, e = us.Geo("east north central")
+	assert.Nil(t, e)
+
+	zip3.SetStrict(true)
+	assert.True(t, zip3.Copy().Strict())
+
+	_, e = zip3.Geo("37")
+	assert.NotNil(t, e)
+	_, e = zip3.Geo("037")
+	assert.Nil(t, e)
+
+	hd := testData()
+	hd.SetStrict(true)
+	_, e = hd.Geo("ca")
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,178 @@
+package fhfa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+)
+
+// maxRevisions is the number of revisions reported in a RefreshSummary.
+const maxRevisions = 5
+
+// RefreshSummary describes a refresh that brought in a new quarter.
+type RefreshSummary struct {
+	GeoLevel  string           `json:"geoLevel"`
+	PrevLast  int              `json:"prevLast"`  // latest quarter before the refresh (CCYYQ)
+	Last      int              `json:"last"`      // latest quarter after the refresh (CCYYQ)
+	QoQ       float64          `json:"qoq"`       // national change over the latest quarter; see Manager.AddNotifier
+	Revisions []RevisedQuarter `json:"revisions"` // the largest revisions to quarters in both releases, largest first
+}
+
+// RevisedQuarter is a revision to the index of a geo at a quarter.
+type RevisedQuarter struct {
+	Geo      string  `json:"geo"`
+	Dt       int     `json:"dt"`       // quarter (CCYYQ)
+	Original float64 `json:"original"` // value before the refresh
+	Revised  float64 `json:"revised"`  // value after the refresh
+}
+
+// Notifier is told of refreshes that bring in a new quarter.
+type Notifier interface {
+	Notify(ctx context.Context, s RefreshSummary) error
+}
+
+// NotifierFunc is a function that is a Notifier.
+type NotifierFunc func(ctx context.Context, s RefreshSummary) error
+
+// Notify calls f.
+func (f NotifierFunc) Notify(ctx context.Context, s RefreshSummary) error {
+	return f(ctx, s)
+}
+
+// ChannelNotifier returns a Notifier that sends the summary on ch.  It doesn't block: if ch isn't ready,
+// the summary is dropped and an error returned.
+func ChannelNotifier(ch chan<- RefreshSummary) Notifier {
+	return NotifierFunc(func(_ context.Context, s RefreshSummary) error {
+		select {
+		case ch <- s:
+			return nil
+		default:
+			return fmt.Errorf("notification channel is full")
+		}
+	})
+}
+
+// WebhookNotifier returns a Notifier that POSTs the summary as JSON to url.  Any status other than 2xx is an error.
+func WebhookNotifier(url string) Notifier {
+	return NotifierFunc(func(ctx context.Context, s RefreshSummary) error {
+		b, e := json.Marshal(s)
+		if e != nil {
+			return e
+		}
+
+		req, e := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+		if e != nil {
+			return e
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, e := http.DefaultClient.Do(req)
+		if e != nil {
+			return e
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("webhook %s: %s", url, resp.Status)
+		}
+
+		return nil
+	})
+}
+
+// summarize returns the summary of replacing old with hd, false if hd has no new quarter.
+func summarize(old, hd *HPIdata) (RefreshSummary, bool) {
+	s := RefreshSummary{GeoLevel: hd.geoLevel, Last: hd.latest()}
+	if old != nil {
+		s.PrevLast = old.latest()
+	}
+
+	if s.Last <= s.PrevLast {
+		return s, false
+	}
+
+	s.QoQ = hd.nationalQoQ(s.Last)
+
+	if old == nil {
+		return s, true
+	}
+
+	for geo, so := range old.series {
+		sn, ok := hd.series[geo]
+		if !ok {
+			continue
+		}
+
+		for j, dt := range so.dates {
+			if k := sn.exact(dt); k >= 0 && sn.indx[k] != so.indx[j] {
+				s.Revisions = append(s.Revisions, RevisedQuarter{Geo: geo, Dt: dt, Original: so.indx[j], Revised: sn.indx[k]})
+			}
+		}
+	}
+
+	size := func(r RevisedQuarter) float64 { return math.Abs(r.Revised/r.Original - 1) }
+	sort.Slice(s.Revisions, func(i, j int) bool {
+		ri, rj := s.Revisions[i], s.Revisions[j]
+		if size(ri) != size(rj) {
+			return size(ri) > size(rj)
+		}
+
+		if ri.Geo != rj.Geo {
+			return ri.Geo < rj.Geo
+		}
+
+		return ri.Dt < rj.Dt
+	})
+
+	if len(s.Revisions) > maxRevisions {
+		s.Revisions = s.Revisions[:maxRevisions]
+	}
+
+	return s, true
+}
+
+// nationalQoQ returns the change over quarter dt (CCYYQ) of the USA series if hd has one.  Otherwise, it is
+// the median change of the geos with data for dt.
+func (hd *HPIdata) nationalQoQ(dt int) float64 {
+	if s, e := hd.Geo("USA"); e == nil {
+		if r, e := s.Change(PrevQtr(dt), dt); e == nil {
+			return r - 1
+		}
+	}
+
+	var chg []float64
+	for _, s := range hd.series {
+		if s.exact(dt) < 0 {
+			continue
+		}
+
+		if r, e := s.Change(PrevQtr(dt), dt); e == nil {
+			chg = append(chg, r-1)
+		}
+	}
+
+	if len(chg) == 0 {
+		return 0
+	}
+
+	sort.Float64s(chg)
+
+	return quantile(chg, 0.5)
+}
+
+// notify sends s to each notifier, returning their errors.
+func notify(ctx context.Context, notifiers []Notifier, s RefreshSummary) error {
+	var errs []error
+	for _, n := range notifiers {
+		if e := n.Notify(ctx, s); e != nil {
+			errs = append(errs, e)
+		}
+	}
+
+	return errors.Join(errs...)
+}
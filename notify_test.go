@@ -0,0 +1,62 @@
+package fhfa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_AddNotifier(t *testing.T) {
+	old := testData()
+	m := NewManager(old)
+
+	// new release: a quarter added to every geo and TX revised
+	hd := testData()
+	for geo, s := range hd.All() {
+		_, v := s.Last()
+		assert.Nil(t, s.Upsert([]int{20101}, []float64{v * (1 + testGrowth[geo])}))
+	}
+	hd.series["TX"].indx[10] *= 1.02
+	m.load = func(string) (*HPIdata, error) { return hd, nil }
+
+	ch := make(chan RefreshSummary, 1)
+	m.AddNotifier(ChannelNotifier(ch))
+
+	var posted RefreshSummary
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&posted))
+	}))
+	defer srv.Close()
+	m.AddNotifier(WebhookNotifier(srv.URL))
+
+	assert.Nil(t, m.Refresh("state.xlsx"))
+
+	s := <-ch
+	assert.Equal(t, "state", s.GeoLevel)
+	assert.Equal(t, 20094, s.PrevLast)
+	assert.Equal(t, 20101, s.Last)
+	assert.InDelta(t, 0.01, s.QoQ, 1e-10)
+	assert.Equal(t, []RevisedQuarter{{Geo: "TX", Dt: 20023, Original: old.series["TX"].indx[10], Revised: hd.series["TX"].indx[10]}},
+		s.Revisions)
+	assert.Equal(t, s, posted)
+
+	// no new quarter, no notification
+	assert.Nil(t, m.Refresh("state.xlsx"))
+	assert.Len(t, ch, 0)
+
+	// the channel is full
+	m.Swap(old)
+	ch <- s
+	assert.ErrorContains(t, m.Refresh("state.xlsx"), "full")
+
+	m.AddNotifier(NotifierFunc(func(context.Context, RefreshSummary) error { return nil }))
+	srv.Close()
+	m.Swap(old)
+	<-ch
+	assert.NotNil(t, m.Refresh("state.xlsx"))
+}
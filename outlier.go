@@ -0,0 +1,59 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Outlier is a quarterly change outside the band given to Outliers.
+type Outlier struct {
+	Geo    string  // geo of the series
+	Dt     int     // date (CCYYQ) at the end of the quarter
+	Change float64 // quarterly change, so 0.2 is a 20% increase
+}
+
+// Outliers returns the quarterly changes in h larger than maxChange in absolute value (e.g. 0.15 is 15%),
+// in date order.  Appended data is included.
+func (h *HPIseries) Outliers(maxChange float64) ([]Outlier, error) {
+	if maxChange <= 0 {
+		return nil, fmt.Errorf("maxChange must be positive, got %v", maxChange)
+	}
+
+	var out []Outlier
+	for j := 1; j < len(h.indx); j++ {
+		if chg := h.indx[j]/h.indx[j-1] - 1; math.Abs(chg) > maxChange {
+			out = append(out, Outlier{Geo: h.geoCode, Dt: h.dates[j], Change: chg})
+		}
+	}
+
+	return out, nil
+}
+
+// Outliers returns the quarterly changes across the series in hd larger than maxChange in absolute value,
+// sorted by geo and date.
+func (hd *HPIdata) Outliers(maxChange float64) ([]Outlier, error) {
+	if maxChange <= 0 {
+		return nil, fmt.Errorf("maxChange must be positive, got %v", maxChange)
+	}
+
+	var out []Outlier
+	for geo, s := range hd.series {
+		o, _ := s.Outliers(maxChange)
+		for j := range o {
+			o[j].Geo = geo
+		}
+
+		out = append(out, o...)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Geo != out[j].Geo {
+			return out[i].Geo < out[j].Geo
+		}
+
+		return out[i].Dt < out[j].Dt
+	})
+
+	return out, nil
+}
@@ -0,0 +1,31 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_Outliers(t *testing.T) {
+	hd := testData()
+	o, e := hd.Outliers(0.15)
+	assert.Nil(t, e)
+	assert.Len(t, o, 0)
+
+	ca, _ := hd.Geo("CA")
+	ca.indx[10] *= 1.5
+	assert.Nil(t, ca.Append([]int{20101}, []float64{ca.indx[39] * 0.7}))
+
+	o, e = hd.Outliers(0.15)
+	assert.Nil(t, e)
+	assert.Len(t, o, 3)
+	assert.Equal(t, "CA", o[0].Geo)
+	assert.Equal(t, 20023, o[0].Dt)
+	assert.InDelta(t, 1.02*1.5-1, o[0].Change, 1e-9)
+	assert.Equal(t, 20024, o[1].Dt)
+	assert.Equal(t, 20101, o[2].Dt)
+	assert.InDelta(t, -0.3, o[2].Change, 1e-9)
+
+	_, e = hd.Outliers(0)
+	assert.NotNil(t, e)
+}
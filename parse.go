@@ -0,0 +1,121 @@
+package fhfa
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/invertedv/dass"
+)
+
+// parseXLSX builds HPIdata from the rows of an FHFA workbook.  The rows are split into blocks of one geo
+// each, which are parsed into series by up to workers goroutines.
+func parseXLSX(r [][]string, workers int) (*HPIdata, error) {
+	if len(r) < 2 || len(r[0]) == 0 {
+		return nil, fmt.Errorf("no headers in workbook")
+	}
+
+	cols := r[1]
+	schema, e := DetectSchema(r[0][0], cols)
+	if e != nil {
+		return nil, e
+	}
+
+	geoLevel := schema.GeoLevel
+	tags := parseTags(r[0][0], cols)
+	if tags.Frequency == Monthly {
+		return nil, fmt.Errorf("monthly data not supported: %s", r[0][0])
+	}
+
+	template := []string{"string", "int", "int", "float"}
+	names := []string{"geoCode", "year", "qtr", "index"}
+	miss := []string{"skip", "skip", "skip", "skip"}
+	geoCol := 0
+
+	if geoLevel == "metro" {
+		template = []string{"string", "string", "int", "int", "float"}
+		names = []string{"areaName", "geoCode", "year", "qtr", "index"}
+		miss = []string{"skip", "skip", "skip", "skip", "skip"}
+		geoCol = 1
+	}
+
+	blocks := geoBlocks(r[1:], geoCol)
+	workers = max(1, min(workers, len(blocks)))
+
+	// results[j] holds the series of blocks[j]
+	results := make([]map[string]*HPIseries, len(blocks))
+	errs := make([]error, len(blocks))
+
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range next {
+				// ParseRows skips the first row, which is the header in a whole workbook
+				rows, e := dass.ParseRows(append([][]string{nil}, blocks[j]...), names, template, miss, 0)
+				if e != nil {
+					errs[j] = e
+					continue
+				}
+
+				part := &HPIdata{geoLevel: geoLevel, series: make(map[string]*HPIseries)}
+				errs[j] = loadRows(part, rows)
+				results[j] = part.series
+			}
+		}()
+	}
+
+	for j := range blocks {
+		next <- j
+	}
+	close(next)
+	wg.Wait()
+
+	hd := &HPIdata{
+		geoLevel: geoLevel,
+		series:   make(map[string]*HPIseries),
+		tags:     tags,
+	}
+
+	// in block order, so a geo that appears twice ends up with its last block, as a serial load would
+	for j, part := range results {
+		if errs[j] != nil {
+			return nil, errs[j]
+		}
+
+		for geo, s := range part {
+			hd.series[geo] = s
+		}
+	}
+
+	hd.shareDates()
+
+	return hd, nil
+}
+
+// geoBlocks splits rows into runs with the same value in column geoCol.
+func geoBlocks(rows [][]string, geoCol int) [][][]string {
+	var blocks [][][]string
+
+	start := 0
+	for j := 1; j <= len(rows); j++ {
+		if j < len(rows) && cell(rows[j], geoCol) == cell(rows[start], geoCol) {
+			continue
+		}
+
+		blocks = append(blocks, rows[start:j])
+		start = j
+	}
+
+	return blocks
+}
+
+// cell returns column col of row, blank if the row is short.
+func cell(row []string, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+
+	return ""
+}
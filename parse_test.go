@@ -0,0 +1,71 @@
+package fhfa
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRows returns the rows of a zip3 workbook with geos series of qtrs quarters each.
+func testRows(geos, qtrs int) [][]string {
+	r := [][]string{
+		{"Three-Digit ZIP Codes"},
+		{"Three-Digit ZIP Code", "Year", "Quarter", "Index (NSA)", "Index Type"},
+	}
+
+	for g := range geos {
+		dt := 19951
+		for q := range qtrs {
+			r = append(r, []string{fmt.Sprintf("%03d", g), fmt.Sprint(dt / 10), fmt.Sprint(dt % 10),
+				fmt.Sprintf("%0.2f", 100+float64(q+g)/10), "Native 3-Digit ZIP index"})
+			dt = NextQtr(dt)
+		}
+	}
+
+	return r
+}
+
+func TestParseXLSX(t *testing.T) {
+	r := testRows(50, 20)
+	assert.Equal(t, "zip3", geoLevel(r[0][0]))
+
+	serial, e := parseXLSX(r, 1)
+	assert.Nil(t, e)
+	parallel, e := parseXLSX(r, 8)
+	assert.Nil(t, e)
+
+	assert.Len(t, parallel.series, 50)
+	for geo, s := range serial.All() {
+		p, e := parallel.Geo(geo)
+		assert.Nil(t, e)
+		assert.Equal(t, s.Dates(), p.Dates())
+		assert.Equal(t, s.Values(), p.Values())
+	}
+
+	v, e := parallel.Index("007", 19952)
+	assert.Nil(t, e)
+	assert.Equal(t, 100.8, v)
+
+	// a geo that appears twice keeps its last block
+	r = append(r, []string{"000", "2000", "1", "1", ""})
+	hd, e := parseXLSX(r, 8)
+	assert.Nil(t, e)
+	assert.Equal(t, []int{20001}, hd.series["000"].Dates())
+
+	assert.Len(t, geoBlocks(r[2:], 0), 51)
+}
+
+func BenchmarkParseXLSX(b *testing.B) {
+	r := testRows(900, 120)
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for range b.N {
+				if _, e := parseXLSX(r, workers); e != nil {
+					b.Fatal(e)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package fhfa
+
+import "fmt"
+
+// PercentileRank returns where the trailing 4-quarter growth of h at dt (CCYYQ) sits in the history of
+// trailing 4-quarter growth through dt, as the fraction of quarters with growth at or below it.  A value
+// near 1 is historically strong appreciation, a value near 0 a historically weak one.
+func (h *HPIseries) PercentileRank(dt int) (float64, error) {
+	rc, e := h.RollingChange(4)
+	if e != nil {
+		return 0, e
+	}
+
+	j := rc.exact(dt)
+	if j < 0 {
+		return 0, fmt.Errorf("no trailing 4-quarter growth at %d", dt)
+	}
+
+	cur := rc.indx[j]
+	n := 0
+	for _, v := range rc.indx[:j+1] {
+		if v <= cur {
+			n++
+		}
+	}
+
+	return float64(n) / float64(j+1), nil
+}
+
+// PercentileAll returns the PercentileRank at dt (CCYYQ) of each geo in hd.  Geos without the data are
+// reported in errs.
+func (hd *HPIdata) PercentileAll(dt int) (ranks map[string]float64, errs map[string]error) {
+	ranks = make(map[string]float64)
+	errs = make(map[string]error)
+	for geo, s := range hd.series {
+		r, e := s.PercentileRank(dt)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		ranks[geo] = r
+	}
+
+	return ranks, errs
+}
@@ -0,0 +1,33 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_PercentileRank(t *testing.T) {
+	h, e := NewHPIseries("X", "X", []int{20201, 20202, 20203, 20204, 20211, 20212, 20213},
+		[]float64{100, 100, 100, 100, 110, 105, 100})
+	assert.Nil(t, e)
+
+	// trailing growth: 1.1, 1.05, 1.0
+	r, e := h.PercentileRank(20211)
+	assert.Nil(t, e)
+	assert.Equal(t, 1.0, r)
+
+	r, e = h.PercentileRank(20213)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.0/3, r, 1e-9)
+
+	_, e = h.PercentileRank(20204)
+	assert.NotNil(t, e)
+
+	ranks, errs := testData().PercentileAll(20094)
+	assert.Len(t, errs, 0)
+	assert.Len(t, ranks, 3)
+	for _, r := range ranks {
+		assert.Greater(t, r, 0.0)
+		assert.LessOrEqual(t, r, 1.0)
+	}
+}
@@ -0,0 +1,189 @@
+package fhfa
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Pipeline is a declarative data-refresh job, usually read from a YAML file by LoadPipeline:
+//
+//	cacheDir: /data/fhfa        # downloaded files are kept here
+//	levels: [state, metro]      # FHFA files to fetch
+//	sources: [/data/zip3.xlsx]  # other files or URLs to load
+//	scenarios:
+//	  - name: adverse
+//	    level: state            # blank applies to every level
+//	    from: 2024Q4
+//	    path: [-0.02, -0.01]
+//	    annualization: simple   # path rates: quarterly (the default), compound or simple
+//	outputs:
+//	  - level: state
+//	    format: csv             # csv, json (newline-delimited) or parquet
+//	    file: /data/out/state.csv
+type Pipeline struct {
+	CacheDir  string             `yaml:"cacheDir"`  // directory for downloaded files; a temporary directory if blank
+	Refresh   bool               `yaml:"refresh"`   // download files even if they are in CacheDir
+	Levels    []string           `yaml:"levels"`    // geo levels to fetch from FHFA
+	Sources   []string           `yaml:"sources"`   // other sources to load (local files or URLs)
+	Scenarios []PipelineScenario `yaml:"scenarios"` // scenario extensions to apply, in order
+	Outputs   []PipelineOutput   `yaml:"outputs"`   // files to write
+}
+
+// PipelineScenario is a scenario extension applied by a Pipeline.
+type PipelineScenario struct {
+	Name  string               `yaml:"name"`  // scenario name
+	Level string               `yaml:"level"` // geo level to apply it to, all levels if blank
+	From  YrQtr                `yaml:"from"`  // quarter the scenario starts from
+	Path  []float64            `yaml:"path"`  // default growth path
+	Paths map[string][]float64 `yaml:"paths"` // geo-specific paths
+
+	Annualization Annualization `yaml:"annualization"` // quarterly (the default), compound or simple
+}
+
+// PipelineOutput is a file written by a Pipeline.
+type PipelineOutput struct {
+	Level  string `yaml:"level"`  // geo level to write
+	Format string `yaml:"format"` // csv (the default), json or parquet
+	File   string `yaml:"file"`   // file to write
+}
+
+// LoadPipeline reads a Pipeline from a YAML file.
+func LoadPipeline(configFile string) (*Pipeline, error) {
+	b, e := os.ReadFile(configFile)
+	if e != nil {
+		return nil, e
+	}
+
+	p := &Pipeline{}
+	if e := yaml.Unmarshal(b, p); e != nil {
+		return nil, fmt.Errorf("%s: %w", configFile, e)
+	}
+
+	if e := p.Check(); e != nil {
+		return nil, fmt.Errorf("%s: %w", configFile, e)
+	}
+
+	return p, nil
+}
+
+// Check validates the pipeline without fetching anything.
+func (p *Pipeline) Check() error {
+	if len(p.Levels) == 0 && len(p.Sources) == 0 {
+		return fmt.Errorf("pipeline has no levels or sources")
+	}
+
+	for _, level := range p.Levels {
+		if !in(level, []string{"zip3", "metro", "nonmetro", "state", "us", "pr", "mh"}) {
+			return fmt.Errorf("invalid geo level: %s", level)
+		}
+	}
+
+	for _, s := range p.Scenarios {
+		if !s.From.Valid() {
+			return fmt.Errorf("scenario %s: %w", s.Name, badDate(int(s.From)))
+		}
+	}
+
+	for _, o := range p.Outputs {
+		if o.File == "" {
+			return fmt.Errorf("output for %s has no file", o.Level)
+		}
+
+		if !in(o.Format, []string{"", "csv", "json", "parquet"}) {
+			return fmt.Errorf("unknown output format: %s", o.Format)
+		}
+	}
+
+	return nil
+}
+
+// Run fetches and loads the data, applies the scenarios and writes the outputs.  It returns the data
+// loaded, keyed by geo level.
+func (p *Pipeline) Run(ctx context.Context) (map[string]*HPIdata, error) {
+	if e := p.Check(); e != nil {
+		return nil, e
+	}
+
+	dir := p.CacheDir
+	if dir == "" {
+		tmp, e := os.MkdirTemp("", "fhfa")
+		if e != nil {
+			return nil, e
+		}
+		defer os.RemoveAll(tmp)
+
+		dir = tmp
+	}
+
+	sources := append([]string{}, p.Sources...)
+	for _, level := range p.Levels {
+		url := URLs(level)
+		file := filepath.Join(dir, filepath.Base(url))
+		_, e := os.Stat(file)
+		hit := !p.Refresh && e == nil
+		currentMetrics().Cache(hit)
+
+		if !hit {
+			if e := Download(ctx, url, file); e != nil {
+				return nil, fmt.Errorf("fetch %s: %w", level, e)
+			}
+		}
+
+		sources = append(sources, file)
+	}
+
+	data := make(map[string]*HPIdata)
+	for _, src := range sources {
+		if e := ctx.Err(); e != nil {
+			return nil, e
+		}
+
+		hd, e := Load(src)
+		if e != nil {
+			return nil, fmt.Errorf("load %s: %w", src, e)
+		}
+
+		data[hd.geoLevel] = hd
+	}
+
+	for _, s := range p.Scenarios {
+		sc := Scenario{Name: s.Name, Path: s.Path, Paths: s.Paths, Annualization: s.Annualization}
+		for level, hd := range data {
+			if s.Level != "" && !strings.EqualFold(s.Level, level) {
+				continue
+			}
+
+			if e := hd.ApplyScenario(sc, int(s.From)); e != nil {
+				return nil, fmt.Errorf("scenario %s at %s: %w", s.Name, level, e)
+			}
+		}
+	}
+
+	for _, o := range p.Outputs {
+		hd, ok := data[o.Level]
+		if !ok {
+			return nil, fmt.Errorf("no %s data for output %s", o.Level, o.File)
+		}
+
+		var e error
+		switch o.Format {
+		case "json":
+			e = hd.SaveNDJSON(o.File)
+		case "parquet":
+			e = hd.SaveParquet(o.File)
+		default:
+			e = hd.SaveContext(ctx, o.File)
+		}
+
+		if e != nil {
+			return nil, e
+		}
+	}
+
+	return data, nil
+}
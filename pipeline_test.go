@@ -0,0 +1,80 @@
+package fhfa
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+// testXLSX writes hd to an xlsx file in the FHFA state layout.
+func testXLSX(t *testing.T, hd *HPIdata) string {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+
+	rows := [][]any{
+		{"House Price Index for the 50 States and the District of Columbia"},
+		{"State", "Year", "Quarter", "Index (NSA)"},
+	}
+
+	for geo, s := range hd.All() {
+		for dt, v := range s.Observations() {
+			rows = append(rows, []any{geo, dt / 10, dt % 10, v})
+		}
+	}
+
+	for j, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, j+1)
+		assert.Nil(t, f.SetSheetRow(sheet, cell, &row))
+	}
+
+	file := filepath.Join(t.TempDir(), "state.xlsx")
+	assert.Nil(t, f.SaveAs(file))
+
+	return file
+}
+
+func TestPipeline_Run(t *testing.T) {
+	dir := t.TempDir()
+	src := testXLSX(t, testData())
+	out := filepath.Join(dir, "state.json")
+
+	config := `
+sources: [` + src + `]
+scenarios:
+  - name: adverse
+    from: 2009Q4
+    path: [-0.1, -0.1]
+outputs:
+  - level: state
+    format: json
+    file: ` + out + `
+`
+	configFile := filepath.Join(dir, "pipeline.yaml")
+	assert.Nil(t, os.WriteFile(configFile, []byte(config), 0o644))
+
+	p, e := LoadPipeline(configFile)
+	assert.Nil(t, e)
+	assert.Equal(t, YrQtr(20094), p.Scenarios[0].From)
+
+	data, e := p.Run(context.Background())
+	assert.Nil(t, e)
+
+	chg, e := data["state"].Change("TX", 20094, 20102)
+	assert.Nil(t, e)
+	assert.InDelta(t, 0.81, chg, 1e-9)
+
+	_, e = os.Stat(out)
+	assert.Nil(t, e)
+
+	assert.Nil(t, os.WriteFile(configFile, []byte("levels: [county]\n"), 0o644))
+	_, e = LoadPipeline(configFile)
+	assert.NotNil(t, e)
+
+	p = &Pipeline{Sources: []string{src}, Outputs: []PipelineOutput{{Level: "metro", File: out}}}
+	_, e = p.Run(context.Background())
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,190 @@
+// Package plot charts FHFA house price indices as plotly figures.  A Figure can be written as plotly JSON
+// (for use with any plotly front end) or as a stand-alone HTML page that loads plotly.js from its CDN.
+//
+// The charts are:
+//
+//   - Series - the index of one or more series over time
+//   - YoY    - year-over-year growth of one or more series
+//   - Bar    - appreciation of each geo of an HPIdata between two quarters, largest first
+package plot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/invertedv/fhfa"
+)
+
+// PlotlyJS is the address from which HTML pages load plotly.js.
+const PlotlyJS = "https://cdn.plot.ly/plotly-2.35.2.min.js"
+
+// Trace is a plotly trace.
+type Trace struct {
+	Type string    `json:"type"`           // scatter or bar
+	Mode string    `json:"mode,omitempty"` // lines for scatter
+	Name string    `json:"name,omitempty"` // legend entry
+	X    []string  `json:"x"`
+	Y    []float64 `json:"y"`
+}
+
+// Axis is a plotly axis.
+type Axis struct {
+	Title      string `json:"title,omitempty"`
+	TickFormat string `json:"tickformat,omitempty"`
+}
+
+// Layout is a plotly layout.
+type Layout struct {
+	Title string `json:"title,omitempty"`
+	XAxis Axis   `json:"xaxis"`
+	YAxis Axis   `json:"yaxis"`
+}
+
+// Figure is a plotly figure.
+type Figure struct {
+	Data   []Trace `json:"data"`
+	Layout Layout  `json:"layout"`
+}
+
+// Series returns a line chart of the index of each of series.
+func Series(title string, series ...*fhfa.HPIseries) *Figure {
+	fig := &Figure{Layout: Layout{Title: title, YAxis: Axis{Title: "Index"}}}
+	for _, s := range series {
+		fig.Data = append(fig.Data, line(s))
+	}
+
+	return fig
+}
+
+// YoY returns a line chart of the year-over-year growth of each of series.  A series with less than 5
+// quarters of data is an error.
+func YoY(title string, series ...*fhfa.HPIseries) (*Figure, error) {
+	fig := &Figure{Layout: Layout{Title: title, YAxis: Axis{Title: "YoY growth", TickFormat: ".1%"}}}
+	for _, s := range series {
+		yoy, e := s.RollingChange(4)
+		if e != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name(), e)
+		}
+
+		tr := line(yoy)
+		for j := range tr.Y {
+			tr.Y[j]--
+		}
+
+		fig.Data = append(fig.Data, tr)
+	}
+
+	return fig, nil
+}
+
+// Bar returns a bar chart of the appreciation of each geo of hd from dtStart (CCYYQ) to dtEnd (CCYYQ), largest
+// first.  If geos is empty, all the geos of hd with data for the range are charted.
+func Bar(title string, hd *fhfa.HPIdata, dtStart, dtEnd int, geos ...string) (*Figure, error) {
+	all := len(geos) == 0
+	if all {
+		geos = hd.Geos()
+	}
+
+	type bar struct {
+		geo string
+		apr float64
+	}
+
+	var bars []bar
+	for _, geo := range geos {
+		r, e := hd.Change(geo, dtStart, dtEnd)
+		if e != nil {
+			if all {
+				continue
+			}
+
+			return nil, e
+		}
+
+		bars = append(bars, bar{geo, r - 1})
+	}
+
+	sort.Slice(bars, func(i, j int) bool {
+		if bars[i].apr != bars[j].apr {
+			return bars[i].apr > bars[j].apr
+		}
+
+		return bars[i].geo < bars[j].geo
+	})
+
+	tr := Trace{Type: "bar", X: []string{}, Y: []float64{}}
+	for _, b := range bars {
+		tr.X = append(tr.X, b.geo)
+		tr.Y = append(tr.Y, b.apr)
+	}
+
+	return &Figure{
+		Data: []Trace{tr},
+		Layout: Layout{
+			Title: title,
+			XAxis: Axis{Title: hd.GeoLevel()},
+			YAxis: Axis{Title: fmt.Sprintf("Appreciation %s to %s", fhfa.YrQtr(dtStart), fhfa.YrQtr(dtEnd)), TickFormat: ".1%"},
+		},
+	}, nil
+}
+
+// JSON returns the plotly JSON of fig.
+func (fig *Figure) JSON() ([]byte, error) {
+	return json.Marshal(fig)
+}
+
+// WriteHTML writes fig to w as a stand-alone HTML page.
+func (fig *Figure) WriteHTML(w io.Writer) error {
+	js, e := fig.JSON()
+	if e != nil {
+		return e
+	}
+
+	_, e = fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<script src="%s"></script>
+</head>
+<body>
+<div id="plot" style="width:100%%;height:90vh;"></div>
+<script>
+var fig = %s;
+Plotly.newPlot("plot", fig.data, fig.layout);
+</script>
+</body>
+</html>
+`, PlotlyJS, js)
+
+	return e
+}
+
+// SaveHTML saves fig as a stand-alone HTML page in localFile.
+func (fig *Figure) SaveHTML(localFile string) error {
+	file, e := os.Create(localFile)
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	if e := fig.WriteHTML(file); e != nil {
+		return e
+	}
+
+	return file.Close()
+}
+
+// line returns a scatter trace of s with quarters on the x-axis as their first day (e.g. 2023-04-01).
+func line(s *fhfa.HPIseries) Trace {
+	tr := Trace{Type: "scatter", Mode: "lines", Name: s.Name()}
+	for dt, v := range s.Observations() {
+		t, _ := fhfa.ToTime(dt)
+		tr.X = append(tr.X, t.Format("2006-01-02"))
+		tr.Y = append(tr.Y, v)
+	}
+
+	return tr
+}
@@ -0,0 +1,76 @@
+package plot
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/invertedv/fhfa"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSeries returns a series for geo that starts at 100 in 20201 and grows at g per quarter for n quarters.
+func testSeries(t *testing.T, geo string, g float64, n int) *fhfa.HPIseries {
+	dts := fhfa.QtrRange(20201, fhfa.AddQtrs(20201, n-1))
+	indx := make([]float64, n)
+	v := 100.0
+	for j := range indx {
+		indx[j] = v
+		v *= 1 + g
+	}
+
+	s, e := fhfa.NewHPIseries(geo, geo, dts, indx)
+	assert.Nil(t, e)
+
+	return s
+}
+
+func TestSeries(t *testing.T) {
+	fig := Series("Index", testSeries(t, "TX", 0.01, 8), testSeries(t, "CA", 0.02, 8))
+	assert.Len(t, fig.Data, 2)
+	assert.Equal(t, "CA", fig.Data[1].Name)
+	assert.Equal(t, "2020-04-01", fig.Data[0].X[1])
+
+	js, e := fig.JSON()
+	assert.Nil(t, e)
+
+	var m map[string]any
+	assert.Nil(t, json.Unmarshal(js, &m))
+	assert.Contains(t, m, "data")
+	assert.Contains(t, m, "layout")
+
+	var b bytes.Buffer
+	assert.Nil(t, fig.WriteHTML(&b))
+	assert.True(t, strings.Contains(b.String(), PlotlyJS))
+	assert.Nil(t, fig.SaveHTML(filepath.Join(t.TempDir(), "index.html")))
+}
+
+func TestYoY(t *testing.T) {
+	fig, e := YoY("YoY", testSeries(t, "TX", 0.01, 8))
+	assert.Nil(t, e)
+	assert.Len(t, fig.Data[0].Y, 4)
+	assert.InEpsilon(t, 1.01*1.01*1.01*1.01-1, fig.Data[0].Y[0], 1e-10)
+	assert.Equal(t, "2021-01-01", fig.Data[0].X[0])
+
+	_, e = YoY("YoY", testSeries(t, "TX", 0.01, 4))
+	assert.NotNil(t, e)
+}
+
+func TestBar(t *testing.T) {
+	hd, e := fhfa.NewHPIdata("state", map[string]*fhfa.HPIseries{
+		"TX": testSeries(t, "TX", 0.01, 8),
+		"CA": testSeries(t, "CA", 0.02, 8),
+		"NY": testSeries(t, "NY", -0.01, 2),
+	})
+	assert.Nil(t, e)
+
+	fig, e := Bar("Appreciation", hd, 20201, 20211)
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"CA", "TX"}, fig.Data[0].X)
+	assert.Contains(t, fig.Layout.YAxis.Title, "2020Q1 to 2021Q1")
+
+	_, e = Bar("Appreciation", hd, 20201, 20211, "TX", "NY")
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,52 @@
+package fhfa
+
+import (
+	"fmt"
+	"time"
+)
+
+// PointInTime answers lookups at one geo level as they would have been answered on a past date, using the
+// vintages of a VintageStore.  Later revisions and quarters published later are excluded.
+type PointInTime struct {
+	vs       *VintageStore
+	geoLevel string
+}
+
+// Level returns the point-in-time view of geoLevel.
+func (vs *VintageStore) Level(geoLevel string) *PointInTime {
+	return &PointInTime{vs: vs, geoLevel: geoLevel}
+}
+
+// IndexAsOf returns the index for geo at quarter dt (CCYYQ) as published on knowledgeDate: the value in the
+// latest vintage released on or before then.  It is an error if dt had not yet been published.
+func (p *PointInTime) IndexAsOf(geo string, dt int, knowledgeDate time.Time) (float64, error) {
+	bc, v, e := p.vs.OpenAsOf(p.geoLevel, knowledgeDate)
+	if e != nil {
+		return 0, e
+	}
+	defer bc.Close()
+
+	indx, e := bc.Index(geo, dt)
+	if e != nil {
+		return 0, fmt.Errorf("%s vintage released %s: %w", p.geoLevel, v.Released.Format("2006-01-02"), e)
+	}
+
+	return indx, nil
+}
+
+// ChangeAsOf returns the ratio of the index at dtEnd (CCYYQ) to dtStart (CCYYQ) for geo as published on
+// knowledgeDate.  Both quarters come from the same vintage.
+func (p *PointInTime) ChangeAsOf(geo string, dtStart, dtEnd int, knowledgeDate time.Time) (float64, error) {
+	bc, v, e := p.vs.OpenAsOf(p.geoLevel, knowledgeDate)
+	if e != nil {
+		return 0, e
+	}
+	defer bc.Close()
+
+	r, e := bc.Change(geo, dtStart, dtEnd)
+	if e != nil {
+		return 0, fmt.Errorf("%s vintage released %s: %w", p.geoLevel, v.Released.Format("2006-01-02"), e)
+	}
+
+	return r, nil
+}
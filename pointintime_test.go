@@ -0,0 +1,52 @@
+package fhfa
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointInTime_IndexAsOf(t *testing.T) {
+	vs, e := OpenVintageStore(t.TempDir())
+	assert.Nil(t, e)
+
+	may := time.Date(2010, 5, 25, 0, 0, 0, 0, time.UTC)
+	aug := time.Date(2010, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	orig := testData()
+	for _, s := range orig.All() {
+		s.dates, s.indx = s.dates[:39], s.indx[:39]
+		s.lastDt, s.lastIndx = s.dates[38], s.indx[38]
+	}
+	assert.Nil(t, vs.Put(may, orig))
+
+	rev := testData()
+	s, _ := rev.Geo("TX")
+	s.indx[38] = 150
+	assert.Nil(t, vs.Put(aug, rev))
+
+	pit := vs.Level("state")
+	june := time.Date(2010, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	v, e := pit.IndexAsOf("TX", 20093, june)
+	assert.Nil(t, e)
+	want, _ := orig.Index("TX", 20093)
+	assert.Equal(t, want, v)
+
+	v, e = pit.IndexAsOf("TX", 20093, aug)
+	assert.Nil(t, e)
+	assert.Equal(t, 150.0, v)
+
+	// 20094 wasn't published in June
+	_, e = pit.IndexAsOf("TX", 20094, june)
+	assert.True(t, errors.Is(e, ErrDateOutOfRange))
+
+	r, e := pit.ChangeAsOf("TX", 20092, 20093, june)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.01, r, 1e-9)
+
+	_, e = pit.IndexAsOf("TX", 20001, may.AddDate(-1, 0, 0))
+	assert.NotNil(t, e)
+}
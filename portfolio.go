@@ -0,0 +1,101 @@
+package fhfa
+
+import "fmt"
+
+// Property is a property to be marked to market with the HPI.
+type Property struct {
+	ID        string   // property identifier
+	Keys      []string // geo keys, one for each HPIdata in the fallback chain
+	OrigDt    int      // date of OrigValue (CCYYQ), e.g. the origination date
+	OrigValue float64  // value at OrigDt
+}
+
+// Mark is the HPI-updated value of a Property.
+type Mark struct {
+	ID       string  // property identifier
+	Value    float64 // updated value
+	Change   float64 // ratio of the index at the as-of date to the index at OrigDt
+	GeoLevel string  // geo level of the series used
+	Err      error   // error, if the property couldn't be valued
+}
+
+// Portfolio values a set of properties using a Best()-style fallback chain of HPIdata.
+type Portfolio struct {
+	props   []Property
+	hpis    []*HPIdata
+	workers int
+}
+
+// NewPortfolio creates a Portfolio.
+//
+// props - properties to value
+//
+// hpis - house price index data ordered by preference. The Keys of each property correspond to these.
+func NewPortfolio(props []Property, hpis []*HPIdata) (*Portfolio, error) {
+	if len(hpis) == 0 {
+		return nil, fmt.Errorf("no HPI data for portfolio")
+	}
+
+	return &Portfolio{
+		props: props,
+		hpis:  hpis,
+	}, nil
+}
+
+// SetWorkers sets the number of goroutines used by Value.  If n is not positive, runtime.NumCPU() is used,
+// which is the default.
+func (p *Portfolio) SetWorkers(n int) {
+	p.workers = n
+}
+
+// Properties returns the properties in the portfolio.
+func (p *Portfolio) Properties() []Property {
+	return p.props
+}
+
+// Value returns the marks of the properties in p as of asOfDt (CCYYQ), in the order of the properties.
+// Each property uses the first HPIdata in the chain with data for its key at both OrigDt and asOfDt.
+func (p *Portfolio) Value(asOfDt int) []Mark {
+	marks := make([]Mark, len(p.props))
+
+	parallel(len(p.props), p.workers, func(start, end int) {
+		for j := start; j < end; j++ {
+			marks[j] = mark(&p.props[j], asOfDt, p.hpis)
+		}
+	})
+
+	return marks
+}
+
+// mark values a single property.
+func mark(prop *Property, asOfDt int, hpis []*HPIdata) Mark {
+	m := Mark{ID: prop.ID}
+
+	if len(prop.Keys) != len(hpis) {
+		m.Err = fmt.Errorf("property %s has %d keys for %d HPI levels", prop.ID, len(prop.Keys), len(hpis))
+		return m
+	}
+
+	if m.Change, m.GeoLevel, m.Err = BestChange(prop.OrigDt, asOfDt, prop.Keys, hpis); m.Err != nil {
+		return m
+	}
+
+	m.Value = prop.OrigValue * m.Change
+
+	return m
+}
+
+// UpdateLTV returns the HPI-updated loan-to-value ratio of a loan with loan-to-value origLTV at origDt (CCYYQ)
+// as of asOfDt (CCYYQ), assuming the balance is unchanged.  The estimated current property value is the
+// original value times valueRatio.  keys and hpis are as in Best.
+func UpdateLTV(origLTV float64, origDt, asOfDt int, keys []string, hpis []*HPIdata) (ltv, valueRatio float64, geoLevel string, e error) {
+	if origLTV < 0 {
+		return 0, 0, "", fmt.Errorf("negative LTV: %v", origLTV)
+	}
+
+	if valueRatio, geoLevel, e = BestChange(origDt, asOfDt, keys, hpis); e != nil {
+		return 0, 0, "", e
+	}
+
+	return origLTV / valueRatio, valueRatio, geoLevel, nil
+}
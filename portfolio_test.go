@@ -0,0 +1,75 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testUS returns synthetic us-level data growing at 1.5% a quarter from 20001 for 40 quarters.
+func testUS() *HPIdata {
+	var (
+		dts  []int
+		indx []float64
+	)
+
+	dt, v := 20001, 100.0
+	for range 40 {
+		dts = append(dts, dt)
+		indx = append(indx, v)
+		dt, v = NextQtr(dt), v*1.015
+	}
+
+	s, _ := NewHPIseries("USA", "USA", dts, indx)
+	hd, _ := NewHPIdata("us", map[string]*HPIseries{"USA": s})
+
+	return hd
+}
+
+func TestPortfolio_Value(t *testing.T) {
+	props := []Property{
+		{ID: "a", Keys: []string{"CA", "USA"}, OrigDt: 20051, OrigValue: 200000},
+		{ID: "b", Keys: []string{"WY", "USA"}, OrigDt: 20051, OrigValue: 100000},
+		{ID: "c", Keys: []string{"TX", "USA"}, OrigDt: 19001, OrigValue: 100000},
+		{ID: "d", Keys: []string{"TX"}, OrigDt: 20051, OrigValue: 100000},
+	}
+
+	p, e := NewPortfolio(props, []*HPIdata{testData(), testUS()})
+	assert.Nil(t, e)
+	p.SetWorkers(2)
+
+	marks := p.Value(20061)
+	assert.Equal(t, len(props), len(marks))
+
+	assert.Nil(t, marks[0].Err)
+	assert.Equal(t, "state", marks[0].GeoLevel)
+	assert.InEpsilon(t, 200000*math.Pow(1+testGrowth["CA"], 4), marks[0].Value, 1e-9)
+
+	assert.Nil(t, marks[1].Err)
+	assert.Equal(t, "us", marks[1].GeoLevel)
+	assert.InEpsilon(t, 100000*math.Pow(1.015, 4), marks[1].Value, 1e-9)
+
+	assert.NotNil(t, marks[2].Err)
+	assert.NotNil(t, marks[3].Err)
+
+	_, e = NewPortfolio(props, nil)
+	assert.NotNil(t, e)
+}
+
+func TestUpdateLTV(t *testing.T) {
+	hpis := []*HPIdata{testData(), testUS()}
+
+	ltv, ratio, level, e := UpdateLTV(80, 20051, 20061, []string{"NY", "USA"}, hpis)
+	assert.Nil(t, e)
+	assert.Equal(t, "state", level)
+	assert.InEpsilon(t, math.Pow(1+testGrowth["NY"], 4), ratio, 1e-9)
+	assert.InEpsilon(t, 80/ratio, ltv, 1e-9)
+
+	_, _, level, e = UpdateLTV(80, 20051, 20061, []string{"WY", "USA"}, hpis)
+	assert.Nil(t, e)
+	assert.Equal(t, "us", level)
+
+	_, _, _, e = UpdateLTV(80, 20051, 20201, []string{"NY", "USA"}, hpis)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,43 @@
+package fhfa
+
+import "fmt"
+
+// End returns the date (CCYYQ) and index value of the last observation of h, including appended data.
+func (h *HPIseries) End() (dt int, indx float64) {
+	return h.dates[len(h.dates)-1], h.indx[len(h.indx)-1]
+}
+
+// LastLoaded returns the date (CCYYQ) and index value of the last published observation of h.  This is the
+// same as Last(): appended (projected) data is excluded.  Use End() for the last observation including
+// appended data.
+func (h *HPIseries) LastLoaded() (dt int, indx float64) {
+	return h.Last()
+}
+
+// Projected returns true if the observation at dt (CCYYQ) was appended (e.g. by Append, ApplyScenario or
+// Extend) rather than published.
+func (h *HPIseries) Projected(dt int) (bool, error) {
+	j := h.exact(dt)
+	if j < 0 {
+		return false, fmt.Errorf("%w: %d", ErrDateMissing, dt)
+	}
+
+	return h.provenance(j) == Projected, nil
+}
+
+// End returns the date (CCYYQ) and value of the last observation for geo, including appended data.
+func (hd *HPIdata) End(geo string) (int, float64, error) {
+	s, e := hd.Geo(geo)
+	if e != nil {
+		return 0, 0, e
+	}
+
+	dt, indx := s.End()
+
+	return dt, indx, nil
+}
+
+// LastLoaded returns the date (CCYYQ) and value of the last published observation for geo.
+func (hd *HPIdata) LastLoaded(geo string) (int, float64, error) {
+	return hd.Last(geo)
+}
@@ -0,0 +1,98 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_End(t *testing.T) {
+	s, e := NewHPIseries("CA", "CA", []int{20201, 20202}, []float64{100, 101})
+	assert.Nil(t, e)
+
+	assert.Nil(t, s.Append([]int{20203, 20204}, []float64{102, 103}))
+
+	dt, v := s.LastLoaded()
+	assert.Equal(t, 20202, dt)
+	assert.Equal(t, 101.0, v)
+
+	dt, v = s.End()
+	assert.Equal(t, 20204, dt)
+	assert.Equal(t, 103.0, v)
+
+	p, e := s.Projected(20202)
+	assert.Nil(t, e)
+	assert.False(t, p)
+
+	p, e = s.Projected(20203)
+	assert.Nil(t, e)
+	assert.True(t, p)
+
+	_, e = s.Projected(20211)
+	assert.NotNil(t, e)
+
+	// Upsert publishes the projected quarter
+	assert.Nil(t, s.Upsert([]int{20203}, []float64{102.5}))
+	p, _ = s.Projected(20203)
+	assert.False(t, p)
+	p, _ = s.Projected(20204)
+	assert.True(t, p)
+
+	w, e := s.Window(20203, 20204)
+	assert.Nil(t, e)
+	p, _ = w.Projected(20204)
+	assert.True(t, p)
+}
+
+func TestHPIdata_End(t *testing.T) {
+	hd := testData()
+	assert.Nil(t, hd.ApplyScenario(Scenario{Path: []float64{0.01, 0.01}}, 20094))
+
+	dt, _, e := hd.LastLoaded("TX")
+	assert.Nil(t, e)
+	assert.Equal(t, 20094, dt)
+
+	dt, _, e = hd.End("TX")
+	assert.Nil(t, e)
+	assert.Equal(t, 20102, dt)
+
+	s, _ := hd.Geo("TX")
+	ext, e := s.Extend(2, TrendModel{})
+	assert.Nil(t, e)
+	p, _ := ext.Projected(20104)
+	assert.True(t, p)
+	p, _ = ext.Projected(20094)
+	assert.False(t, p)
+
+	_, _, e = hd.End("ZZ")
+	assert.NotNil(t, e)
+}
+
+func TestHPIseries_Provenance(t *testing.T) {
+	s, e := NewHPIseries("CA", "CA", []int{20201, 20202}, []float64{100, 101})
+	assert.Nil(t, e)
+	assert.Nil(t, s.Append([]int{20203}, []float64{102}))
+
+	p, e := s.Provenance(20202)
+	assert.Nil(t, e)
+	assert.Equal(t, Published, p)
+
+	v, p, e := s.IndexProvenance(20203)
+	assert.Nil(t, e)
+	assert.Equal(t, 102.0, v)
+	assert.Equal(t, Projected, p)
+	assert.Equal(t, "projected", p.String())
+
+	_, e = s.Provenance(20204)
+	assert.NotNil(t, e)
+
+	assert.Nil(t, s.SetExtrapolation(Extrapolation{Method: ExtrapFlat}))
+	p, e = s.Provenance(20204)
+	assert.Nil(t, e)
+	assert.Equal(t, Projected, p)
+
+	hd := testData()
+	p, e = hd.Provenance("CA", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, Published, p)
+}
@@ -0,0 +1,99 @@
+package fhfa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PropertyType distinguishes the collateral types that use different FHFA indices.
+type PropertyType int
+
+const (
+	// SiteBuilt is all property other than manufactured housing.  Its lookups skip the mh series.
+	SiteBuilt PropertyType = iota
+
+	// Manufactured is manufactured housing.  Its lookups use the mh series first, then the geographic ones.
+	Manufactured
+)
+
+// String returns the name of pt.
+func (pt PropertyType) String() string {
+	switch pt {
+	case SiteBuilt:
+		return "site-built"
+	case Manufactured:
+		return "manufactured"
+	default:
+		return fmt.Sprintf("PropertyType(%d)", int(pt))
+	}
+}
+
+// ParsePropertyType converts an agency loan-level property type code (SF, PU, CO, CP or MH) to a PropertyType.
+// An empty code is site-built.
+func ParsePropertyType(code string) (PropertyType, error) {
+	switch strings.ToUpper(strings.TrimSpace(code)) {
+	case "", "SF", "PU", "CO", "CP":
+		return SiteBuilt, nil
+	case "MH":
+		return Manufactured, nil
+	default:
+		return 0, fmt.Errorf("unknown property type: %s", code)
+	}
+}
+
+// Route returns the keys and HPIdata to search, in order, for property of type pt.  They are those of Keys,
+// with the mh levels moved to the front for manufactured housing and dropped otherwise.
+func (fc *FallbackChain) Route(pt PropertyType, zip3, cbsa, state string) (keys []string, hpis []*HPIdata, e error) {
+	if pt != SiteBuilt && pt != Manufactured {
+		return nil, nil, fmt.Errorf("invalid property type: %d", pt)
+	}
+
+	all := fc.Keys(zip3, cbsa, state)
+	for j, hd := range fc.hpis {
+		if hd.geoLevel == "mh" && pt == Manufactured {
+			keys, hpis = append(keys, all[j]), append(hpis, hd)
+		}
+	}
+
+	for j, hd := range fc.hpis {
+		if hd.geoLevel != "mh" {
+			keys, hpis = append(keys, all[j]), append(hpis, hd)
+		}
+	}
+
+	if len(hpis) == 0 {
+		return nil, nil, fmt.Errorf("no %s series in fallback chain", pt)
+	}
+
+	return keys, hpis, nil
+}
+
+// LookupType returns the house price index at dt (CCYYQ) for property of type pt and the geo level of the
+// series used.  See Route.
+func (fc *FallbackChain) LookupType(pt PropertyType, zip3, cbsa, state string, dt int) (hpi float64, geoLevel string, e error) {
+	if e := fc.checkTerritory(state); e != nil {
+		return 0, "", e
+	}
+
+	keys, hpis, e := fc.Route(pt, zip3, cbsa, state)
+	if e != nil {
+		return 0, "", e
+	}
+
+	return fc.best(dt, keys, hpis)
+}
+
+// ChangeType returns the ratio of the index at dtEnd (CCYYQ) to dtStart (CCYYQ) for property of type pt and
+// the geo level of the series used.  See Route.
+func (fc *FallbackChain) ChangeType(pt PropertyType, zip3, cbsa, state string, dtStart, dtEnd int) (ratio float64, geoLevel string, e error) {
+	if e := fc.checkTerritory(state); e != nil {
+		return 0, "", e
+	}
+
+	keys, hpis, e := fc.Route(pt, zip3, cbsa, state)
+	if e != nil {
+		return 0, "", e
+	}
+
+	return fc.bestChange(dtStart, dtEnd, keys, hpis)
+}
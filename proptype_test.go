@@ -0,0 +1,52 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallbackChain_LookupType(t *testing.T) {
+	mh := testLevel(testUS(), "mh")
+	for _, s := range mh.series {
+		s.indx[len(s.indx)-1] = 1
+	}
+
+	fc, e := NewFallbackChain(testData(), testUS(), mh)
+	assert.Nil(t, e)
+
+	keys, hpis, e := fc.Route(Manufactured, "900", "", "CA")
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"USA", "CA", "USA"}, keys)
+	assert.Equal(t, "mh", hpis[0].GeoLevel())
+
+	_, hpis, e = fc.Route(SiteBuilt, "900", "", "CA")
+	assert.Nil(t, e)
+	assert.Len(t, hpis, 2)
+
+	_, level, e := fc.LookupType(Manufactured, "900", "", "CA", 20094)
+	assert.Nil(t, e)
+	assert.Equal(t, "mh", level)
+
+	_, level, e = fc.LookupType(SiteBuilt, "900", "", "CA", 20094)
+	assert.Nil(t, e)
+	assert.Equal(t, "state", level)
+
+	_, level, e = fc.ChangeType(Manufactured, "900", "", "CA", 20091, 20092)
+	assert.Nil(t, e)
+	assert.Equal(t, "mh", level)
+
+	pt, e := ParsePropertyType(" mh")
+	assert.Nil(t, e)
+	assert.Equal(t, Manufactured, pt)
+
+	pt, e = ParsePropertyType("CO")
+	assert.Nil(t, e)
+	assert.Equal(t, "site-built", pt.String())
+
+	_, e = ParsePropertyType("XX")
+	assert.NotNil(t, e)
+
+	_, _, e = fc.Route(PropertyType(5), "900", "", "CA")
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,117 @@
+package fhfa
+
+import "fmt"
+
+// Provenance describes where an observation of a series came from.
+type Provenance int
+
+const (
+	// Published values were loaded from the source (e.g. the FHFA file).
+	Published Provenance = iota
+
+	// Projected values were appended by the user (e.g. by Append, ApplyScenario or Extend).
+	Projected
+
+	// Filled values were interpolated into missing quarters by FillGaps.
+	Filled
+
+	// Imputed values were derived from the growth of a parent geography by Backfill.
+	Imputed
+)
+
+// String returns the name of the provenance.
+func (p Provenance) String() string {
+	switch p {
+	case Published:
+		return "published"
+	case Projected:
+		return "projected"
+	case Filled:
+		return "filled"
+	case Imputed:
+		return "imputed"
+	default:
+		return fmt.Sprintf("Provenance(%d)", int(p))
+	}
+}
+
+// Provenance returns the provenance of the value Index returns at dt (CCYYQ).  Extrapolated values are
+// Projected.
+func (h *HPIseries) Provenance(dt int) (Provenance, error) {
+	_, p, e := h.IndexProvenance(dt)
+
+	return p, e
+}
+
+// IndexProvenance returns the index at dt (CCYYQ), as Index does, along with its provenance.
+func (h *HPIseries) IndexProvenance(dt int) (float64, Provenance, error) {
+	indx, e := h.position(dt, h.match)
+	if e != nil {
+		if dt > h.dates[len(h.dates)-1] && h.extrap.Method != ExtrapNone {
+			v, e1 := h.extrapolate(dt)
+			return v, Projected, e1
+		}
+
+		return 0, Published, e
+	}
+
+	return h.indx[indx], h.provenance(indx), nil
+}
+
+// Provenance returns the provenance of the value for geo at dt (CCYYQ).
+func (hd *HPIdata) Provenance(geo string, dt int) (Provenance, error) {
+	s, e := hd.Geo(geo)
+	if e != nil {
+		return Published, e
+	}
+
+	return s.Provenance(dt)
+}
+
+// provenance returns the provenance of the jth observation of h.
+func (h *HPIseries) provenance(j int) Provenance {
+	if h.prov == nil {
+		return Published
+	}
+
+	return h.prov[j]
+}
+
+// flag sets the provenance of the observations of h from index from on to p.  Observations without a
+// provenance are published.
+func (h *HPIseries) flag(from int, p Provenance) {
+	if h.prov == nil && p == Published {
+		return
+	}
+
+	for len(h.prov) < len(h.dates) {
+		h.prov = append(h.prov, Published)
+	}
+
+	for j := from; j < len(h.prov); j++ {
+		h.prov[j] = p
+	}
+}
+
+// carryFlags sets the provenance of the observations of h to that of the same dates in src.
+func (h *HPIseries) carryFlags(src *HPIseries) {
+	if src.prov == nil {
+		return
+	}
+
+	h.prov = make([]Provenance, len(h.dates))
+	for k, dt := range h.dates {
+		if j := src.exact(dt); j >= 0 {
+			h.prov[k] = src.prov[j]
+		}
+	}
+}
+
+// copyFlags returns a copy of prov[first:last], nil if prov is nil.
+func copyFlags(prov []Provenance, first, last int) []Provenance {
+	if prov == nil {
+		return nil
+	}
+
+	return append([]Provenance{}, prov[first:last]...)
+}
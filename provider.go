@@ -0,0 +1,74 @@
+package fhfa
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// IndexProvider is a source of house price indices keyed by geo.  HPIdata implements it, whether loaded from
+// FHFA data or another publisher (e.g. LoadCaseShiller), so indices from different publishers can be compared
+// or blended through one API.
+type IndexProvider interface {
+	// Index returns the index for geo at dt (CCYYQ).
+	Index(geo string, dt int) (float64, error)
+
+	// Change returns the ratio of the index at dtEnd (CCYYQ) to dtStart (CCYYQ) for geo.
+	Change(geo string, dtStart, dtEnd int) (float64, error)
+
+	// Geos returns the geos with data.
+	Geos() []string
+}
+
+var _ IndexProvider = (*HPIdata)(nil)
+
+// Providers is an IndexProvider that blends several providers, ordered by preference.  A lookup is answered
+// by the first provider that can answer it.
+type Providers []IndexProvider
+
+var _ IndexProvider = Providers(nil)
+
+// Index returns the index for geo at dt (CCYYQ) from the first provider with it.
+func (p Providers) Index(geo string, dt int) (float64, error) {
+	return first(p, func(ip IndexProvider) (float64, error) { return ip.Index(geo, dt) })
+}
+
+// Change returns the ratio of the index at dtEnd (CCYYQ) to dtStart (CCYYQ) for geo from the first provider
+// with both quarters.
+func (p Providers) Change(geo string, dtStart, dtEnd int) (float64, error) {
+	return first(p, func(ip IndexProvider) (float64, error) { return ip.Change(geo, dtStart, dtEnd) })
+}
+
+// Geos returns the geos of any provider, in order.
+func (p Providers) Geos() []string {
+	var geos []string
+	for _, ip := range p {
+		for _, geo := range ip.Geos() {
+			if !in(geo, geos) {
+				geos = append(geos, geo)
+			}
+		}
+	}
+	sort.Strings(geos)
+
+	return geos
+}
+
+// first returns the result of fn for the first provider for which it succeeds.
+func first(p Providers, fn func(ip IndexProvider) (float64, error)) (float64, error) {
+	if len(p) == 0 {
+		return 0, fmt.Errorf("no index providers")
+	}
+
+	var errs []error
+	for _, ip := range p {
+		v, e := fn(ip)
+		if e == nil {
+			return v, nil
+		}
+
+		errs = append(errs, e)
+	}
+
+	return 0, errors.Join(errs...)
+}
@@ -0,0 +1,246 @@
+package fhfa
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/invertedv/dass"
+)
+
+// Series IDs of the price indices most used to deflate house prices.
+const (
+	CPIUSeries    = "CPIAUCNS"    // FRED ID of the CPI-U, all items, not seasonally adjusted
+	PCESeries     = "PCEPI"       // FRED ID of the PCE chain-type price index
+	BLSCPIUSeries = "CUUR0000SA0" // BLS ID of the CPI-U, all items, not seasonally adjusted
+)
+
+// BLSCPIURL is the address of the BLS flat file of the all-items CPI series.
+const BLSCPIURL = "https://download.bls.gov/pub/time.series/cu/cu.data.1.AllItems"
+
+// FREDURL returns the CSV download address of a FRED series (e.g. CPIAUCNS for the CPI-U, PCEPI for the PCE
+// price index).
+func FREDURL(seriesID string) string {
+	return "https://fred.stlouisfed.org/graph/fredgraph.csv?id=" + seriesID
+}
+
+// LoadCPI loads a price index from source - either a local file or a web address - in the FRED CSV layout
+// (a header row followed by date,value rows).  Monthly data are averaged to quarters, keeping only quarters
+// with all 3 months present.  Quarterly data are used as is.
+func LoadCPI(source string) (*HPIseries, error) {
+	var (
+		r    [][]string
+		rows *dass.Rows
+		e    error
+	)
+
+	if r, e = dass.FetchCSV(source); e != nil {
+		return nil, e
+	}
+
+	if rows, e = dass.ParseRows(r, []string{"date", "value"}, []string{"date", "float"}, []string{"skip", "skip"}, 0); e != nil {
+		return nil, e
+	}
+
+	var (
+		ts   []time.Time
+		vals []float64
+	)
+	for _, row := range rows.Iter() {
+		ts = append(ts, row["date"].(time.Time))
+		vals = append(vals, row["value"].(float64))
+	}
+
+	dts, indx := toQuarterly(ts, vals)
+	if len(dts) == 0 {
+		return nil, fmt.Errorf("no data in %s", source)
+	}
+
+	return NewHPIseries("CPI", "", dts, indx)
+}
+
+// LoadCPIU loads the CPI-U from FRED, averaged to quarters.
+func LoadCPIU() (*HPIseries, error) {
+	return loadFREDPrices(CPIUSeries, "CPI-U")
+}
+
+// LoadPCE loads the PCE price index from FRED, averaged to quarters.
+func LoadPCE() (*HPIseries, error) {
+	return loadFREDPrices(PCESeries, "PCE")
+}
+
+// loadFREDPrices loads the FRED series id and names it name.
+func loadFREDPrices(id, name string) (*HPIseries, error) {
+	s, e := LoadCPI(FREDURL(id))
+	if e != nil {
+		return nil, e
+	}
+
+	s.geoName = name
+
+	return s, nil
+}
+
+// LoadBLS loads the monthly series seriesID (e.g. BLSCPIUSeries) from source - either a local file or a web
+// address (e.g. BLSCPIURL) - in the BLS time-series flat file layout: tab-separated series_id, year, period
+// (M01 to M12) and value.  Annual averages (M13) and other periods are ignored.  The monthly values are
+// averaged to quarters, keeping only quarters with all 3 months present.
+func LoadBLS(source, seriesID string) (*HPIseries, error) {
+	text, e := fetchText(source)
+	if e != nil {
+		return nil, e
+	}
+
+	var (
+		ts   []time.Time
+		vals []float64
+	)
+	for _, line := range strings.Split(text, "\n") {
+		flds := strings.Split(line, "\t")
+		if len(flds) < 4 || strings.TrimSpace(flds[0]) != seriesID {
+			continue
+		}
+
+		period := strings.TrimSpace(flds[2])
+		mo, e := strconv.Atoi(strings.TrimPrefix(period, "M"))
+		if !strings.HasPrefix(period, "M") || e != nil || mo < 1 || mo > 12 {
+			continue
+		}
+
+		yr, e := strconv.Atoi(strings.TrimSpace(flds[1]))
+		if e != nil {
+			return nil, fmt.Errorf("bad year in %s: %s", source, flds[1])
+		}
+
+		v, e := strconv.ParseFloat(strings.TrimSpace(flds[3]), 64)
+		if e != nil {
+			continue
+		}
+
+		ts = append(ts, time.Date(yr, time.Month(mo), 1, 0, 0, 0, 0, time.UTC))
+		vals = append(vals, v)
+	}
+
+	dts, indx := toQuarterly(ts, vals)
+	if len(dts) == 0 {
+		return nil, fmt.Errorf("series %s not in %s", seriesID, source)
+	}
+
+	return NewHPIseries(seriesID, "", dts, indx)
+}
+
+// fetchText returns the contents of source, either a local file or a web address.
+func fetchText(source string) (string, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		b, e := os.ReadFile(source)
+
+		return string(b), e
+	}
+
+	req, e := http.NewRequest(http.MethodGet, source, nil)
+	if e != nil {
+		return "", e
+	}
+
+	// the BLS rejects requests without a user agent that identifies the caller
+	req.Header.Set("User-Agent", "github.com/invertedv/fhfa")
+
+	resp, e := http.DefaultClient.Do(req)
+	if e != nil {
+		return "", e
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", source, resp.Status)
+	}
+
+	b, e := io.ReadAll(resp.Body)
+
+	return string(b), e
+}
+
+// toQuarterly converts observations at dates ts to quarters.  Monthly data are averaged to quarters, keeping only
+// quarters with all 3 months present.  Quarterly data are used as is.  The result is in date order.
+func toQuarterly(ts []time.Time, vals []float64) (dts []int, indx []float64) {
+	sums := make(map[int]float64)
+	counts := make(map[int]int)
+	monthly := false
+	for j, t := range ts {
+		if (t.Month()-1)%3 != 0 {
+			monthly = true
+		}
+
+		q := ToYrQtr(t)
+		sums[q] += vals[j]
+		counts[q]++
+	}
+
+	for q, n := range counts {
+		if monthly && n != 3 {
+			continue
+		}
+
+		dts = append(dts, q)
+	}
+
+	sort.Ints(dts)
+	indx = make([]float64, len(dts))
+	for j, q := range dts {
+		indx[j] = sums[q] / float64(counts[q])
+	}
+
+	return dts, indx
+}
+
+// Deflate returns the real (inflation-adjusted) version of h using the price index cpi.  The real series
+// covers the quarters of h within the range of cpi and equals h at its first quarter.
+func (h *HPIseries) Deflate(cpi *HPIseries) (*HPIseries, error) {
+	var (
+		dts  []int
+		indx []float64
+		base float64
+	)
+
+	lastDt, lastIndx := 0, 0.0
+	for j, dt := range h.dates {
+		p, e := cpi.Index(dt)
+		if e != nil {
+			continue
+		}
+
+		if base == 0 {
+			base = p
+		}
+
+		v := h.indx[j] * base / p
+		dts = append(dts, dt)
+		indx = append(indx, v)
+
+		if dt <= h.lastDt {
+			lastDt, lastIndx = dt, v
+		}
+	}
+
+	if len(dts) == 0 {
+		return nil, fmt.Errorf("no overlap between series and price index")
+	}
+
+	rs, e := NewHPIseries(h.geoName, h.geoCode, dts, indx)
+	if e != nil {
+		return nil, e
+	}
+
+	if lastDt > 0 {
+		rs.lastDt, rs.lastIndx = lastDt, lastIndx
+	}
+
+	rs.carryFlags(h)
+
+	return rs, nil
+}
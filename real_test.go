@@ -0,0 +1,88 @@
+package fhfa
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCPI(t *testing.T) {
+	var csv strings.Builder
+	csv.WriteString("observation_date,CPIAUCNS\n")
+	for m := range 7 {
+		csv.WriteString(fmt.Sprintf("2020-%02d-01,%d\n", m+1, 100+m))
+	}
+
+	file := fmt.Sprintf("%s/cpi.csv", os.TempDir())
+	assert.Nil(t, os.WriteFile(file, []byte(csv.String()), 0o644))
+	defer os.Remove(file)
+
+	cpi, e := LoadCPI(file)
+	assert.Nil(t, e)
+
+	// July is an incomplete quarter
+	dt, v := cpi.Last()
+	assert.Equal(t, 20202, dt)
+	assert.InEpsilon(t, 104.0, v, 1e-9)
+
+	v, e = cpi.Index(20201)
+	assert.Nil(t, e)
+	assert.InEpsilon(t, 101.0, v, 1e-9)
+}
+
+func TestLoadBLS(t *testing.T) {
+	var txt strings.Builder
+	txt.WriteString("series_id        \tyear\tperiod\t       value\tfootnote_codes\n")
+	for m := range 6 {
+		txt.WriteString(fmt.Sprintf("CUUR0000SA0      \t2020\tM%02d\t%12.3f\t\n", m+1, 200.0+float64(m)))
+		txt.WriteString(fmt.Sprintf("CUUR0000SA0E     \t2020\tM%02d\t%12.3f\t\n", m+1, 50.0))
+	}
+	txt.WriteString("CUUR0000SA0      \t2020\tM13\t     999.000\t\n")
+
+	file := fmt.Sprintf("%s/cu.data", t.TempDir())
+	assert.Nil(t, os.WriteFile(file, []byte(txt.String()), 0o644))
+
+	cpi, e := LoadBLS(file, BLSCPIUSeries)
+	assert.Nil(t, e)
+	assert.Equal(t, []int{20201, 20202}, cpi.Dates())
+	assert.InEpsilon(t, 204.0, cpi.Values()[1], 1e-9)
+
+	_, e = LoadBLS(file, "XXX")
+	assert.NotNil(t, e)
+}
+
+func TestHPIseries_Deflate(t *testing.T) {
+	s := testData().series["TX"]
+
+	// prices grow at the same rate as TX, so the real index is flat
+	var (
+		dts  []int
+		indx []float64
+	)
+	dt, p := 19991, 50.0
+	for range 60 {
+		dts = append(dts, dt)
+		indx = append(indx, p)
+		dt = NextQtr(dt)
+		p *= 1 + testGrowth["TX"]
+	}
+
+	cpi, e := NewHPIseries("CPI", "", dts, indx)
+	assert.Nil(t, e)
+
+	rs, e := s.Deflate(cpi)
+	assert.Nil(t, e)
+
+	for j, dt := range rs.dates {
+		assert.Equal(t, s.dates[j], dt)
+		assert.InEpsilon(t, 100.0, rs.indx[j], 1e-9)
+	}
+
+	short, e := NewHPIseries("CPI", "", []int{19801}, []float64{1})
+	assert.Nil(t, e)
+	_, e = s.Deflate(short)
+	assert.NotNil(t, e)
+}
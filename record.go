@@ -0,0 +1,60 @@
+package fhfa
+
+import "fmt"
+
+// Record is a single quarter of a series in long format.  It is the common form the exporters and importers
+// work from, and the simplest way to move the data to and from other systems.
+type Record struct {
+	GeoLevel string  // geo level, e.g. state
+	Geo      string  // geo name, the metro name for metro data
+	Code     string  // geo key (e.g. TX, 10180)
+	YrQtr    int     // date (CCYYQ)
+	Index    float64 // index value
+}
+
+// Records returns the data in long format, one Record per geo and quarter in geo and date order.
+func (hd *HPIdata) Records() []Record {
+	var recs []Record
+	for geo, s := range hd.All() {
+		for j, dt := range s.dates {
+			recs = append(recs, Record{GeoLevel: hd.geoLevel, Geo: s.geoName, Code: geo, YrQtr: dt, Index: s.indx[j]})
+		}
+	}
+
+	return recs
+}
+
+// FromRecords builds HPIdata from recs, which may be in any order.  Every record must have the same, valid
+// GeoLevel and a valid date, and a geo may not repeat a quarter.
+func FromRecords(recs []Record) (*HPIdata, error) {
+	if len(recs) == 0 {
+		return nil, fmt.Errorf("no records")
+	}
+
+	hd := &HPIdata{
+		geoLevel: recs[0].GeoLevel,
+		series:   make(map[string]*HPIseries),
+	}
+
+	if e := checkGeoLevel(hd.geoLevel); e != nil {
+		return nil, e
+	}
+
+	for _, r := range recs {
+		if r.GeoLevel != hd.geoLevel {
+			return nil, fmt.Errorf("records have different geo levels: %s, %s", hd.geoLevel, r.GeoLevel)
+		}
+
+		if !YrQtr(r.YrQtr).Valid() {
+			return nil, badDate(r.YrQtr)
+		}
+
+		hd.add(r.Code, r.Geo, r.YrQtr, r.Index)
+	}
+
+	if e := hd.finish(); e != nil {
+		return nil, e
+	}
+
+	return hd, nil
+}
@@ -0,0 +1,43 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_Records(t *testing.T) {
+	hd := testData()
+	recs := hd.Records()
+	assert.Equal(t, 120, len(recs))
+	assert.Equal(t, Record{GeoLevel: "state", Geo: "CA", Code: "CA", YrQtr: 20001, Index: 100}, recs[0])
+	assert.Equal(t, "NY", recs[40].Code)
+
+	// order doesn't matter
+	recs[0], recs[119] = recs[119], recs[0]
+	hd1, e := FromRecords(recs)
+	assert.Nil(t, e)
+	ok, diff := hd1.Equal(hd, 0)
+	assert.True(t, ok, diff)
+
+	recs[5].GeoLevel = "metro"
+	_, e = FromRecords(recs)
+	assert.NotNil(t, e)
+
+	recs[5].GeoLevel, recs[6].YrQtr = "state", 20005
+	_, e = FromRecords(recs)
+	assert.NotNil(t, e)
+
+	recs[6].YrQtr = recs[7].YrQtr
+	_, e = FromRecords(recs)
+	assert.NotNil(t, e)
+
+	for j := range recs {
+		recs[j].GeoLevel = "sate"
+	}
+	_, e = FromRecords(recs)
+	assert.ErrorContains(t, e, "invalid geo level")
+
+	_, e = FromRecords(nil)
+	assert.NotNil(t, e)
+}
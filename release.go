@@ -0,0 +1,154 @@
+package fhfa
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// ReleaseRow is the line of a geo in the summary of a quarterly release.  The changes are the ratio minus 1,
+// so 0.05 is 5% appreciation, and are NaN if the series doesn't have the earlier quarter.
+type ReleaseRow struct {
+	Geo      string  // geo of the series
+	Name     string  // name of the geo
+	Dt       int     // quarter (CCYYQ)
+	Index    float64 // index at Dt
+	QoQ      float64 // change from the prior quarter
+	YoY      float64 // change from 4 quarters earlier
+	FiveYear float64 // change from 20 quarters earlier
+}
+
+// ReleaseTable summarizes a quarterly release, one row per geo.  It sorts by geo; use SortBy for other orders.
+type ReleaseTable []ReleaseRow
+
+func (rt ReleaseTable) Len() int           { return len(rt) }
+func (rt ReleaseTable) Less(i, j int) bool { return rt[i].Geo < rt[j].Geo }
+func (rt ReleaseTable) Swap(i, j int)      { rt[i], rt[j] = rt[j], rt[i] }
+
+// SortBy sorts rt by less, keeping geo order among ties.
+func (rt ReleaseTable) SortBy(less func(a, b *ReleaseRow) bool) {
+	sort.SliceStable(rt, func(i, j int) bool { return less(&rt[i], &rt[j]) })
+}
+
+// ReleaseTable returns the index level and the quarter-over-quarter, year-over-year and 5-year changes at dt
+// (CCYYQ) for every geo in hd with data at dt, sorted by geo.
+func (hd *HPIdata) ReleaseTable(dt int) (ReleaseTable, error) {
+	if !YrQtr(dt).Valid() {
+		return nil, badDate(dt)
+	}
+
+	var rt ReleaseTable
+	for geo, s := range hd.All() {
+		k := s.exact(dt)
+		if k < 0 {
+			continue
+		}
+
+		change := func(qtrs int) float64 {
+			if j := s.exact(AddQtrs(dt, -qtrs)); j >= 0 {
+				return s.indx[k]/s.indx[j] - 1
+			}
+
+			return math.NaN()
+		}
+
+		rt = append(rt, ReleaseRow{
+			Geo:      geo,
+			Name:     s.geoName,
+			Dt:       dt,
+			Index:    s.indx[k],
+			QoQ:      change(1),
+			YoY:      change(4),
+			FiveYear: change(20),
+		})
+	}
+
+	if len(rt) == 0 {
+		return nil, fmt.Errorf("%w: no geo has data at %d", ErrDateMissing, dt)
+	}
+
+	return rt, nil
+}
+
+// Save saves rt as a CSV with the columns geo, name, dt, index, qoq, yoy and fiveYear.  Missing changes
+// are blank.
+func (rt ReleaseTable) Save(localFile string) error {
+	file, e := create(localFile)
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if e := w.Write([]string{"geo", "name", "dt", "index", "qoq", "yoy", "fiveYear"}); e != nil {
+		return e
+	}
+
+	for _, r := range rt {
+		row := []string{r.Geo, r.Name, strconv.Itoa(r.Dt), formatValue(r.Index), formatValue(r.QoQ),
+			formatValue(r.YoY), formatValue(r.FiveYear)}
+		if e := w.Write(row); e != nil {
+			return e
+		}
+	}
+
+	if w.Flush(); w.Error() != nil {
+		return w.Error()
+	}
+
+	return file.Close()
+}
+
+// SaveJSON saves rt as a JSON array.  Missing changes are null.
+func (rt ReleaseTable) SaveJSON(localFile string) error {
+	file, e := create(localFile)
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	if e := json.NewEncoder(w).Encode(rt); e != nil {
+		return e
+	}
+
+	if e := w.Flush(); e != nil {
+		return e
+	}
+
+	return file.Close()
+}
+
+// MarshalJSON encodes r with lower-case keys and missing changes as null.
+func (r ReleaseRow) MarshalJSON() ([]byte, error) {
+	value := func(x float64) *float64 {
+		if math.IsNaN(x) {
+			return nil
+		}
+
+		return &x
+	}
+
+	return json.Marshal(struct {
+		Geo      string   `json:"geo"`
+		Name     string   `json:"name"`
+		Dt       int      `json:"dt"`
+		Index    float64  `json:"index"`
+		QoQ      *float64 `json:"qoq"`
+		YoY      *float64 `json:"yoy"`
+		FiveYear *float64 `json:"fiveYear"`
+	}{r.Geo, r.Name, r.Dt, r.Index, value(r.QoQ), value(r.YoY), value(r.FiveYear)})
+}
+
+// formatValue formats x for a CSV, blank if it is NaN.
+func formatValue(x float64) string {
+	if math.IsNaN(x) {
+		return ""
+	}
+
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
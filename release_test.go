@@ -0,0 +1,63 @@
+package fhfa
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_ReleaseTable(t *testing.T) {
+	hd := testData()
+	s, _ := hd.Geo("TX")
+	assert.Nil(t, s.Append([]int{20101}, []float64{200}))
+
+	rt, e := hd.ReleaseTable(20051)
+	assert.Nil(t, e)
+	assert.Equal(t, 3, rt.Len())
+	assert.Equal(t, "CA", rt[0].Geo)
+	assert.InDelta(t, 0.02, rt[0].QoQ, 1e-12)
+	assert.InDelta(t, math.Pow(1.02, 4)-1, rt[0].YoY, 1e-12)
+	assert.InDelta(t, math.Pow(1.02, 20)-1, rt[0].FiveYear, 1e-12)
+
+	rt.SortBy(func(a, b *ReleaseRow) bool { return a.YoY < b.YoY })
+	assert.Equal(t, []string{"NY", "TX", "CA"}, []string{rt[0].Geo, rt[1].Geo, rt[2].Geo})
+
+	// only TX has 20101
+	rt, e = hd.ReleaseTable(20101)
+	assert.Nil(t, e)
+	assert.Equal(t, 1, rt.Len())
+	assert.InDelta(t, 200.0/s.indx[39]-1, rt[0].QoQ, 1e-12)
+
+	// no history 5 years back
+	rt, e = hd.ReleaseTable(20031)
+	assert.Nil(t, e)
+	assert.True(t, math.IsNaN(rt[0].FiveYear))
+
+	dir := t.TempDir()
+	assert.Nil(t, rt.Save(filepath.Join(dir, "release.csv")))
+	b, e := os.ReadFile(filepath.Join(dir, "release.csv"))
+	assert.Nil(t, e)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Equal(t, "geo,name,dt,index,qoq,yoy,fiveYear", lines[0])
+	assert.Equal(t, 4, len(lines))
+	assert.True(t, strings.HasPrefix(lines[1], "CA,CA,20031,"))
+	assert.True(t, strings.HasSuffix(lines[1], ","))
+
+	assert.Nil(t, rt.SaveJSON(filepath.Join(dir, "release.json")))
+	b, e = os.ReadFile(filepath.Join(dir, "release.json"))
+	assert.Nil(t, e)
+	var rows []map[string]any
+	assert.Nil(t, json.Unmarshal(b, &rows))
+	assert.Nil(t, rows[0]["fiveYear"])
+	assert.InDelta(t, 100*math.Pow(1.02, 12), rows[0]["index"], 1e-9)
+
+	_, e = hd.ReleaseTable(20111)
+	assert.ErrorIs(t, e, ErrDateMissing)
+	_, e = hd.ReleaseTable(20115)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,117 @@
+package fhfa
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/cbsa_changes.csv
+var cbsaChangesCSV string
+
+// CBSAChange records an OMB redefinition that replaced CBSA Old by New.
+type CBSAChange struct {
+	Old     string // code before the redefinition
+	New     string // code after the redefinition
+	Vintage int    // year of the OMB delineation that made the change
+}
+
+// CBSAChanges returns the embedded table of CBSA code changes, in vintage order.  The table is not
+// complete; add to it with ReadCBSAChanges or by hand.
+func CBSAChanges() []CBSAChange {
+	chg, e := ReadCBSAChanges(cbsaChangesCSV)
+	if e != nil {
+		panic(e)
+	}
+
+	return chg
+}
+
+// ReadCBSAChanges parses a CSV of CBSA code changes with columns old, new and vintage and a header row.
+func ReadCBSAChanges(csvText string) ([]CBSAChange, error) {
+	recs, e := csv.NewReader(strings.NewReader(csvText)).ReadAll()
+	if e != nil {
+		return nil, e
+	}
+
+	if len(recs) == 0 {
+		return nil, fmt.Errorf("no CBSA changes")
+	}
+
+	var chg []CBSAChange
+	for _, rec := range recs[1:] {
+		if len(rec) != 3 {
+			return nil, fmt.Errorf("bad CBSA change: %v", rec)
+		}
+
+		vintage, e := strconv.Atoi(strings.TrimSpace(rec[2]))
+		if e != nil {
+			return nil, fmt.Errorf("bad CBSA change vintage: %s", rec[2])
+		}
+
+		chg = append(chg, CBSAChange{
+			Old:     NormalizeGeo("metro", rec[0]),
+			New:     NormalizeGeo("metro", rec[1]),
+			Vintage: vintage,
+		})
+	}
+
+	sort.SliceStable(chg, func(i, j int) bool { return chg[i].Vintage < chg[j].Vintage })
+
+	return chg, nil
+}
+
+// RemapCBSAs applies changes, in vintage order, to metro data so the history of a redefined metro stays
+// continuous.  For each change whose old code is in hd:
+//
+//   - if the new code is not in hd, the series is moved to the new code
+//   - otherwise the new series is extended back by the growth of the old one, which must include the first
+//     quarter of the new series
+//
+// The old code is then an alias of the new one (see Alias).  It returns the changes applied.
+func (hd *HPIdata) RemapCBSAs(changes []CBSAChange) ([]CBSAChange, error) {
+	if hd.geoLevel != "metro" {
+		return nil, fmt.Errorf("need metro data, got %s", hd.geoLevel)
+	}
+
+	changes = append([]CBSAChange{}, changes...)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Vintage < changes[j].Vintage })
+
+	var applied []CBSAChange
+	for _, c := range changes {
+		old, ok := hd.series[c.Old]
+		if !ok {
+			continue
+		}
+
+		s := old.Copy()
+		s.geoCode = c.New
+		if cur, ok := hd.series[c.New]; ok {
+			dt, v := cur.First()
+			spliced, e := old.Splice(cur, dt)
+			if e != nil {
+				return applied, fmt.Errorf("CBSA %s -> %s: %v", c.Old, c.New, e)
+			}
+
+			// keep the published level of the new series
+			scale := v / spliced.indx[spliced.exact(dt)]
+			s = spliced.Apply(func(_ int, x float64) float64 { return x * scale })
+		}
+
+		hd.series[c.New] = s
+		delete(hd.series, c.Old)
+
+		if e := hd.Alias(c.Old, c.New); e != nil {
+			return applied, e
+		}
+
+		applied = append(applied, c)
+	}
+
+	hd.shareDates()
+
+	return applied, nil
+}
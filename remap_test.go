@@ -0,0 +1,61 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_RemapCBSAs(t *testing.T) {
+	mk := func(code string, start, n int, base float64) *HPIseries {
+		var (
+			dts  []int
+			indx []float64
+		)
+
+		for j, dt := 0, start; j < n; j, dt = j+1, NextQtr(dt) {
+			dts = append(dts, dt)
+			indx = append(indx, base+float64(j))
+		}
+
+		s, _ := NewHPIseries(code, code, dts, indx)
+
+		return s
+	}
+
+	hd, e := NewHPIdata("metro", map[string]*HPIseries{
+		"26180": mk("26180", 20001, 20, 100),
+		"46520": mk("46520", 20031, 10, 200),
+		"42260": mk("42260", 20001, 4, 100),
+	})
+	assert.Nil(t, e)
+
+	chg := CBSAChanges()
+	assert.Equal(t, CBSAChange{Old: "26180", New: "46520", Vintage: 2013}, chg[0])
+
+	applied, e := hd.RemapCBSAs(chg)
+	assert.Nil(t, e)
+	assert.Len(t, applied, 2)
+	assert.ElementsMatch(t, []string{"46520", "35840"}, hd.Geos())
+
+	s, _ := hd.Geo("46520")
+	assert.Equal(t, 22, s.Len())
+	first, v := s.First()
+	assert.Equal(t, 20001, first)
+	assert.InDelta(t, 100*200.0/112, v, 1e-9)
+	v, _ = s.Index(20052)
+	assert.InDelta(t, 209.0, v, 1e-9)
+
+	v, e = hd.Index("26180", 20052)
+	assert.Nil(t, e)
+	assert.InDelta(t, 209.0, v, 1e-9)
+
+	s, _ = hd.Geo("42260")
+	assert.Equal(t, "35840", s.geoCode)
+
+	_, e = ReadCBSAChanges("old,new,vintage\n1,2\n")
+	assert.NotNil(t, e)
+
+	_, e = testData().RemapCBSAs(chg)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,118 @@
+package fhfa
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Release selects one of the releases held by Revisions.
+type Release int
+
+const (
+	// ReleaseOriginal is the earlier release.
+	ReleaseOriginal Release = iota
+
+	// ReleaseRevised is the later release.
+	ReleaseRevised
+)
+
+// Revision holds the original and revised values of a quarter.  A value is 0 if the quarter is not in
+// that release.
+type Revision struct {
+	Dt         int     // quarter (CCYYQ)
+	Original   float64 // value in the original release
+	Revised    float64 // value in the revised release
+	InOriginal bool    // true if the quarter is in the original release
+	InRevised  bool    // true if the quarter is in the revised release
+}
+
+// Revisions holds two releases of HPI data at the same geo level so that values can be queried as of
+// either release.  This supports point-in-time work that needs unrevised values.
+type Revisions struct {
+	original *HPIdata
+	revised  *HPIdata
+}
+
+// MergeReleases combines an original and a revised release of the same geo level.  Copies are kept, so later
+// changes to the arguments don't affect the result.
+func MergeReleases(original, revised *HPIdata) (*Revisions, error) {
+	if original.geoLevel != revised.geoLevel {
+		return nil, fmt.Errorf("releases have different geo levels: %s, %s", original.geoLevel, revised.geoLevel)
+	}
+
+	if e := original.tags.Compatible(revised.tags); e != nil {
+		return nil, e
+	}
+
+	return &Revisions{original: original.Copy(), revised: revised.Copy()}, nil
+}
+
+// Data returns the HPIdata of release.
+func (r *Revisions) Data(release Release) *HPIdata {
+	if release == ReleaseOriginal {
+		return r.original
+	}
+
+	return r.revised
+}
+
+// Geos returns the geos in either release, in order.
+func (r *Revisions) Geos() []string {
+	var geos []string
+	for _, hd := range []*HPIdata{r.original, r.revised} {
+		for k := range hd.series {
+			if !in(k, geos) {
+				geos = append(geos, k)
+			}
+		}
+	}
+	sort.Strings(geos)
+
+	return geos
+}
+
+// Index returns the house price index for geo at dt (CCYYQ) as published in release.
+func (r *Revisions) Index(geo string, dt int, release Release) (float64, error) {
+	return r.Data(release).Index(geo, dt)
+}
+
+// Revisions returns the original and revised values of geo for every quarter in either release, in date order.
+func (r *Revisions) Revisions(geo string) ([]Revision, error) {
+	so, eo := r.original.Geo(geo)
+	sr, er := r.revised.Geo(geo)
+	if eo != nil && er != nil {
+		return nil, fmt.Errorf("geo %s not found in either release: %w", geo, ErrGeoNotFound)
+	}
+
+	revs := make(map[int]*Revision)
+	get := func(dt int) *Revision {
+		if _, ok := revs[dt]; !ok {
+			revs[dt] = &Revision{Dt: dt}
+		}
+
+		return revs[dt]
+	}
+
+	if so != nil {
+		for dt, v := range so.Observations() {
+			rv := get(dt)
+			rv.Original, rv.InOriginal = v, true
+		}
+	}
+
+	if sr != nil {
+		for dt, v := range sr.Observations() {
+			rv := get(dt)
+			rv.Revised, rv.InRevised = v, true
+		}
+	}
+
+	var out []Revision
+	for _, rv := range revs {
+		out = append(out, *rv)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Dt < out[j].Dt })
+
+	return out, nil
+}
@@ -0,0 +1,47 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeReleases(t *testing.T) {
+	original := testData()
+	revised := testData()
+	_, e := revised.Upsert(testRelease("CA", "TX", "NY"), AppendOptions{})
+	assert.Nil(t, e)
+
+	revised.series["TX"].indx[39] = 1
+
+	r, e := MergeReleases(original, revised)
+	assert.Nil(t, e)
+	assert.Equal(t, []string{"CA", "NY", "TX"}, r.Geos())
+
+	v, e := r.Index("TX", 20094, ReleaseOriginal)
+	assert.Nil(t, e)
+	assert.NotEqual(t, 1.0, v)
+
+	v, e = r.Index("TX", 20094, ReleaseRevised)
+	assert.Nil(t, e)
+	assert.Equal(t, 1.0, v)
+
+	_, e = r.Index("TX", 20101, ReleaseOriginal)
+	assert.NotNil(t, e)
+
+	revs, e := r.Revisions("TX")
+	assert.Nil(t, e)
+	assert.Equal(t, 44, len(revs))
+
+	last := revs[39]
+	assert.Equal(t, 20094, last.Dt)
+	assert.True(t, last.InOriginal && last.InRevised)
+	assert.Equal(t, 1.0, last.Revised)
+	assert.False(t, revs[40].InOriginal)
+
+	_, e = r.Revisions("WY")
+	assert.NotNil(t, e)
+
+	_, e = MergeReleases(original, testUS())
+	assert.NotNil(t, e)
+}
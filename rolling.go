@@ -0,0 +1,54 @@
+package fhfa
+
+import "fmt"
+
+// RollingChange returns the series of trailing windowQtrs-quarter appreciation of h.  The value at each date
+// is the ratio of the index to its value windowQtrs quarters earlier, so the result starts windowQtrs quarters
+// after the start of h.
+func (h *HPIseries) RollingChange(windowQtrs int) (*HPIseries, error) {
+	if windowQtrs < 1 {
+		return nil, fmt.Errorf("windowQtrs must be positive")
+	}
+
+	var (
+		dts  []int
+		indx []float64
+	)
+
+	lastDt, lastIndx := 0, 0.0
+	for j, dt := range h.dates {
+		prior := AddQtrs(dt, -windowQtrs)
+		if prior < h.dates[0] {
+			continue
+		}
+
+		v, e := h.Index(prior)
+		if e != nil {
+			return nil, e
+		}
+
+		dts = append(dts, dt)
+		indx = append(indx, h.indx[j]/v)
+
+		if dt <= h.lastDt {
+			lastDt, lastIndx = dt, h.indx[j]/v
+		}
+	}
+
+	if len(dts) == 0 {
+		return nil, fmt.Errorf("series shorter than window of %d quarters", windowQtrs)
+	}
+
+	rc, e := NewHPIseries(h.geoName, h.geoCode, dts, indx)
+	if e != nil {
+		return nil, e
+	}
+
+	if lastDt > 0 {
+		rc.lastDt, rc.lastIndx = lastDt, lastIndx
+	}
+
+	rc.carryFlags(h)
+
+	return rc, nil
+}
@@ -0,0 +1,34 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_RollingChange(t *testing.T) {
+	s := testData().series["CA"]
+
+	rc, e := s.RollingChange(4)
+	assert.Nil(t, e)
+	assert.Equal(t, len(s.dates)-4, len(rc.dates))
+	assert.Equal(t, 20011, rc.dates[0])
+
+	for _, v := range rc.indx {
+		assert.InEpsilon(t, math.Pow(1+testGrowth["CA"], 4), v, 1e-9)
+	}
+
+	_, e = s.RollingChange(100)
+	assert.NotNil(t, e)
+}
+
+func TestAddQtrs(t *testing.T) {
+	dts := []int{20221, 20224, 20221, 20223}
+	n := []int{3, 1, -1, -11}
+	exp := []int{20224, 20231, 20214, 20194}
+
+	for j, dt := range dts {
+		assert.Equal(t, exp[j], AddQtrs(dt, n[j]))
+	}
+}
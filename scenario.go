@@ -0,0 +1,117 @@
+package fhfa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Scenario holds quarterly house price appreciation (HPA) paths for a stress scenario
+// (e.g. CCAR base, adverse, severely adverse).  Path elements are growth rates for each quarter,
+// so 0.01 is 1% growth in the quarter unless Annualization says they are quoted as annual rates.
+type Scenario struct {
+	Name          string               // scenario name
+	Path          []float64            // default path, used for geos without an entry in Paths
+	Paths         map[string][]float64 // geo-specific paths
+	Annualization Annualization        // convention the paths are quoted in, quarterly by default
+}
+
+// PathFor returns the HPA path for geo, as given. The geo-specific path is used if present, otherwise the default.
+func (s Scenario) PathFor(geo string) ([]float64, error) {
+	if p, ok := s.Paths[geo]; ok {
+		return p, nil
+	}
+
+	if s.Path != nil {
+		return s.Path, nil
+	}
+
+	return nil, fmt.Errorf("scenario %s has no path for geo %s", s.Name, geo)
+}
+
+// ApplyScenario extends every series in hd along the scenario path, starting at fromDt (CCYYQ).
+// The first element of the path is the growth from fromDt to the following quarter.  Any data after
+// fromDt is replaced.  No series is changed unless every series has a valid path and has the quarter fromDt.
+func (hd *HPIdata) ApplyScenario(s Scenario, fromDt int) error {
+	if e := s.Annualization.check(); e != nil {
+		return e
+	}
+
+	var missing []string
+	for geo, v := range hd.series {
+		path, e := s.PathFor(geo)
+		if e != nil {
+			missing = append(missing, geo)
+			continue
+		}
+
+		if _, e := v.checkPath(s.Annualization.QuarterlyPath(path), fromDt); e != nil {
+			return fmt.Errorf("geo %s: %v", geo, e)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("scenario %s has no path for geos: %s", s.Name, strings.Join(missing, ","))
+	}
+
+	for geo, v := range hd.series {
+		path, _ := s.PathFor(geo)
+		if e := v.ApplyPath(s.Annualization.QuarterlyPath(path), fromDt); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// ApplyPath extends h along path starting at fromDt (CCYYQ), replacing any data after fromDt.
+// The elements of path are quarterly growth rates. Values added are flagged as projected, and Last()
+// returns fromDt if fromDt precedes the last loaded date.
+func (h *HPIseries) ApplyPath(path []float64, fromDt int) error {
+	indx, e := h.checkPath(path, fromDt)
+	if e != nil {
+		return e
+	}
+
+	// cap the slices so appending doesn't overwrite arrays shared with the caller
+	h.dates = h.dates[: indx+1 : indx+1]
+	h.indx = h.indx[: indx+1 : indx+1]
+	h.prov = copyFlags(h.prov, 0, indx+1)
+
+	if fromDt < h.lastDt {
+		h.lastDt, h.lastIndx = fromDt, h.indx[indx]
+	}
+
+	dt, v := fromDt, h.indx[indx]
+	for _, g := range path {
+		dt = NextQtr(dt)
+		v *= 1 + g
+		h.dates = append(h.dates, dt)
+		h.indx = append(h.indx, v)
+	}
+
+	h.flag(indx+1, Projected)
+
+	return nil
+}
+
+// checkPath checks that h can be extended along path from fromDt (CCYYQ) and returns the position of fromDt.
+func (h *HPIseries) checkPath(path []float64, fromDt int) (int, error) {
+	indx, e := h.DateIndex(fromDt)
+	if e != nil {
+		return 0, e
+	}
+
+	if h.dates[indx] != fromDt {
+		return 0, fmt.Errorf("%w: %d", ErrDateMissing, fromDt)
+	}
+
+	for _, g := range path {
+		if g <= -1 {
+			return 0, fmt.Errorf("growth rate must exceed -1, got %v", g)
+		}
+	}
+
+	return indx, nil
+}
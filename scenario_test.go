@@ -0,0 +1,57 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_ApplyScenario(t *testing.T) {
+	hd := testData()
+
+	s := Scenario{
+		Name:  "adverse",
+		Path:  []float64{-0.02, -0.02, -0.01},
+		Paths: map[string][]float64{"CA": {-0.05, -0.05}},
+	}
+
+	ld, li := hd.series["TX"].Last()
+	assert.Nil(t, hd.ApplyScenario(s, ld))
+
+	v, e := hd.Index("TX", NextQtr(NextQtr(NextQtr(ld))))
+	assert.Nil(t, e)
+	assert.InEpsilon(t, li*0.98*0.98*0.99, v, 1e-9)
+
+	ldCA, liCA := hd.series["CA"].Last()
+	v, e = hd.Index("CA", NextQtr(NextQtr(ldCA)))
+	assert.Nil(t, e)
+	assert.InEpsilon(t, liCA*math.Pow(0.95, 2), v, 1e-9)
+
+	// start before the end of the data
+	from := 20051
+	base, _ := hd.Index("NY", from)
+	assert.Nil(t, hd.ApplyScenario(s, from))
+	v, e = hd.Index("NY", NextQtr(from))
+	assert.Nil(t, e)
+	assert.InEpsilon(t, base*0.98, v, 1e-9)
+
+	dt, _ := hd.series["NY"].Last()
+	assert.Equal(t, from, dt)
+
+	_, e = hd.Index("NY", 20061)
+	assert.NotNil(t, e)
+
+	// missing paths
+	s.Path = nil
+	assert.NotNil(t, hd.ApplyScenario(s, from))
+
+	// a bad path for one geo leaves every geo unchanged
+	hd = testData()
+	bad := Scenario{Name: "bad", Path: []float64{0.01}, Paths: map[string][]float64{"TX": {-1}}}
+	assert.NotNil(t, hd.ApplyScenario(bad, 20051))
+	for _, geo := range hd.Geos() {
+		dt, _ := hd.series[geo].End()
+		assert.Equal(t, 20094, dt, geo)
+	}
+}
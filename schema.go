@@ -0,0 +1,114 @@
+package fhfa
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownSchema is returned (wrapped in a *SchemaError) when a workbook's headers match no known layout.
+var ErrUnknownSchema = errors.New("unrecognized FHFA file layout")
+
+// Schema identifies the layout of an FHFA workbook by its title (the first cell) and column headers (the
+// second row).  Matching is case-insensitive.
+type Schema struct {
+	Name     string   // name of the layout
+	GeoLevel string   // geo level of the data: metro data has an area name column before the geo code
+	Title    string   // text the title must contain
+	Columns  []string // prefixes the leading column headers must start with, "|" separating alternatives; "" matches any header
+}
+
+// SchemaError reports a workbook whose headers match no known layout.  It matches ErrUnknownSchema with
+// errors.Is.
+type SchemaError struct {
+	Title   string   // title of the workbook
+	Columns []string // column headers of the workbook
+}
+
+// Error returns the error message, including the headers.
+func (se *SchemaError) Error() string {
+	return fmt.Sprintf("%v: title %q, columns %q", ErrUnknownSchema, se.Title, se.Columns)
+}
+
+// Unwrap returns ErrUnknownSchema.
+func (se *SchemaError) Unwrap() error {
+	return ErrUnknownSchema
+}
+
+// the layouts of the FHFA all-transactions workbooks
+var fhfaSchemas = []Schema{
+	{Name: "at-zip3", GeoLevel: "zip3", Title: "three-digit zip", Columns: []string{"three-digit zip", "year", "quarter|qtr", "index|hpi"}},
+	{Name: "at-metro", GeoLevel: "metro", Title: "metropolitan areas", Columns: []string{"", "", "year", "quarter|qtr", "index|hpi"}},
+	{Name: "at-nonmetro", GeoLevel: "nonmetro", Title: "not in metropolitan statistical areas", Columns: []string{"", "year", "quarter|qtr", "index|hpi"}},
+	{Name: "at-state", GeoLevel: "state", Title: "states and the district of columbia", Columns: []string{"", "year", "quarter|qtr", "index|hpi"}},
+	{Name: "at-us", GeoLevel: "us", Title: "census divisions", Columns: []string{"", "year", "quarter|qtr", "index|hpi"}},
+	{Name: "at-pr", GeoLevel: "pr", Title: "puerto rico", Columns: []string{"", "year", "quarter|qtr", "index|hpi"}},
+	{Name: "at-mh", GeoLevel: "mh", Title: "manufactured homes", Columns: []string{"", "year", "quarter|qtr", "index|hpi"}},
+}
+
+var (
+	schemaMu sync.RWMutex
+	schemas  []Schema // registered by RegisterSchema, tried before fhfaSchemas
+)
+
+// RegisterSchema adds a layout, tried before the built-in ones, so files whose headers have changed can be
+// loaded once their columns are confirmed to be in the expected order.
+func RegisterSchema(s Schema) error {
+	if !in(s.GeoLevel, []string{"zip3", "metro", "nonmetro", "state", "us", "pr", "mh"}) {
+		return fmt.Errorf("invalid geo level: %s", s.GeoLevel)
+	}
+
+	if s.Title == "" {
+		return fmt.Errorf("schema %s has no title", s.Name)
+	}
+
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+
+	schemas = append(schemas, s)
+
+	return nil
+}
+
+// Schemas returns the registered layouts followed by the built-in ones, in the order they are tried.
+func Schemas() []Schema {
+	schemaMu.RLock()
+	defer schemaMu.RUnlock()
+
+	return append(append([]Schema{}, schemas...), fhfaSchemas...)
+}
+
+// DetectSchema returns the layout of a workbook with the given title and column headers.  It returns a
+// *SchemaError if none match.
+func DetectSchema(title string, columns []string) (Schema, error) {
+	for _, s := range Schemas() {
+		if s.matches(title, columns) {
+			return s, nil
+		}
+	}
+
+	return Schema{}, &SchemaError{Title: title, Columns: columns}
+}
+
+// matches returns true if the title and columns fit s.
+func (s Schema) matches(title string, columns []string) bool {
+	if !strings.Contains(strings.ToLower(title), strings.ToLower(s.Title)) || len(columns) < len(s.Columns) {
+		return false
+	}
+
+	for j, c := range s.Columns {
+		hdr := strings.ToLower(strings.TrimSpace(columns[j]))
+
+		ok := false
+		for _, alt := range strings.Split(strings.ToLower(c), "|") {
+			ok = ok || strings.HasPrefix(hdr, alt)
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
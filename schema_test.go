@@ -0,0 +1,40 @@
+package fhfa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSchema(t *testing.T) {
+	s, e := DetectSchema("House Price Index for the 50 States and the District of Columbia",
+		[]string{"State", "Year", "Quarter", "Index (NSA)"})
+	assert.Nil(t, e)
+	assert.Equal(t, "at-state", s.Name)
+
+	s, e = DetectSchema("Metropolitan Areas", []string{"Metropolitan Area", "CBSA", "Year", "Qtr", "HPI"})
+	assert.Nil(t, e)
+	assert.Equal(t, "metro", s.GeoLevel)
+
+	// the columns have moved
+	_, e = DetectSchema("Metropolitan Areas", []string{"CBSA", "Year", "Qtr", "HPI"})
+	var se *SchemaError
+	assert.True(t, errors.As(e, &se))
+	assert.True(t, errors.Is(e, ErrUnknownSchema))
+	assert.Contains(t, e.Error(), `"CBSA"`)
+
+	r := testRows(1, 2)
+	r[0][0] = "Quarterly Home Values by Postal Prefix"
+	_, e = parseXLSX(r, 1)
+	assert.True(t, errors.Is(e, ErrUnknownSchema))
+
+	assert.Nil(t, RegisterSchema(Schema{Name: "prefix", GeoLevel: "zip3", Title: "postal prefix", Columns: []string{"", "year"}}))
+	assert.Equal(t, "prefix", Schemas()[0].Name)
+	hd, e := parseXLSX(r, 1)
+	assert.Nil(t, e)
+	assert.Equal(t, "zip3", hd.GeoLevel())
+
+	assert.NotNil(t, RegisterSchema(Schema{Name: "bad", GeoLevel: "county", Title: "x"}))
+	assert.NotNil(t, RegisterSchema(Schema{Name: "bad", GeoLevel: "zip3"}))
+}
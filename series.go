@@ -0,0 +1,92 @@
+package fhfa
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AddSeries adds a copy of s to hd, keyed by its geo code (normalized by NormalizeGeo).  The geo code must
+// be valid at the geo level of hd, the dates of s must be contiguous quarters and hd must not already have
+// the geo.
+func (hd *HPIdata) AddSeries(s *HPIseries) error {
+	geo, e := hd.checkSeries(s)
+	if e != nil {
+		return e
+	}
+
+	if _, ok := hd.series[geo]; ok {
+		return fmt.Errorf("geo %s already in data", geo)
+	}
+
+	hd.series[geo] = s.Copy()
+
+	return nil
+}
+
+// ReplaceSeries replaces the series of hd with the geo code of s by a copy of s.  It is checked as for
+// AddSeries, and hd must already have the geo.
+func (hd *HPIdata) ReplaceSeries(s *HPIseries) error {
+	geo, e := hd.checkSeries(s)
+	if e != nil {
+		return e
+	}
+
+	if _, ok := hd.series[geo]; !ok {
+		return &GeoError{Geo: geo, Level: hd.geoLevel}
+	}
+
+	hd.series[geo] = s.Copy()
+
+	return nil
+}
+
+// RemoveSeries removes geo from hd.
+func (hd *HPIdata) RemoveSeries(geo string) error {
+	geo = NormalizeGeo(hd.geoLevel, geo)
+	if _, ok := hd.series[geo]; !ok {
+		return &GeoError{Geo: geo, Level: hd.geoLevel}
+	}
+
+	delete(hd.series, geo)
+
+	return nil
+}
+
+// checkSeries checks that s may be stored in hd, returning its key.
+func (hd *HPIdata) checkSeries(s *HPIseries) (string, error) {
+	geo := NormalizeGeo(hd.geoLevel, s.geoCode)
+	if e := validGeo(hd.geoLevel, geo); e != nil {
+		return "", e
+	}
+
+	if !QtrsOK(s.dates) {
+		return "", fmt.Errorf("geo %s: %w", geo, ErrFrequencyMismatch)
+	}
+
+	return geo, nil
+}
+
+// validGeo checks that the normalized key geo has the form of a geo at geoLevel.
+func validGeo(geoLevel, geo string) error {
+	numeric := func(n int) bool {
+		_, e := strconv.ParseUint(geo, 10, 64)
+		return e == nil && len(geo) == n
+	}
+
+	ok := geo != ""
+	switch geoLevel {
+	case "zip3":
+		ok = numeric(3)
+	case "metro":
+		ok = numeric(5)
+	case "state", "nonmetro":
+		_, e := NormalizeState(geo)
+		ok = e == nil
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid %s geo: %q", geoLevel, geo)
+	}
+
+	return nil
+}
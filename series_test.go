@@ -0,0 +1,44 @@
+package fhfa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_AddSeries(t *testing.T) {
+	hd := testData()
+
+	fl, e := NewHPIseries("Florida", "fl", []int{20001, 20002}, []float64{100, 101})
+	assert.Nil(t, e)
+	assert.Nil(t, hd.AddSeries(fl))
+	v, e := hd.Index("FL", 20002)
+	assert.Nil(t, e)
+	assert.Equal(t, 101.0, v)
+	assert.NotNil(t, hd.AddSeries(fl))
+
+	bad, _ := NewHPIseries("Nowhere", "ZZ", []int{20001}, []float64{100})
+	assert.NotNil(t, hd.AddSeries(bad))
+
+	gap := fl.Copy()
+	gap.dates = []int{20001, 20003}
+	assert.True(t, errors.Is(hd.ReplaceSeries(gap), ErrFrequencyMismatch))
+
+	fl2, _ := NewHPIseries("Florida", "FL", []int{20001}, []float64{90})
+	assert.Nil(t, hd.ReplaceSeries(fl2))
+	v, _ = hd.Index("FL", 20001)
+	assert.Equal(t, 90.0, v)
+
+	wy, _ := NewHPIseries("Wyoming", "WY", []int{20001}, []float64{90})
+	assert.True(t, errors.Is(hd.ReplaceSeries(wy), ErrGeoNotFound))
+
+	assert.Nil(t, hd.RemoveSeries("florida"))
+	assert.True(t, errors.Is(hd.RemoveSeries("FL"), ErrGeoNotFound))
+	assert.Equal(t, 3, hd.Len())
+
+	zip, _ := NewHPIseries("", "37", []int{20001}, []float64{90})
+	zd := &HPIdata{geoLevel: "zip3", series: make(map[string]*HPIseries)}
+	assert.Nil(t, zd.AddSeries(zip))
+	assert.Equal(t, []string{"037"}, zd.Geos())
+}
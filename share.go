@@ -0,0 +1,34 @@
+package fhfa
+
+// shareDates points the dates of every gap-free series of hd into a single slice of quarters, so a level
+// with thousands of series holds one copy of its dates rather than one per series.  Each series gets a
+// sub-slice whose capacity equals its length, so appending to one series copies its dates rather than
+// writing over another's.  Dates are never modified in place, so sharing them is safe.
+func (hd *HPIdata) shareDates() {
+	first, last := 0, 0
+	for _, s := range hd.series {
+		if len(s.dates) == 0 || !QtrsOK(s.dates) {
+			continue
+		}
+
+		if first == 0 || s.dates[0] < first {
+			first = s.dates[0]
+		}
+
+		last = max(last, s.dates[len(s.dates)-1])
+	}
+
+	if first == 0 {
+		return
+	}
+
+	all := QtrRange(first, last)
+	for _, s := range hd.series {
+		if len(s.dates) == 0 || !QtrsOK(s.dates) {
+			continue
+		}
+
+		off, n := QtrDiffSigned(first, s.dates[0]), len(s.dates)
+		s.dates = all[off : off+n : off+n]
+	}
+}
@@ -0,0 +1,31 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_shareDates(t *testing.T) {
+	hd := testData()
+	w, e := hd.series["NY"].Window(20011, 20054)
+	assert.Nil(t, e)
+	hd.series["NY"] = w
+
+	hd, e = Load(testXLSX(t, hd))
+	assert.Nil(t, e)
+
+	ca, ny := hd.series["CA"], hd.series["NY"]
+	assert.Equal(t, 20011, ny.dates[0])
+	assert.Same(t, &ca.dates[4], &ny.dates[0])
+	assert.Equal(t, len(ny.dates), cap(ny.dates))
+
+	// appending copies rather than overwriting CA
+	assert.Nil(t, ny.Append([]int{20061}, []float64{1}))
+	n := len(ny.dates) - 1
+	assert.Equal(t, 20061, ny.dates[n])
+	assert.NotSame(t, &ca.dates[24], &ny.dates[n])
+
+	cp := hd.Copy()
+	assert.Same(t, &cp.series["CA"].dates[0], &cp.series["TX"].dates[0])
+}
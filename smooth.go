@@ -0,0 +1,66 @@
+package fhfa
+
+import "fmt"
+
+// Smooth returns the windowQtrs-quarter moving average of h.  If centered is false the average is trailing,
+// so the result starts windowQtrs-1 quarters after h.  If centered is true windowQtrs must be odd and the
+// average is over the (windowQtrs-1)/2 quarters on either side, so the result loses that many quarters at
+// each end.
+func (h *HPIseries) Smooth(windowQtrs int, centered bool) (*HPIseries, error) {
+	if windowQtrs < 1 {
+		return nil, fmt.Errorf("windowQtrs must be positive")
+	}
+
+	if centered && windowQtrs%2 == 0 {
+		return nil, fmt.Errorf("centered window must be odd, got %d", windowQtrs)
+	}
+
+	if len(h.indx) < windowQtrs {
+		return nil, fmt.Errorf("series shorter than window of %d quarters", windowQtrs)
+	}
+
+	// offset is the position of the smoothed date relative to the end of the window
+	offset := 0
+	if centered {
+		offset = (windowQtrs - 1) / 2
+	}
+
+	var (
+		dts  []int
+		indx []float64
+		sum  float64
+	)
+
+	lastDt, lastIndx := 0, 0.0
+	for j, v := range h.indx {
+		sum += v
+		if j >= windowQtrs {
+			sum -= h.indx[j-windowQtrs]
+		}
+
+		if j < windowQtrs-1 {
+			continue
+		}
+
+		dt := h.dates[j-offset]
+		dts = append(dts, dt)
+		indx = append(indx, sum/float64(windowQtrs))
+
+		if dt <= h.lastDt {
+			lastDt, lastIndx = dt, sum/float64(windowQtrs)
+		}
+	}
+
+	sm, e := NewHPIseries(h.geoName, h.geoCode, dts, indx)
+	if e != nil {
+		return nil, e
+	}
+
+	if lastDt > 0 {
+		sm.lastDt, sm.lastIndx = lastDt, lastIndx
+	}
+
+	sm.carryFlags(h)
+
+	return sm, nil
+}
@@ -0,0 +1,32 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_Smooth(t *testing.T) {
+	h, e := NewHPIseries("X", "X", []int{20201, 20202, 20203, 20204, 20211}, []float64{100, 104, 100, 104, 100})
+	assert.Nil(t, e)
+
+	sm, e := h.Smooth(2, false)
+	assert.Nil(t, e)
+	assert.Equal(t, []int{20202, 20203, 20204, 20211}, sm.Dates())
+	assert.Equal(t, []float64{102, 102, 102, 102}, sm.Values())
+
+	sm, e = h.Smooth(3, true)
+	assert.Nil(t, e)
+	assert.Equal(t, []int{20202, 20203, 20204}, sm.Dates())
+	assert.InDelta(t, 304.0/3, sm.Values()[0], 1e-9)
+	assert.InDelta(t, 308.0/3, sm.Values()[1], 1e-9)
+
+	dt, _ := sm.Last()
+	assert.Equal(t, 20204, dt)
+
+	_, e = h.Smooth(2, true)
+	assert.NotNil(t, e)
+
+	_, e = h.Smooth(6, false)
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,46 @@
+package fhfa
+
+import "fmt"
+
+// Splice returns a new series that follows h through atDt (CCYYQ) and other after it.  other is scaled so
+// the two agree at atDt, which both must contain.  This chains, for instance, a discontinued metro onto its
+// successor.  The result has the geo name and code of other; Last() is the scaled Last() of other.
+func (h *HPIseries) Splice(other *HPIseries, atDt int) (*HPIseries, error) {
+	j, k := h.exact(atDt), other.exact(atDt)
+	if j < 0 || k < 0 {
+		return nil, fmt.Errorf("splice date %d must be in both series", atDt)
+	}
+
+	scale := h.indx[j] / other.indx[k]
+
+	out := &HPIseries{
+		geoName: other.geoName,
+		geoCode: other.geoCode,
+		dates:   append(append([]int{}, h.dates[:j+1]...), other.dates[k+1:]...),
+		indx:    append([]float64{}, h.indx[:j+1]...),
+		extrap:  other.extrap,
+		match:   other.match,
+	}
+
+	for _, v := range other.indx[k+1:] {
+		out.indx = append(out.indx, v*scale)
+	}
+
+	if h.prov != nil || other.prov != nil {
+		for m := range out.dates {
+			if m <= j {
+				out.prov = append(out.prov, h.provenance(m))
+				continue
+			}
+
+			out.prov = append(out.prov, other.provenance(m-j+k))
+		}
+	}
+
+	out.lastDt, out.lastIndx = atDt, h.indx[j]
+	if other.lastDt > atDt {
+		out.lastDt, out.lastIndx = other.lastDt, other.lastIndx*scale
+	}
+
+	return out, nil
+}
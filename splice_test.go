@@ -0,0 +1,37 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_Splice(t *testing.T) {
+	hd := testData()
+	ca, _ := hd.Geo("CA")
+	tx, _ := hd.Geo("TX")
+
+	s, e := ca.Splice(tx, 20044)
+	assert.Nil(t, e)
+	assert.Equal(t, 40, s.Len())
+	assert.True(t, QtrsOK(s.dates))
+	assert.Equal(t, "TX", s.geoCode)
+
+	v, _ := s.Index(20044)
+	vc, _ := ca.Index(20044)
+	assert.Equal(t, vc, v)
+
+	chg, e := s.Change(20051, 20061)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.01*1.01*1.01*1.01, chg, 1e-9)
+
+	chg, _ = s.Change(20001, 20044)
+	chgCA, _ := ca.Change(20001, 20044)
+	assert.Equal(t, chgCA, chg)
+
+	dt, _ := s.Last()
+	assert.Equal(t, 20094, dt)
+
+	_, e = ca.Splice(tx, 20111)
+	assert.NotNil(t, e)
+}
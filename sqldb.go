@@ -0,0 +1,171 @@
+package fhfa
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LoadDB loads data at geoLevel from any database/sql source.  The query must return the columns geo, yrqtr
+// (CCYYQ) and index, and may return name (the geo name), in any order and under any case.  Rows may be in any
+// order and rows with a null index are skipped.  args are passed to the query.
+func LoadDB(ctx context.Context, db *sql.DB, geoLevel, query string, args ...any) (*HPIdata, error) {
+	if e := checkGeoLevel(geoLevel); e != nil {
+		return nil, e
+	}
+
+	rows, e := db.QueryContext(ctx, query, args...)
+	if e != nil {
+		return nil, e
+	}
+	defer rows.Close()
+
+	cols, e := rows.Columns()
+	if e != nil {
+		return nil, e
+	}
+
+	var (
+		geo, name sql.NullString
+		dt        sql.NullInt64
+		indx      sql.NullFloat64
+		skip      any
+	)
+
+	dest := make([]any, len(cols))
+	found := make(map[string]bool)
+	for j, col := range cols {
+		col = strings.ToLower(col)
+		found[col] = true
+
+		switch col {
+		case "geo":
+			dest[j] = &geo
+		case "name":
+			dest[j] = &name
+		case "yrqtr":
+			dest[j] = &dt
+		case "index":
+			dest[j] = &indx
+		default:
+			dest[j] = &skip
+		}
+	}
+
+	for _, col := range []string{"geo", "yrqtr", "index"} {
+		if !found[col] {
+			return nil, fmt.Errorf("query has no %s column", col)
+		}
+	}
+
+	hd := &HPIdata{
+		source:   query,
+		geoLevel: geoLevel,
+		series:   make(map[string]*HPIseries),
+	}
+
+	for rows.Next() {
+		if e := rows.Scan(dest...); e != nil {
+			return nil, e
+		}
+
+		if !indx.Valid || !geo.Valid || !dt.Valid {
+			continue
+		}
+
+		if !YrQtr(dt.Int64).Valid() {
+			return nil, badDate(int(dt.Int64))
+		}
+
+		hd.add(geo.String, name.String, int(dt.Int64), indx.Float64)
+	}
+
+	if e := rows.Err(); e != nil {
+		return nil, e
+	}
+
+	if e := hd.finish(); e != nil {
+		return nil, e
+	}
+
+	return hd, nil
+}
+
+// SaveDB writes every observation of hd to db in a single transaction.  insert is a statement with three
+// placeholders, for the geo, yrqtr (CCYYQ) and index, in the syntax of the driver, e.g.
+//
+//	INSERT INTO hpi (geo, yrqtr, index) VALUES (?, ?, ?)
+func (hd *HPIdata) SaveDB(ctx context.Context, db *sql.DB, insert string) error {
+	tx, e := db.BeginTx(ctx, nil)
+	if e != nil {
+		return e
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, e := tx.PrepareContext(ctx, insert)
+	if e != nil {
+		return e
+	}
+	defer stmt.Close()
+
+	for geo, s := range hd.All() {
+		for j, dt := range s.dates {
+			if _, e := stmt.ExecContext(ctx, geo, dt, s.indx[j]); e != nil {
+				return fmt.Errorf("geo %s, quarter %d: %w", geo, dt, e)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// add adds an observation of geo, which may be out of date order, to hd.  The geo name is set if name isn't blank.
+// finish must be called after the last observation is added.
+func (hd *HPIdata) add(geo, name string, dt int, v float64) {
+	geo = NormalizeGeo(hd.geoLevel, geo)
+	s, ok := hd.series[geo]
+	if !ok {
+		s = &HPIseries{geoName: geo, geoCode: geo}
+		hd.series[geo] = s
+	}
+
+	if name != "" {
+		s.geoName = name
+	}
+
+	s.dates = append(s.dates, dt)
+	s.indx = append(s.indx, v)
+}
+
+// finish puts the series built by add in date order and checks for duplicate quarters.
+func (hd *HPIdata) finish() error {
+	for geo, s := range hd.series {
+		sort.Sort(byDate{s})
+
+		for j := 1; j < len(s.dates); j++ {
+			if s.dates[j] == s.dates[j-1] {
+				return fmt.Errorf("geo %s has duplicate quarter %d", geo, s.dates[j])
+			}
+		}
+
+		s.lastDt, s.lastIndx = s.dates[len(s.dates)-1], s.indx[len(s.indx)-1]
+	}
+
+	hd.shareDates()
+
+	return nil
+}
+
+// byDate sorts the observations of a series by date.
+type byDate struct {
+	s *HPIseries
+}
+
+func (b byDate) Len() int           { return len(b.s.dates) }
+func (b byDate) Less(i, j int) bool { return b.s.dates[i] < b.s.dates[j] }
+func (b byDate) Swap(i, j int) {
+	b.s.dates[i], b.s.dates[j] = b.s.dates[j], b.s.dates[i]
+	b.s.indx[i], b.s.indx[j] = b.s.indx[j], b.s.indx[i]
+}
@@ -0,0 +1,104 @@
+package fhfa
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memDB is a database/sql driver holding one table of (geo, yrqtr, index, name) rows.  Every query returns the
+// whole table and every statement executed inserts a row.
+type memDB struct {
+	rows [][]driver.Value
+}
+
+func (m *memDB) Connect(context.Context) (driver.Conn, error) { return m, nil }
+func (m *memDB) Driver() driver.Driver                        { return nil }
+func (m *memDB) Prepare(string) (driver.Stmt, error)          { return m, nil }
+func (m *memDB) Close() error                                 { return nil }
+func (m *memDB) Begin() (driver.Tx, error)                    { return m, nil }
+func (m *memDB) Commit() error                                { return nil }
+func (m *memDB) Rollback() error                              { return nil }
+func (m *memDB) NumInput() int                                { return -1 }
+
+func (m *memDB) Exec(args []driver.Value) (driver.Result, error) {
+	m.rows = append(m.rows, append(args, nil))
+
+	return driver.RowsAffected(1), nil
+}
+
+func (m *memDB) Query([]driver.Value) (driver.Rows, error) {
+	return &memRows{rows: m.rows}, nil
+}
+
+// memRows iterates over the rows of a memDB.
+type memRows struct {
+	rows [][]driver.Value
+	j    int
+}
+
+func (r *memRows) Columns() []string { return []string{"geo", "YrQtr", "index", "name"} }
+func (r *memRows) Close() error      { return nil }
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if r.j >= len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.j])
+	r.j++
+
+	return nil
+}
+
+func TestLoadDB(t *testing.T) {
+	m := &memDB{rows: [][]driver.Value{
+		{"10180", int64(20202), 102.0, "Abilene, TX"},
+		{"10180", int64(20201), 101.0, "Abilene, TX"},
+		{"10180", int64(20203), nil, "Abilene, TX"},
+		{"10420", int64(20201), 99.0, nil},
+	}}
+	db := sql.OpenDB(m)
+
+	hd, e := LoadDB(context.Background(), db, "metro", "SELECT * FROM hpi")
+	assert.Nil(t, e)
+	assert.ElementsMatch(t, []string{"10180", "10420"}, hd.Geos())
+
+	s, e := hd.Geo("10180")
+	assert.Nil(t, e)
+	assert.Equal(t, []int{20201, 20202}, s.Dates())
+	assert.Equal(t, "Abilene, TX", s.Name())
+
+	m.rows = append(m.rows, []driver.Value{"10420", int64(20201), 98.0, nil})
+	_, e = LoadDB(context.Background(), db, "metro", "SELECT * FROM hpi")
+	assert.ErrorContains(t, e, "duplicate")
+
+	m.rows[0][1] = int64(20205)
+	_, e = LoadDB(context.Background(), db, "metro", "SELECT * FROM hpi")
+	assert.ErrorIs(t, e, ErrBadDate)
+
+	_, e = LoadDB(context.Background(), db, "metros", "SELECT * FROM hpi")
+	assert.ErrorContains(t, e, "invalid geo level")
+}
+
+func TestHPIdata_SaveDB(t *testing.T) {
+	m := &memDB{}
+	db := sql.OpenDB(m)
+
+	hd := testData()
+	assert.Nil(t, hd.SaveDB(context.Background(), db, "INSERT INTO hpi VALUES (?, ?, ?)"))
+	assert.Len(t, m.rows, 120)
+
+	hd1, e := LoadDB(context.Background(), db, "state", "SELECT * FROM hpi")
+	assert.Nil(t, e)
+
+	for geo, s := range hd.All() {
+		s1, e := hd1.Geo(geo)
+		assert.Nil(t, e)
+		assert.Equal(t, s.Values(), s1.Values())
+	}
+}
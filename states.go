@@ -0,0 +1,83 @@
+package fhfa
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed data/states.csv
+var statesCSV string
+
+// state holds the identifiers of a state or territory.
+type state struct {
+	postal, fips, name string
+}
+
+var (
+	statesOnce sync.Once
+	states     map[string]*state // keyed by upper-cased postal code, FIPS code and name
+)
+
+// NormalizeState returns the postal abbreviation of a state given its postal abbreviation, FIPS code or
+// name (e.g. "California", "ca", "06" and "6" all return "CA").  Territories are included.
+func NormalizeState(st string) (string, error) {
+	s, e := findState(st)
+	if e != nil {
+		return "", e
+	}
+
+	return s.postal, nil
+}
+
+// StateFIPS returns the 2-digit FIPS code of a state given its postal abbreviation, FIPS code or name.
+func StateFIPS(st string) (string, error) {
+	s, e := findState(st)
+	if e != nil {
+		return "", e
+	}
+
+	return s.fips, nil
+}
+
+// StateName returns the name of a state given its postal abbreviation, FIPS code or name.
+func StateName(st string) (string, error) {
+	s, e := findState(st)
+	if e != nil {
+		return "", e
+	}
+
+	return s.name, nil
+}
+
+// findState looks up st by postal code, FIPS code or name
+func findState(st string) (*state, error) {
+	statesOnce.Do(loadStates)
+
+	key := strings.ToUpper(strings.Join(strings.Fields(st), " "))
+	if len(key) == 1 {
+		key = "0" + key
+	}
+
+	s, ok := states[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown state: %s", st)
+	}
+
+	return s, nil
+}
+
+// loadStates loads the embedded state table
+func loadStates() {
+	states = make(map[string]*state)
+
+	for _, line := range strings.Split(strings.TrimSpace(statesCSV), "\n")[1:] {
+		flds := strings.Split(line, ",")
+		s := &state{postal: flds[0], fips: flds[1], name: flds[2]}
+
+		for _, k := range flds {
+			states[strings.ToUpper(k)] = s
+		}
+	}
+}
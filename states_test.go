@@ -0,0 +1,46 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeState(t *testing.T) {
+	in := []string{"California", "ca", "06", "6", " new  york ", "PR", "72"}
+	exp := []string{"CA", "CA", "CA", "CA", "NY", "PR", "PR"}
+
+	for j, st := range in {
+		act, e := NormalizeState(st)
+		assert.Nil(t, e)
+		assert.Equal(t, exp[j], act)
+	}
+
+	_, e := NormalizeState("XX")
+	assert.NotNil(t, e)
+
+	fips, e := StateFIPS("Texas")
+	assert.Nil(t, e)
+	assert.Equal(t, "48", fips)
+
+	name, e := StateName("dc")
+	assert.Nil(t, e)
+	assert.Equal(t, "District of Columbia", name)
+}
+
+func TestHPIdata_Geo(t *testing.T) {
+	hd := testData()
+
+	for _, geo := range []string{"TX", "Texas", "48", "tx"} {
+		s, e := hd.Geo(geo)
+		assert.Nil(t, e)
+		assert.Equal(t, "TX", s.geoCode)
+	}
+
+	_, e := hd.Geo("Wyoming")
+	assert.NotNil(t, e)
+
+	// only state-keyed levels normalize
+	_, e = testLevel(testData(), "metro").Geo("Texas")
+	assert.NotNil(t, e)
+}
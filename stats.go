@@ -0,0 +1,90 @@
+package fhfa
+
+import (
+	"math"
+	"sort"
+)
+
+// Stats holds summary statistics of a series over a range of dates.  Growth is the quarterly
+// growth rate, so 0.01 is 1% in a quarter.
+type Stats struct {
+	Geo        string  // geo of the series
+	FirstDt    int     // first date in the range (CCYYQ)
+	LastDt     int     // last date in the range (CCYYQ)
+	N          int     // number of quarters
+	Min        float64 // minimum index value
+	MinDt      int     // date of the minimum
+	Max        float64 // maximum index value
+	MaxDt      int     // date of the maximum
+	MeanGrowth float64 // mean quarterly growth
+	Volatility float64 // standard deviation of quarterly growth
+}
+
+// Stats returns summary statistics of h between dtStart and dtEnd (CCYYQ).
+func (h *HPIseries) Stats(dtStart, dtEnd int) (*Stats, error) {
+	first, last, e := h.span(dtStart, dtEnd)
+	if e != nil {
+		return nil, e
+	}
+
+	st := &Stats{
+		Geo:     h.geoCode,
+		FirstDt: h.dates[first],
+		LastDt:  h.dates[last],
+		N:       last - first + 1,
+	}
+
+	st.MinDt, st.Min, _ = h.Trough(dtStart, dtEnd)
+	st.MaxDt, st.Max, _ = h.Peak(dtStart, dtEnd)
+
+	var growth []float64
+	for j := first + 1; j <= last; j++ {
+		growth = append(growth, h.indx[j]/h.indx[j-1]-1)
+	}
+
+	st.MeanGrowth, st.Volatility = meanSD(growth)
+
+	return st, nil
+}
+
+// StatsAll returns the summary statistics of each geo in hd between dtStart and dtEnd (CCYYQ), sorted by geo.
+// Geos without data in the range are reported in errs.
+func (hd *HPIdata) StatsAll(dtStart, dtEnd int) (stats []*Stats, errs map[string]error) {
+	errs = make(map[string]error)
+	for geo, s := range hd.series {
+		st, e := s.Stats(dtStart, dtEnd)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		st.Geo = geo
+		stats = append(stats, st)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Geo < stats[j].Geo })
+
+	return stats, errs
+}
+
+// meanSD returns the mean and sample standard deviation of x.
+func meanSD(x []float64) (mean, sd float64) {
+	if len(x) == 0 {
+		return 0, 0
+	}
+
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+
+	if len(x) < 2 {
+		return mean, 0
+	}
+
+	for _, v := range x {
+		sd += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(sd / float64(len(x)-1))
+}
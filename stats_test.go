@@ -0,0 +1,43 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_Stats(t *testing.T) {
+	dts := []int{20061, 20062, 20063, 20064}
+	indx := []float64{100, 110, 99, 99}
+	s, e := NewHPIseries("XX", "XX", dts, indx)
+	assert.Nil(t, e)
+
+	st, e := s.Stats(20054, 20071)
+	assert.Nil(t, e)
+	assert.Equal(t, 4, st.N)
+	assert.Equal(t, 20061, st.FirstDt)
+	assert.Equal(t, 20064, st.LastDt)
+	assert.Equal(t, 110.0, st.Max)
+	assert.Equal(t, 20062, st.MaxDt)
+	assert.Equal(t, 99.0, st.Min)
+	assert.Equal(t, 20063, st.MinDt)
+	assert.InDelta(t, 0, st.MeanGrowth, 1e-12)
+	assert.InEpsilon(t, 0.1, st.Volatility, 1e-9)
+}
+
+func TestHPIdata_StatsAll(t *testing.T) {
+	hd := testData()
+
+	stats, errs := hd.StatsAll(20051, 20054)
+	assert.Empty(t, errs)
+	assert.Equal(t, 3, len(stats))
+	assert.Equal(t, "CA", stats[0].Geo)
+
+	for _, st := range stats {
+		assert.InEpsilon(t, testGrowth[st.Geo], st.MeanGrowth, 1e-9)
+		assert.InDelta(t, 0, st.Volatility, 1e-12)
+	}
+
+	_, errs = hd.StatsAll(19901, 19904)
+	assert.Equal(t, 3, len(errs))
+}
@@ -0,0 +1,90 @@
+package fhfa
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LTVDistribution describes the updated CLTVs of a set of loans under a scenario at one horizon.
+type LTVDistribution struct {
+	Horizon int            // quarters after the scenario start
+	Dt      int            // date (CCYYQ) of the horizon
+	Mean    float64        // mean CLTV
+	Median  float64        // median CLTV
+	P90     float64        // 90th percentile CLTV
+	P99     float64        // 99th percentile CLTV
+	Max     float64        // maximum CLTV
+	Summary *EquitySummary // totals and CLTV buckets (see SummarizeEquity)
+}
+
+// StressResult is the revaluation of a set of loans under one scenario.
+type StressResult struct {
+	Scenario string            // scenario name
+	Horizons []LTVDistribution // one per horizon, in the order requested
+	Err      error             // error applying the scenario
+}
+
+// StressLTV revalues loans under each scenario: every HPIdata of the fallback chain baseline is extended from
+// fromDt (CCYYQ) along the scenario's paths (see ApplyScenario) and the loans are marked (see MarkLoans) at
+// each horizon, given in quarters after fromDt.  Balances amortize to each horizon unless fixed by the loan.
+// Scenarios are run in parallel and baseline is not changed.  The results are in the order of scenarios.
+func StressLTV(loans []Loan, baseline []*HPIdata, scenarios []Scenario, fromDt int, horizons []int) ([]StressResult, error) {
+	if len(baseline) == 0 {
+		return nil, fmt.Errorf("no HPI data for stress test")
+	}
+
+	for _, h := range horizons {
+		if h < 0 {
+			return nil, fmt.Errorf("negative horizon: %d", h)
+		}
+	}
+
+	results := make([]StressResult, len(scenarios))
+	parallel(len(scenarios), 0, func(start, end int) {
+		for j := start; j < end; j++ {
+			results[j] = stress(loans, baseline, scenarios[j], fromDt, horizons)
+		}
+	})
+
+	return results, nil
+}
+
+// stress runs a single scenario.
+func stress(loans []Loan, baseline []*HPIdata, s Scenario, fromDt int, horizons []int) StressResult {
+	res := StressResult{Scenario: s.Name}
+
+	hpis := make([]*HPIdata, len(baseline))
+	for j, hd := range baseline {
+		hpis[j] = hd.Copy()
+		if e := hpis[j].ApplyScenario(s, fromDt); e != nil {
+			res.Err = fmt.Errorf("%s data: %w", hd.geoLevel, e)
+			return res
+		}
+	}
+
+	for _, h := range horizons {
+		dt := AddQtrs(fromDt, h)
+		marks := MarkLoans(loans, dt, hpis, 1)
+
+		var cltv []float64
+		for _, m := range marks {
+			if m.Err == nil {
+				cltv = append(cltv, m.CLTV)
+			}
+		}
+
+		d := LTVDistribution{Horizon: h, Dt: dt, Summary: SummarizeEquity(marks, nil)}
+		if len(cltv) > 0 {
+			sort.Float64s(cltv)
+			d.Mean, _ = meanSD(cltv)
+			d.Median = quantile(cltv, 0.5)
+			d.P90 = quantile(cltv, 0.9)
+			d.P99 = quantile(cltv, 0.99)
+			d.Max = cltv[len(cltv)-1]
+		}
+
+		res.Horizons = append(res.Horizons, d)
+	}
+
+	return res
+}
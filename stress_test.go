@@ -0,0 +1,47 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStressLTV(t *testing.T) {
+	baseline := []*HPIdata{testData(), testUS()}
+	loans := []Loan{
+		{Property: Property{ID: "a", Keys: []string{"CA", "USA"}, OrigDt: 20091, OrigValue: 100}, Balance: 80},
+		{Property: Property{ID: "b", Keys: []string{"TX", "USA"}, OrigDt: 20091, OrigValue: 100}, Balance: 90},
+	}
+
+	scenarios := []Scenario{
+		{Name: "base", Path: []float64{0, 0, 0, 0}},
+		{Name: "adverse", Path: []float64{-0.1, -0.1, -0.1, -0.1}},
+		{Name: "bad", Paths: map[string][]float64{"CA": {0}}},
+	}
+
+	res, e := StressLTV(loans, baseline, scenarios, 20094, []int{0, 4})
+	assert.Nil(t, e)
+	assert.Len(t, res, 3)
+
+	base, adverse := res[0], res[1]
+	assert.Nil(t, base.Err)
+	assert.Equal(t, 20104, base.Horizons[1].Dt)
+	assert.InDelta(t, base.Horizons[0].Mean, base.Horizons[1].Mean, 1e-9)
+
+	assert.Nil(t, adverse.Err)
+	assert.InDelta(t, base.Horizons[1].Mean/0.6561, adverse.Horizons[1].Mean, 1e-9)
+	assert.Equal(t, 2, adverse.Horizons[1].Summary.N)
+	assert.True(t, adverse.Horizons[1].Median <= adverse.Horizons[1].P99)
+	assert.True(t, adverse.Horizons[1].P99 <= adverse.Horizons[1].Max)
+
+	assert.NotNil(t, res[2].Err)
+
+	// the baseline is untouched
+	_, e = baseline[0].Index("CA", 20101)
+	assert.NotNil(t, e)
+
+	_, e = StressLTV(loans, nil, scenarios, 20094, []int{4})
+	assert.NotNil(t, e)
+	_, e = StressLTV(loans, baseline, scenarios, 20094, []int{-1})
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,168 @@
+package fhfa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IndexType is the methodology of an index.
+type IndexType int
+
+const (
+	// IndexTypeUnknown is the type of data whose source doesn't say.
+	IndexTypeUnknown IndexType = iota
+
+	// AllTransactions indices use purchases and refinance appraisals.
+	AllTransactions
+
+	// PurchaseOnly indices use purchases only.
+	PurchaseOnly
+
+	// Expanded indices add county recorder and FHA data to the purchase-only data.
+	Expanded
+
+	// DistressFree indices exclude distressed sales.
+	DistressFree
+)
+
+// String returns the name of it.
+func (it IndexType) String() string {
+	switch it {
+	case IndexTypeUnknown:
+		return "unknown"
+	case AllTransactions:
+		return "all-transactions"
+	case PurchaseOnly:
+		return "purchase-only"
+	case Expanded:
+		return "expanded-data"
+	case DistressFree:
+		return "distress-free"
+	default:
+		return fmt.Sprintf("IndexType(%d)", int(it))
+	}
+}
+
+// Adjustment is the seasonal adjustment of an index.
+type Adjustment int
+
+const (
+	// AdjustmentUnknown is the adjustment of data whose source doesn't say.
+	AdjustmentUnknown Adjustment = iota
+
+	// NSA is not seasonally adjusted.
+	NSA
+
+	// SA is seasonally adjusted.
+	SA
+)
+
+// String returns the name of a.
+func (a Adjustment) String() string {
+	switch a {
+	case AdjustmentUnknown:
+		return "unknown"
+	case NSA:
+		return "NSA"
+	case SA:
+		return "SA"
+	default:
+		return fmt.Sprintf("Adjustment(%d)", int(a))
+	}
+}
+
+// Frequency is the frequency at which an index is published.
+type Frequency int
+
+const (
+	// FrequencyUnknown is the frequency of data whose source doesn't say.
+	FrequencyUnknown Frequency = iota
+
+	// Quarterly indices are published each quarter.
+	Quarterly
+
+	// Monthly indices are published each month.
+	Monthly
+)
+
+// String returns the name of f.
+func (f Frequency) String() string {
+	switch f {
+	case FrequencyUnknown:
+		return "unknown"
+	case Quarterly:
+		return "quarterly"
+	case Monthly:
+		return "monthly"
+	default:
+		return fmt.Sprintf("Frequency(%d)", int(f))
+	}
+}
+
+// IndexTags describe an index.  Unknown values are compatible with anything.
+type IndexTags struct {
+	Type       IndexType
+	Adjustment Adjustment
+	Frequency  Frequency // frequency of the source; the data in this package is always quarterly
+}
+
+// String returns the tags as, e.g., "all-transactions NSA quarterly".
+func (t IndexTags) String() string {
+	return fmt.Sprintf("%s %s %s", t.Type, t.Adjustment, t.Frequency)
+}
+
+// Compatible returns an error if data tagged t and u shouldn't be combined, e.g. SA and NSA data.
+func (t IndexTags) Compatible(u IndexTags) error {
+	switch {
+	case t.Type != IndexTypeUnknown && u.Type != IndexTypeUnknown && t.Type != u.Type:
+		return fmt.Errorf("index types differ: %s, %s", t.Type, u.Type)
+	case t.Adjustment != AdjustmentUnknown && u.Adjustment != AdjustmentUnknown && t.Adjustment != u.Adjustment:
+		return fmt.Errorf("seasonal adjustments differ: %s, %s", t.Adjustment, u.Adjustment)
+	case t.Frequency != FrequencyUnknown && u.Frequency != FrequencyUnknown && t.Frequency != u.Frequency:
+		return fmt.Errorf("frequencies differ: %s, %s", t.Frequency, u.Frequency)
+	}
+
+	return nil
+}
+
+// Tags returns the index tags of hd.  FHFA workbooks are tagged from their headers when loaded; data from
+// other sources may be untagged.
+func (hd *HPIdata) Tags() IndexTags {
+	return hd.tags
+}
+
+// SetTags sets the index tags of hd.
+func (hd *HPIdata) SetTags(t IndexTags) {
+	hd.tags = t
+}
+
+// parseTags reads the index tags from the title and column headers of an FHFA workbook.  The quarterly
+// workbooks the package loads are all-transactions NSA unless they say otherwise.
+func parseTags(title string, cols []string) IndexTags {
+	h := strings.ToLower(title + " " + strings.Join(cols, " "))
+	h = strings.ReplaceAll(h, "-", " ")
+
+	t := IndexTags{Type: AllTransactions, Adjustment: NSA, Frequency: Quarterly}
+
+	switch {
+	case strings.Contains(h, "purchase only"):
+		t.Type = PurchaseOnly
+	case strings.Contains(h, "expanded"):
+		t.Type = Expanded
+	case strings.Contains(h, "distress free"):
+		t.Type = DistressFree
+	}
+
+	sa := strings.Contains(h, "(sa)") || strings.Contains(h, "seasonally adjusted")
+	if sa && !strings.Contains(h, "(nsa)") && !strings.Contains(h, "not seasonally adjusted") {
+		t.Adjustment = SA
+	}
+
+	for _, c := range cols {
+		if strings.EqualFold(strings.TrimSpace(c), "month") {
+			t.Frequency = Monthly
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,47 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	hd, e := parseXLSX(testRows(2, 4), 1)
+	assert.Nil(t, e)
+	assert.Equal(t, IndexTags{Type: AllTransactions, Adjustment: NSA, Frequency: Quarterly}, hd.Tags())
+	assert.Equal(t, "all-transactions NSA quarterly", hd.Tags().String())
+
+	tags := parseTags("Purchase-Only Indexes (Seasonally Adjusted)", []string{"State", "Year", "Quarter", "Index (SA)"})
+	assert.Equal(t, IndexTags{Type: PurchaseOnly, Adjustment: SA, Frequency: Quarterly}, tags)
+
+	tags = parseTags("Expanded-Data Indexes", []string{"Year", "Quarter", "Index (SA)", "Index (NSA)"})
+	assert.Equal(t, IndexTags{Type: Expanded, Adjustment: NSA, Frequency: Quarterly}, tags)
+
+	tags = parseTags("Distress-Free Indexes", []string{"Year", "Month", "Index"})
+	assert.Equal(t, IndexTags{Type: DistressFree, Adjustment: NSA, Frequency: Monthly}, tags)
+
+	r := testRows(1, 2)
+	r[1][2] = "Month"
+	_, e = parseXLSX(r, 1)
+	assert.NotNil(t, e)
+}
+
+func TestIndexTags_Compatible(t *testing.T) {
+	nsa := IndexTags{Type: AllTransactions, Adjustment: NSA, Frequency: Quarterly}
+	sa := nsa
+	sa.Adjustment = SA
+
+	assert.Nil(t, nsa.Compatible(IndexTags{}))
+	assert.NotNil(t, nsa.Compatible(sa))
+	assert.NotNil(t, nsa.Compatible(IndexTags{Type: PurchaseOnly}))
+
+	hd, ta := testData(), testData()
+	hd.SetTags(nsa)
+	ta.SetTags(sa)
+	assert.NotNil(t, hd.Append(ta))
+	_, e := MergeReleases(hd, ta)
+	assert.NotNil(t, e)
+
+	assert.Equal(t, nsa, hd.Copy().Tags())
+}
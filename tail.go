@@ -0,0 +1,120 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// TailStats describes the empirical distribution of k-quarter changes, the ratio minus 1, so -0.2 is a 20%
+// decline over the k quarters.  Changes over every k-quarter window in the history are used, so the windows
+// overlap.
+type TailStats struct {
+	Geo       string    // geo, blank for changes pooled across geos
+	Qtrs      int       // k, the length of the windows in quarters
+	N         int       // number of windows
+	Worst     float64   // largest decline (smallest change)
+	WorstGeo  string    // geo of the largest decline
+	WorstDt   int       // last quarter of the window with the largest decline (CCYYQ)
+	Probs     []float64 // probabilities of Quantiles, e.g. 0.01 for the 1st percentile
+	Quantiles []float64 // quantiles of the changes at Probs
+}
+
+// Tail returns the distribution of the k-quarter changes of h with the quantiles at probs, e.g. the worst
+// 8-quarter decline and the 1st percentile of 4-quarter changes for calibrating collateral haircuts.
+// Windows need the quarters at both ends.
+func (h *HPIseries) Tail(k int, probs []float64) (*TailStats, error) {
+	ts, _, e := h.tail(k, probs)
+
+	return ts, e
+}
+
+// TailAll returns Tail for every geo in hd, sorted by geo, and the distribution of the changes pooled across
+// geos, which is nil if no geo has a window.  Geos without a k-quarter window are reported in errs.
+func (hd *HPIdata) TailAll(k int, probs []float64) (tails []*TailStats, pooled *TailStats, errs map[string]error) {
+	errs = make(map[string]error)
+
+	var all []float64
+	for geo, s := range hd.All() {
+		ts, chg, e := s.tail(k, probs)
+		if e != nil {
+			errs[geo] = e
+			continue
+		}
+
+		if pooled == nil || ts.Worst < pooled.Worst {
+			pooled = &TailStats{Qtrs: k, Probs: probs, Worst: ts.Worst, WorstGeo: geo, WorstDt: ts.WorstDt}
+		}
+
+		ts.Geo = geo
+		tails = append(tails, ts)
+		all = append(all, chg...)
+	}
+
+	if pooled != nil {
+		pooled.finish(all)
+	}
+
+	return tails, pooled, errs
+}
+
+// tail returns Tail and the sorted k-quarter changes of h.
+func (h *HPIseries) tail(k int, probs []float64) (*TailStats, []float64, error) {
+	if e := checkTail(k, probs); e != nil {
+		return nil, nil, e
+	}
+
+	ts := &TailStats{Geo: h.geoCode, Qtrs: k, Probs: probs, Worst: math.Inf(1)}
+	chg := h.windows(k, ts)
+	if len(chg) == 0 {
+		return nil, nil, fmt.Errorf("geo %s has no %d-quarter windows", h.geoCode, k)
+	}
+
+	ts.finish(chg)
+
+	return ts, chg, nil
+}
+
+// windows returns the k-quarter changes of h and records the worst in ts.
+func (h *HPIseries) windows(k int, ts *TailStats) []float64 {
+	var chg []float64
+	for j, dt := range h.dates {
+		i := h.exact(AddQtrs(dt, -k))
+		if i < 0 || i >= j {
+			continue
+		}
+
+		c := h.indx[j]/h.indx[i] - 1
+		chg = append(chg, c)
+
+		if c < ts.Worst {
+			ts.Worst, ts.WorstGeo, ts.WorstDt = c, h.geoCode, dt
+		}
+	}
+
+	return chg
+}
+
+// finish fills in the count and quantiles from the changes chg, which it sorts.
+func (ts *TailStats) finish(chg []float64) {
+	sort.Float64s(chg)
+	ts.N = len(chg)
+	for _, p := range ts.Probs {
+		ts.Quantiles = append(ts.Quantiles, quantile(chg, p))
+	}
+}
+
+// checkTail validates the arguments of Tail
+func checkTail(k int, probs []float64) error {
+	if k < 1 {
+		return fmt.Errorf("window must be at least 1 quarter, got %d", k)
+	}
+
+	for _, p := range probs {
+		if p < 0 || p > 1 {
+			return fmt.Errorf("probability must be in [0,1], got %v", p)
+		}
+	}
+
+	return nil
+}
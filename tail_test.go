@@ -0,0 +1,46 @@
+package fhfa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_Tail(t *testing.T) {
+	dts := []int{20061, 20062, 20063, 20064, 20071, 20072}
+	indx := []float64{100, 120, 90, 72, 108, 108}
+	s, e := NewHPIseries("XX", "XX", dts, indx)
+	assert.Nil(t, e)
+
+	// 2-quarter changes: -0.1, -0.4, 0.2, 0.5
+	ts, e := s.Tail(2, []float64{0, 0.5, 1})
+	assert.Nil(t, e)
+	assert.Equal(t, 4, ts.N)
+	assert.InDelta(t, -0.4, ts.Worst, 1e-12)
+	assert.Equal(t, 20064, ts.WorstDt)
+	assert.InDelta(t, -0.4, ts.Quantiles[0], 1e-12)
+	assert.InDelta(t, 0.05, ts.Quantiles[1], 1e-12)
+	assert.InDelta(t, 0.5, ts.Quantiles[2], 1e-12)
+
+	_, e = s.Tail(6, nil)
+	assert.NotNil(t, e)
+	_, e = s.Tail(0, nil)
+	assert.NotNil(t, e)
+	_, e = s.Tail(1, []float64{2})
+	assert.NotNil(t, e)
+
+	hd := testData()
+	tails, pooled, errs := hd.TailAll(4, []float64{0.01})
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"CA", "NY", "TX"}, []string{tails[0].Geo, tails[1].Geo, tails[2].Geo})
+	assert.Equal(t, 36, tails[0].N)
+	assert.Equal(t, 108, pooled.N)
+	assert.Equal(t, "NY", pooled.WorstGeo)
+	assert.InDelta(t, math.Pow(0.995, 4)-1, pooled.Worst, 1e-12)
+	assert.InDelta(t, math.Pow(0.995, 4)-1, pooled.Quantiles[0], 1e-12)
+
+	_, pooled, errs = hd.TailAll(40, nil)
+	assert.Nil(t, pooled)
+	assert.Equal(t, 3, len(errs))
+}
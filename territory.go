@@ -0,0 +1,87 @@
+package fhfa
+
+import "fmt"
+
+// FHFA publishes indices for Puerto Rico but not for the other territories: Guam (GU), the U.S. Virgin
+// Islands (VI), American Samoa (AS) and the Northern Mariana Islands (MP).
+
+// territories are the postal codes of the territories without FHFA indices.
+var territories = []string{"AS", "GU", "MP", "VI"}
+
+// TerritoryPolicy determines how a FallbackChain handles property in a territory without FHFA indices.
+type TerritoryPolicy int
+
+const (
+	// TerritoryReject returns a *TerritoryError.
+	TerritoryReject TerritoryPolicy = iota
+
+	// TerritoryNational uses the national (us or mh) series.
+	TerritoryNational
+
+	// TerritoryPR uses the Puerto Rico series, then the national series.
+	TerritoryPR
+)
+
+// TerritoryError reports a lookup for a territory without FHFA indices.  It matches both
+// ErrUnsupportedTerritory and ErrGeoNotFound with errors.Is.
+type TerritoryError struct {
+	Territory string // postal code of the territory
+}
+
+// Error returns the error message.
+func (te *TerritoryError) Error() string {
+	return fmt.Sprintf("FHFA publishes no index for territory %s", te.Territory)
+}
+
+// Unwrap returns ErrUnsupportedTerritory and ErrGeoNotFound.
+func (te *TerritoryError) Unwrap() []error {
+	return []error{ErrUnsupportedTerritory, ErrGeoNotFound}
+}
+
+// IsTerritory returns true if st (a postal code, name or FIPS code) is a territory without FHFA indices.
+// Puerto Rico is not one.
+func IsTerritory(st string) bool {
+	s, e := NormalizeState(st)
+
+	return e == nil && in(s, territories)
+}
+
+// SetTerritoryPolicy sets how the chain handles property in GU, VI, AS and MP.  The default is TerritoryReject.
+func (fc *FallbackChain) SetTerritoryPolicy(p TerritoryPolicy) error {
+	if p < TerritoryReject || p > TerritoryPR {
+		return fmt.Errorf("invalid territory policy: %d", p)
+	}
+
+	fc.territory = p
+
+	return nil
+}
+
+// checkTerritory returns a *TerritoryError if state is a territory the chain rejects.
+func (fc *FallbackChain) checkTerritory(state string) error {
+	if fc.territory == TerritoryReject && IsTerritory(state) {
+		st, _ := NormalizeState(state)
+		return &TerritoryError{Territory: st}
+	}
+
+	return nil
+}
+
+// territoryKeys returns the keys for property in a territory.
+func (fc *FallbackChain) territoryKeys(zip3 string) []string {
+	keys := make([]string, len(fc.hpis))
+	for j, hd := range fc.hpis {
+		switch hd.geoLevel {
+		case "zip3":
+			keys[j] = zip3
+		case "pr":
+			if fc.territory == TerritoryPR {
+				keys[j] = "PR"
+			}
+		case "us", "mh":
+			keys[j] = "USA"
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,44 @@
+package fhfa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallbackChain_SetTerritoryPolicy(t *testing.T) {
+	pr := testLevel(testData(), "pr")
+	pr.series = map[string]*HPIseries{"PR": pr.series["NY"]}
+
+	fc, e := NewFallbackChain(testData(), pr, testUS())
+	assert.Nil(t, e)
+
+	_, _, e = fc.Lookup("969", "", "GU", 20051)
+	var te *TerritoryError
+	assert.True(t, errors.As(e, &te))
+	assert.Equal(t, "GU", te.Territory)
+	assert.True(t, errors.Is(e, ErrUnsupportedTerritory))
+	assert.True(t, errors.Is(e, ErrGeoNotFound))
+
+	_, _, e = fc.Change("008", "", "VI", 20051, 20052)
+	assert.True(t, errors.Is(e, ErrUnsupportedTerritory))
+
+	assert.Nil(t, fc.SetTerritoryPolicy(TerritoryNational))
+	assert.Equal(t, []string{"", "", "USA"}, fc.Keys("969", "", "GU"))
+	_, level, e := fc.Lookup("969", "", "GU", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, "us", level)
+
+	assert.Nil(t, fc.SetTerritoryPolicy(TerritoryPR))
+	assert.Equal(t, []string{"", "PR", "USA"}, fc.Keys("969", "", "66"))
+	_, level, e = fc.Lookup("969", "", "GU", 20051)
+	assert.Nil(t, e)
+	assert.Equal(t, "pr", level)
+
+	assert.NotNil(t, fc.SetTerritoryPolicy(TerritoryPolicy(9)))
+
+	assert.True(t, IsTerritory("Guam"))
+	assert.False(t, IsTerritory("PR"))
+	assert.False(t, IsTerritory("TX"))
+}
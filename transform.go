@@ -0,0 +1,33 @@
+package fhfa
+
+import "fmt"
+
+// Apply returns a copy of h with each index value replaced by f(dt, value), where dt is CCYYQ.  Use it
+// for custom adjustments such as haircuts or caps.
+func (h *HPIseries) Apply(f func(dt int, v float64) float64) *HPIseries {
+	out := h.Copy()
+	for j, dt := range out.dates {
+		out.indx[j] = f(dt, out.indx[j])
+		if dt == out.lastDt {
+			out.lastIndx = out.indx[j]
+		}
+	}
+
+	return out
+}
+
+// MapSeries returns a copy of hd with every series replaced by f(series).  f is passed a copy, so it may
+// modify its argument.  An error is returned if f returns nil or an empty series.
+func (hd *HPIdata) MapSeries(f func(*HPIseries) *HPIseries) (*HPIdata, error) {
+	out := hd.Copy()
+	for geo, s := range out.series {
+		m := f(s)
+		if m == nil || len(m.dates) == 0 {
+			return nil, fmt.Errorf("geo %s: transform returned no data", geo)
+		}
+
+		out.series[geo] = m
+	}
+
+	return out, nil
+}
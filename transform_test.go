@@ -0,0 +1,36 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIseries_Apply(t *testing.T) {
+	hd := testData()
+	ca, _ := hd.Geo("CA")
+
+	h := ca.Apply(func(dt int, v float64) float64 { return 0.9 * v })
+	v, _ := h.Index(20051)
+	vc, _ := ca.Index(20051)
+	assert.InDelta(t, 0.9*vc, v, 1e-9)
+
+	_, last := h.Last()
+	_, lastCA := ca.Last()
+	assert.InDelta(t, 0.9*lastCA, last, 1e-9)
+
+	m, e := hd.MapSeries(func(s *HPIseries) *HPIseries {
+		w, _ := s.Window(20051, 20054)
+		return w
+	})
+	assert.Nil(t, e)
+	tx, _ := m.Geo("TX")
+	assert.Equal(t, 4, tx.Len())
+
+	// hd is unchanged
+	tx, _ = hd.Geo("TX")
+	assert.Equal(t, 40, tx.Len())
+
+	_, e = hd.MapSeries(func(s *HPIseries) *HPIseries { return nil })
+	assert.NotNil(t, e)
+}
@@ -0,0 +1,133 @@
+package fhfa
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Issue is a problem found by Validate.  Dt is 0 if the problem is not specific to a quarter.
+type Issue struct {
+	Geo     string // geo of the series
+	Dt      int    // date (CCYYQ) of the problem
+	Problem string // description of the problem
+}
+
+// ValidationReport holds the results of Validate.
+type ValidationReport struct {
+	Series int     // number of series checked
+	Issues []Issue // problems found, sorted by geo and date
+}
+
+// OK returns true if no problems were found.
+func (r *ValidationReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Error returns the report as an error, nil if no problems were found.
+func (r *ValidationReport) Error() error {
+	if r.OK() {
+		return nil
+	}
+
+	return fmt.Errorf("validation found %d problems, first: %s", len(r.Issues), r.Issues[0])
+}
+
+// String returns a description of the issue.
+func (i Issue) String() string {
+	if i.Dt == 0 {
+		return fmt.Sprintf("geo %s: %s", i.Geo, i.Problem)
+	}
+
+	return fmt.Sprintf("geo %s at %d: %s", i.Geo, i.Dt, i.Problem)
+}
+
+// Validate checks the integrity of hd.  Each series must be non-empty with contiguous quarters, positive
+// index values and a Last() date within the series.  No two series may share a geo code.  If maxChange is
+// positive, quarter-over-quarter moves larger than maxChange in absolute value (e.g. 0.15 is 15%) are also
+// reported.
+func (hd *HPIdata) Validate(maxChange float64) *ValidationReport {
+	r := &ValidationReport{Series: len(hd.series)}
+
+	codes := make(map[string]string)
+	for geo, s := range hd.series {
+		r.Issues = append(r.Issues, s.validate(geo, maxChange)...)
+
+		if s.geoCode == "" {
+			continue
+		}
+
+		if other, ok := codes[s.geoCode]; ok {
+			// report the duplicate under the larger key so the report is deterministic
+			r.Issues = append(r.Issues, Issue{Geo: max(geo, other),
+				Problem: fmt.Sprintf("geo code %s duplicates geo %s", s.geoCode, min(geo, other))})
+			continue
+		}
+
+		codes[s.geoCode] = geo
+	}
+
+	sort.SliceStable(r.Issues, func(i, j int) bool {
+		if r.Issues[i].Geo != r.Issues[j].Geo {
+			return r.Issues[i].Geo < r.Issues[j].Geo
+		}
+
+		return r.Issues[i].Dt < r.Issues[j].Dt
+	})
+
+	return r
+}
+
+// Validate checks the integrity of h.  See HPIdata.Validate.
+func (h *HPIseries) Validate(maxChange float64) *ValidationReport {
+	return &ValidationReport{Series: 1, Issues: h.validate(h.geoCode, maxChange)}
+}
+
+// validate returns the problems in h, reported under geo.
+func (h *HPIseries) validate(geo string, maxChange float64) []Issue {
+	if len(h.dates) == 0 {
+		return []Issue{{Geo: geo, Problem: "series is empty"}}
+	}
+
+	if len(h.dates) != len(h.indx) {
+		return []Issue{{Geo: geo, Problem: fmt.Sprintf("%d dates but %d index values", len(h.dates), len(h.indx))}}
+	}
+
+	var issues []Issue
+	if h.prov != nil && len(h.prov) != len(h.dates) {
+		issues = append(issues, Issue{Geo: geo, Problem: "provenance does not match dates"})
+	}
+
+	for j, dt := range h.dates {
+		if qtr := dt % 10; qtr < 1 || qtr > 4 {
+			issues = append(issues, Issue{Geo: geo, Dt: dt, Problem: "illegal date"})
+		}
+
+		if v := h.indx[j]; !(v > 0) || math.IsInf(v, 1) {
+			issues = append(issues, Issue{Geo: geo, Dt: dt, Problem: fmt.Sprintf("index value %v is not positive", v)})
+		}
+
+		if j == 0 {
+			continue
+		}
+
+		if dt <= h.dates[j-1] || QtrDiff(h.dates[j-1], dt) != 1 {
+			issues = append(issues, Issue{Geo: geo, Dt: dt, Problem: fmt.Sprintf("does not follow %d", h.dates[j-1])})
+			continue
+		}
+
+		if chg := h.indx[j]/h.indx[j-1] - 1; maxChange > 0 && math.Abs(chg) > maxChange {
+			issues = append(issues, Issue{Geo: geo, Dt: dt, Problem: fmt.Sprintf("quarterly change %0.4f exceeds %0.4f", chg, maxChange)})
+		}
+	}
+
+	// scan rather than search since the dates may be out of order
+	if j := slices.Index(h.dates, h.lastDt); j < 0 {
+		issues = append(issues, Issue{Geo: geo, Dt: h.lastDt, Problem: "last loaded date not in series"})
+	} else if h.indx[j] != h.lastIndx {
+		issues = append(issues, Issue{Geo: geo, Dt: h.lastDt, Problem: "last loaded value does not match series"})
+	}
+
+	return issues
+}
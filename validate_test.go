@@ -0,0 +1,55 @@
+package fhfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHPIdata_Validate(t *testing.T) {
+	hd := testData()
+	r := hd.Validate(0.15)
+	assert.True(t, r.OK())
+	assert.Nil(t, r.Error())
+	assert.Equal(t, 3, r.Series)
+
+	ca, _ := hd.Geo("CA")
+	ca.indx[5] = -1
+	ca.indx[10] *= 1.5
+
+	tx, _ := hd.Geo("TX")
+	tx.dates[3] = 20211
+	tx.geoCode = "NY"
+
+	r = hd.Validate(0.15)
+	assert.False(t, r.OK())
+	assert.NotNil(t, r.Error())
+
+	var ca5, ca10, tx3, dup bool
+	for _, is := range r.Issues {
+		switch {
+		case is.Geo == "CA" && is.Dt == 20012:
+			ca5 = true
+		case is.Geo == "CA" && is.Dt == 20023:
+			ca10 = true
+		case is.Geo == "TX" && is.Dt == 20211:
+			tx3 = true
+		case is.Geo == "TX" && is.Dt == 0:
+			dup = true
+		}
+	}
+
+	assert.True(t, ca5)
+	assert.True(t, ca10)
+	assert.True(t, tx3)
+	assert.True(t, dup)
+
+	// no threshold, no move check
+	ny, _ := hd.Geo("NY")
+	ny.indx[10] *= 2
+	assert.True(t, ny.Validate(0).OK())
+	assert.False(t, ny.Validate(0.15).OK())
+
+	ny.lastIndx = 1
+	assert.False(t, ny.Validate(0).OK())
+}
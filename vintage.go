@@ -0,0 +1,185 @@
+package fhfa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// vintageIndex is the name of the metadata file of a VintageStore.
+const vintageIndex = "vintages.json"
+
+// Vintage describes a snapshot in a VintageStore.
+type Vintage struct {
+	GeoLevel string    `json:"geoLevel"` // geo level of the data
+	Released time.Time `json:"released"` // date the data was published
+	File     string    `json:"file"`     // binary cache file, relative to the store directory
+	Source   string    `json:"source"`   // source the data was loaded from
+	Latest   int       `json:"latest"`   // latest quarter (CCYYQ) in the data
+}
+
+// VintageStore keeps snapshots of HPIdata keyed by geo level and release date in a directory, as binary cache
+// files (see SaveBinary) and a JSON index, so backtests can use the data as it was known on a given date.
+// It is safe for concurrent use within a process.
+type VintageStore struct {
+	mu       sync.RWMutex
+	dir      string
+	vintages []Vintage // sorted by geo level and release date
+}
+
+// OpenVintageStore opens the store in dir, creating it if needed.
+func OpenVintageStore(dir string) (*VintageStore, error) {
+	if e := os.MkdirAll(dir, 0o755); e != nil {
+		return nil, e
+	}
+
+	vs := &VintageStore{dir: dir}
+
+	b, e := os.ReadFile(filepath.Join(dir, vintageIndex))
+	if errors.Is(e, os.ErrNotExist) {
+		return vs, nil
+	}
+
+	if e != nil {
+		return nil, e
+	}
+
+	if e := json.Unmarshal(b, &vs.vintages); e != nil {
+		return nil, fmt.Errorf("%s: %w", vintageIndex, e)
+	}
+
+	vs.sort()
+
+	return vs, nil
+}
+
+// Put saves hd as the vintage of its geo level released on released, replacing any vintage with the same
+// geo level and release date.
+func (vs *VintageStore) Put(released time.Time, hd *HPIdata) error {
+	released = released.UTC().Truncate(24 * time.Hour)
+	v := Vintage{
+		GeoLevel: hd.geoLevel,
+		Released: released,
+		File:     fmt.Sprintf("%s_%s.bin", hd.geoLevel, released.Format("2006-01-02")),
+		Source:   hd.source,
+		Latest:   hd.latest(),
+	}
+
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	// replace the snapshot by renaming, since readers may have the old file mapped
+	file := filepath.Join(vs.dir, v.File)
+	if e := hd.SaveBinary(file + ".tmp"); e != nil {
+		_ = os.Remove(file + ".tmp")
+		return e
+	}
+
+	if e := os.Rename(file+".tmp", file); e != nil {
+		return e
+	}
+
+	vintages := []Vintage{v}
+	for _, old := range vs.vintages {
+		if old.GeoLevel != v.GeoLevel || !old.Released.Equal(v.Released) {
+			vintages = append(vintages, old)
+		}
+	}
+
+	b, e := json.MarshalIndent(vintages, "", "  ")
+	if e != nil {
+		return e
+	}
+
+	// write the index atomically, so readers never see part of it
+	tmp := filepath.Join(vs.dir, vintageIndex+".tmp")
+	if e := os.WriteFile(tmp, b, 0o644); e != nil {
+		return e
+	}
+
+	if e := os.Rename(tmp, filepath.Join(vs.dir, vintageIndex)); e != nil {
+		return e
+	}
+
+	vs.vintages = vintages
+	vs.sort()
+
+	return nil
+}
+
+// Vintages returns the vintages of geoLevel, oldest first.
+func (vs *VintageStore) Vintages(geoLevel string) []Vintage {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	var out []Vintage
+	for _, v := range vs.vintages {
+		if v.GeoLevel == geoLevel {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// Vintage returns the latest vintage of geoLevel released on or before t.
+func (vs *VintageStore) Vintage(geoLevel string, t time.Time) (Vintage, error) {
+	vints := vs.Vintages(geoLevel)
+	j := sort.Search(len(vints), func(k int) bool { return vints[k].Released.After(t) })
+	if j == 0 {
+		return Vintage{}, fmt.Errorf("no %s vintage released by %s", geoLevel, t.Format("2006-01-02"))
+	}
+
+	return vints[j-1], nil
+}
+
+// AsOf returns the data of geoLevel as known at t: the latest vintage released on or before t.
+func (vs *VintageStore) AsOf(geoLevel string, t time.Time) (*HPIdata, Vintage, error) {
+	bc, v, e := vs.OpenAsOf(geoLevel, t)
+	if e != nil {
+		return nil, Vintage{}, e
+	}
+	defer bc.Close()
+
+	hd, e := bc.Data()
+	if e != nil {
+		return nil, Vintage{}, e
+	}
+
+	hd.source = v.Source
+
+	return hd, v, nil
+}
+
+// OpenAsOf opens the binary cache of the vintage AsOf would return, for lookups without loading the data.
+// Close it when done.
+func (vs *VintageStore) OpenAsOf(geoLevel string, t time.Time) (*BinaryCache, Vintage, error) {
+	v, e := vs.Vintage(geoLevel, t)
+	if e != nil {
+		return nil, Vintage{}, e
+	}
+
+	bc, e := OpenBinary(filepath.Join(vs.dir, v.File))
+	if e != nil {
+		return nil, Vintage{}, e
+	}
+
+	return bc, v, nil
+}
+
+// sort sorts the vintages by geo level and release date.
+func (vs *VintageStore) sort() {
+	sort.Slice(vs.vintages, func(i, j int) bool {
+		a, b := vs.vintages[i], vs.vintages[j]
+		if a.GeoLevel != b.GeoLevel {
+			return a.GeoLevel < b.GeoLevel
+		}
+
+		return a.Released.Before(b.Released)
+	})
+}
@@ -0,0 +1,69 @@
+package fhfa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVintageStore(t *testing.T) {
+	dir := t.TempDir()
+	vs, e := OpenVintageStore(dir)
+	assert.Nil(t, e)
+
+	may := time.Date(2010, 5, 25, 0, 0, 0, 0, time.UTC)
+	aug := time.Date(2010, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	orig := testData()
+	for _, s := range orig.All() {
+		s.dates, s.indx = s.dates[:39], s.indx[:39]
+		s.lastDt, s.lastIndx = s.dates[38], s.indx[38]
+	}
+	assert.Nil(t, vs.Put(may, orig))
+
+	rev := testData()
+	s, _ := rev.Geo("TX")
+	s.indx[38] = 1
+	assert.Nil(t, vs.Put(aug, rev))
+
+	// reopen to read the index from disk
+	vs, e = OpenVintageStore(dir)
+	assert.Nil(t, e)
+	assert.Len(t, vs.Vintages("state"), 2)
+
+	hd, v, e := vs.AsOf("state", time.Date(2010, 7, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, e)
+	assert.Equal(t, may, v.Released)
+	assert.Equal(t, 20093, v.Latest)
+	x, _ := hd.Index("TX", 20093)
+	assert.NotEqual(t, 1.0, x)
+
+	_, v, e = vs.AsOf("state", aug)
+	assert.Nil(t, e)
+	assert.Equal(t, aug, v.Released)
+
+	_, _, e = vs.AsOf("state", may.AddDate(0, 0, -1))
+	assert.NotNil(t, e)
+
+	_, _, e = vs.AsOf("metro", aug)
+	assert.NotNil(t, e)
+
+	// replacing a vintage keeps one entry and doesn't disturb a reader of the old snapshot
+	bc, _, e := vs.OpenAsOf("state", aug)
+	assert.Nil(t, e)
+	defer bc.Close()
+
+	s.indx[38] = 2
+	assert.Nil(t, vs.Put(aug, rev))
+	assert.Len(t, vs.Vintages("state"), 2)
+
+	x, e = bc.Index("TX", 20093)
+	assert.Nil(t, e)
+	assert.Equal(t, 1.0, x)
+
+	hd, _, e = vs.AsOf("state", aug)
+	assert.Nil(t, e)
+	x, _ = hd.Index("TX", 20093)
+	assert.Equal(t, 2.0, x)
+}
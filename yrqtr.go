@@ -0,0 +1,200 @@
+package fhfa
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// YrQtr is a quarter stored as CCYYQ (e.g. 20231 is the first quarter of 2023).  Every function taking a
+// CCYYQ int has a YrQtr counterpart or accepts int(yq), so YrQtr can be adopted gradually.
+type YrQtr int
+
+// NewYrQtr returns the YrQtr for quarter qtr (1-4) of year.
+func NewYrQtr(year, qtr int) (YrQtr, error) {
+	yq := YrQtr(10*year + qtr)
+	if !yq.Valid() {
+		return 0, fmt.Errorf("%w: year %d, quarter %d", ErrBadDate, year, qtr)
+	}
+
+	return yq, nil
+}
+
+// YrQtrOf returns the quarter containing t.
+func YrQtrOf(t time.Time) YrQtr {
+	return YrQtr(ToYrQtr(t))
+}
+
+// ParseYrQtr parses a quarter written as 2023Q1, 2023-Q1, 2023 Q1, Q1 2023 or Q1-2023 (in either case) or as
+// the CCYYQ int 20231.
+func ParseYrQtr(s string) (YrQtr, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+
+	var yr, qtr string
+	switch {
+	case strings.HasPrefix(str, "Q") && len(str) > 2:
+		yr, qtr = str[2:], str[1:2]
+	case strings.Contains(str, "Q"):
+		yr, qtr, _ = strings.Cut(str, "Q")
+	default:
+		dt, e := strconv.Atoi(str)
+		if e != nil || !YrQtr(dt).Valid() {
+			return 0, fmt.Errorf("cannot parse %q as a quarter", s)
+		}
+
+		return YrQtr(dt), nil
+	}
+
+	yr = strings.Trim(yr, " -")
+	y, e1 := strconv.Atoi(yr)
+	q, e2 := strconv.Atoi(qtr)
+	if e1 != nil || e2 != nil || len(yr) != 4 || len(qtr) != 1 {
+		return 0, fmt.Errorf("cannot parse %q as a quarter", s)
+	}
+
+	return NewYrQtr(y, q)
+}
+
+// FormatYrQtr formats dt (CCYYQ) according to layout, in which %Y is replaced by the 4-digit year, %y by the
+// 2-digit year and %q by the quarter.  For example, "%YQ%q" gives 2023Q1 and "Q%q %Y" gives Q1 2023.
+func FormatYrQtr(dt int, layout string) string {
+	yq := YrQtr(dt)
+
+	return strings.NewReplacer(
+		"%Y", fmt.Sprintf("%04d", yq.Year()),
+		"%y", fmt.Sprintf("%02d", yq.Year()%100),
+		"%q", strconv.Itoa(yq.Quarter()),
+	).Replace(layout)
+}
+
+// minYear and maxYear bound the years accepted by Valid and ToTime.
+const (
+	minYear = 1960
+	maxYear = 2060
+)
+
+// Valid returns true if the quarter is between 1 and 4 and the year is between minYear and maxYear.
+func (yq YrQtr) Valid() bool {
+	yr, qtr := yq.Year(), yq.Quarter()
+
+	return yq > 0 && yr >= minYear && yr <= maxYear && qtr >= 1 && qtr <= 4
+}
+
+// Year returns the year of yq.
+func (yq YrQtr) Year() int {
+	return int(yq) / 10
+}
+
+// Quarter returns the quarter (1-4) of yq.
+func (yq YrQtr) Quarter() int {
+	return int(yq) % 10
+}
+
+// Next returns the following quarter.
+func (yq YrQtr) Next() YrQtr {
+	return yq.Add(1)
+}
+
+// Prev returns the preceding quarter.
+func (yq YrQtr) Prev() YrQtr {
+	return yq.Add(-1)
+}
+
+// Add returns the quarter n quarters after yq.  n may be negative.
+func (yq YrQtr) Add(n int) YrQtr {
+	return YrQtr(AddQtrs(int(yq), n))
+}
+
+// Diff returns the number of quarters from u to yq, which is negative if yq precedes u.
+func (yq YrQtr) Diff(u YrQtr) int {
+	return QtrDiffSigned(int(u), int(yq))
+}
+
+// Time returns the first day of the quarter.
+func (yq YrQtr) Time() time.Time {
+	return time.Date(yq.Year(), time.Month(1+3*(yq.Quarter()-1)), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// String returns the quarter in the form 2023Q1.
+func (yq YrQtr) String() string {
+	if !yq.Valid() {
+		return fmt.Sprintf("YrQtr(%d)", int(yq))
+	}
+
+	return fmt.Sprintf("%dQ%d", yq.Year(), yq.Quarter())
+}
+
+// Format formats yq according to layout.  See FormatYrQtr.
+func (yq YrQtr) Format(layout string) string {
+	return FormatYrQtr(int(yq), layout)
+}
+
+// MarshalText returns the quarter in the form 2023Q1.
+func (yq YrQtr) MarshalText() ([]byte, error) {
+	if !yq.Valid() {
+		return nil, badDate(int(yq))
+	}
+
+	return []byte(yq.String()), nil
+}
+
+// UnmarshalText parses any form accepted by ParseYrQtr.
+func (yq *YrQtr) UnmarshalText(text []byte) error {
+	v, e := ParseYrQtr(string(text))
+	if e != nil {
+		return e
+	}
+
+	*yq = v
+
+	return nil
+}
+
+// UnmarshalJSON accepts either a string in any form accepted by ParseYrQtr or a CCYYQ number.
+func (yq *YrQtr) UnmarshalJSON(data []byte) error {
+	return yq.UnmarshalText(bytes.Trim(data, `"`))
+}
+
+// IndexYQ returns the index at yq.  See Index.
+func (h *HPIseries) IndexYQ(yq YrQtr) (float64, error) {
+	if !yq.Valid() {
+		return 0, badDate(int(yq))
+	}
+
+	return h.Index(int(yq))
+}
+
+// ChangeYQ returns the ratio of the index at end to start.  See Change.
+func (h *HPIseries) ChangeYQ(start, end YrQtr) (float64, error) {
+	if !start.Valid() {
+		return 0, badDate(int(start))
+	}
+
+	if !end.Valid() {
+		return 0, badDate(int(end))
+	}
+
+	return h.Change(int(start), int(end))
+}
+
+// IndexYQ returns the index for geo at yq.  See Index.
+func (hd *HPIdata) IndexYQ(geo string, yq YrQtr) (float64, error) {
+	s, e := hd.Geo(geo)
+	if e != nil {
+		return 0, e
+	}
+
+	return s.IndexYQ(yq)
+}
+
+// ChangeYQ returns the ratio of the index for geo at end to start.  See Change.
+func (hd *HPIdata) ChangeYQ(geo string, start, end YrQtr) (float64, error) {
+	s, e := hd.Geo(geo)
+	if e != nil {
+		return 0, e
+	}
+
+	return s.ChangeYQ(start, end)
+}
@@ -0,0 +1,88 @@
+package fhfa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYrQtr(t *testing.T) {
+	yq, e := NewYrQtr(2023, 1)
+	assert.Nil(t, e)
+	assert.Equal(t, YrQtr(20231), yq)
+	assert.Equal(t, "2023Q1", yq.String())
+	assert.Equal(t, 2023, yq.Year())
+	assert.Equal(t, 1, yq.Quarter())
+	assert.Equal(t, YrQtr(20232), yq.Next())
+	assert.Equal(t, YrQtr(20224), yq.Prev())
+	assert.Equal(t, YrQtr(20213), yq.Add(-6))
+	assert.Equal(t, 6, yq.Diff(20213))
+	assert.Equal(t, -6, YrQtr(20213).Diff(yq))
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), yq.Time())
+	assert.Equal(t, YrQtr(20233), YrQtrOf(time.Date(2023, 8, 15, 0, 0, 0, 0, time.UTC)))
+
+	_, e = NewYrQtr(2023, 5)
+	assert.NotNil(t, e)
+	assert.False(t, YrQtr(20235).Valid())
+	assert.Equal(t, "YrQtr(20235)", YrQtr(20235).String())
+
+	// Valid and ToTime accept the same range
+	for _, dt := range []int{19594, 19601, 20604, 20611} {
+		_, e = ToTime(dt)
+		assert.Equal(t, YrQtr(dt).Valid(), e == nil, dt)
+	}
+	assert.True(t, YrQtr(20604).Valid())
+	assert.False(t, YrQtr(20611).Valid())
+
+	for _, s := range []string{"2023Q1", "2023q1", " 20231 ", "2023-Q1", "2023 Q1", "Q1 2023", "q1-2023"} {
+		yq, e = ParseYrQtr(s)
+		assert.Nil(t, e)
+		assert.Equal(t, YrQtr(20231), yq)
+	}
+
+	for _, s := range []string{"2023Q5", "20235", "2023Q12", "abc", "Q1 23", "Q"} {
+		_, e = ParseYrQtr(s)
+		assert.NotNil(t, e)
+	}
+}
+
+func TestFormatYrQtr(t *testing.T) {
+	assert.Equal(t, "2023Q1", FormatYrQtr(20231, "%YQ%q"))
+	assert.Equal(t, "Q4 2009", FormatYrQtr(20094, "Q%q %Y"))
+	assert.Equal(t, "09-4", YrQtr(20094).Format("%y-%q"))
+
+	type cfg struct {
+		Start YrQtr `json:"start"`
+		End   YrQtr `json:"end"`
+	}
+
+	b, e := json.Marshal(cfg{Start: 20231, End: 20234})
+	assert.Nil(t, e)
+	assert.Equal(t, `{"start":"2023Q1","end":"2023Q4"}`, string(b))
+
+	var c cfg
+	assert.Nil(t, json.Unmarshal([]byte(`{"start":"Q2 2020","end":20213}`), &c))
+	assert.Equal(t, cfg{Start: 20202, End: 20213}, c)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"start":"2020Q5"}`), &c))
+
+	_, e = json.Marshal(cfg{Start: 20235})
+	assert.NotNil(t, e)
+}
+
+func TestHPIdata_IndexYQ(t *testing.T) {
+	hd := testData()
+	v, e := hd.IndexYQ("CA", 20051)
+	assert.Nil(t, e)
+	v1, _ := hd.Index("CA", 20051)
+	assert.Equal(t, v1, v)
+
+	_, e = hd.IndexYQ("CA", 20055)
+	assert.NotNil(t, e)
+
+	chg, e := hd.ChangeYQ("TX", 20051, 20061)
+	assert.Nil(t, e)
+	assert.InDelta(t, 1.01*1.01*1.01*1.01, chg, 1e-9)
+}
@@ -0,0 +1,133 @@
+package fhfa
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LoadZip loads the FHFA xlsx and csv files in the zip archive source whose base names match pattern (see
+// path.Match, e.g. "*3zip*"), in name order.  A blank pattern loads every xlsx and csv file.  The source of
+// each HPIdata is source!name, where name is the file within the archive.
+func LoadZip(source, pattern string) ([]*HPIdata, error) {
+	if _, e := path.Match(pattern, ""); e != nil {
+		return nil, e
+	}
+
+	local, cleanup, e := localCopy(source)
+	if e != nil {
+		return nil, e
+	}
+	defer cleanup()
+
+	zr, e := zip.OpenReader(local)
+	if e != nil {
+		return nil, e
+	}
+	defer zr.Close()
+
+	var files []*zip.File
+	for _, f := range zr.File {
+		base := path.Base(f.Name)
+		if ext := strings.ToLower(path.Ext(base)); f.FileInfo().IsDir() || (ext != ".xlsx" && ext != ".csv") {
+			continue
+		}
+
+		if ok, _ := path.Match(pattern, base); pattern != "" && !ok {
+			continue
+		}
+
+		files = append(files, f)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no xlsx or csv files matching %q in %s", pattern, source)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	dir, e := os.MkdirTemp("", "fhfa")
+	if e != nil {
+		return nil, e
+	}
+	defer os.RemoveAll(dir)
+
+	var hds []*HPIdata
+	for j, f := range files {
+		// files may share a base name in different folders of the archive
+		file := filepath.Join(dir, fmt.Sprintf("%d_%s", j, path.Base(f.Name)))
+		if e := unzipFile(f, file); e != nil {
+			return nil, e
+		}
+
+		hd, e := loadSource(file)
+		if e != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, e)
+		}
+
+		hd.source = source + "!" + f.Name
+		hds = append(hds, hd)
+	}
+
+	return hds, nil
+}
+
+// loadZip loads the single xlsx or csv file in the zip archive source, for Load.
+func loadZip(source string) (*HPIdata, error) {
+	hds, e := LoadZip(source, "")
+	if e != nil {
+		return nil, e
+	}
+
+	if len(hds) > 1 {
+		return nil, fmt.Errorf("%s has %d files, use LoadZip to select them", source, len(hds))
+	}
+
+	return hds[0], nil
+}
+
+// unzipFile writes the contents of f to the local file local.
+func unzipFile(f *zip.File, local string) error {
+	r, e := f.Open()
+	if e != nil {
+		return e
+	}
+	defer r.Close()
+
+	file, e := os.Create(local)
+	if e != nil {
+		return e
+	}
+	defer file.Close()
+
+	if _, e := io.Copy(file, r); e != nil {
+		return e
+	}
+
+	return file.Close()
+}
+
+// readCSV returns the rows of a CSV file laid out as the FHFA workbooks are.
+func readCSV(localFile string) ([][]string, error) {
+	file, e := os.Open(localFile)
+	if e != nil {
+		return nil, e
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
+
+	return r.ReadAll()
+}
+
+// isZip returns true if source is a zip archive, going by its extension.
+func isZip(source string) bool {
+	return strings.HasSuffix(strings.ToLower(source), ".zip")
+}
@@ -0,0 +1,77 @@
+package fhfa
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testZip writes a zip archive to file holding the named files, which are CSVs of testRows except for .txt files.
+func testZip(t *testing.T, file string, names ...string) {
+	f, e := os.Create(file)
+	assert.Nil(t, e)
+	zw := zip.NewWriter(f)
+
+	for j, name := range names {
+		w, e := zw.Create(name)
+		assert.Nil(t, e)
+
+		if filepath.Ext(name) == ".txt" {
+			_, _ = w.Write([]byte("not data"))
+			continue
+		}
+
+		cw := csv.NewWriter(w)
+		assert.Nil(t, cw.WriteAll(testRows(j+2, 8)))
+	}
+
+	assert.Nil(t, zw.Close())
+	assert.Nil(t, f.Close())
+}
+
+func TestLoadZip(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "hpi.zip")
+	testZip(t, file, "HPI_AT_3zip.csv", "readme.txt", "old/HPI_AT_3zip_2023.csv")
+
+	hds, e := LoadZip(file, "")
+	assert.Nil(t, e)
+	assert.Len(t, hds, 2)
+	assert.Equal(t, file+"!HPI_AT_3zip.csv", hds[0].Source())
+	assert.Equal(t, "zip3", hds[0].GeoLevel())
+	assert.Equal(t, 2, hds[0].Len())
+	assert.Equal(t, 4, hds[1].Len())
+
+	hds, e = LoadZip(file, "*2023*")
+	assert.Nil(t, e)
+	assert.Len(t, hds, 1)
+	assert.Equal(t, 4, hds[0].Len())
+
+	_, e = LoadZip(file, "*.xlsx")
+	assert.NotNil(t, e)
+	_, e = LoadZip(file, "[")
+	assert.NotNil(t, e)
+
+	// Load takes an archive with one file
+	_, e = Load(file)
+	assert.NotNil(t, e)
+
+	one := filepath.Join(dir, "one.zip")
+	testZip(t, one, "HPI_AT_3zip.csv")
+	hd, e := Load(one)
+	assert.Nil(t, e)
+	assert.Equal(t, 2, hd.Len())
+
+	srv := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer srv.Close()
+
+	hd, e = Load(srv.URL + "/one.zip")
+	assert.Nil(t, e)
+	assert.Equal(t, 2, hd.Len())
+}